@@ -2,9 +2,12 @@ package main
 
 import (
 	"crypto/rsa"
+	"fmt"
 	"net/url"
 	"os"
 	"runtime"
+	"sync"
+	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/pkg/errors"
@@ -20,11 +23,17 @@ type Agent struct {
 	pubKey        *rsa.PublicKey
 	Identity      *models.DeviceIdentity
 	Info          *models.DeviceInfo
-	authData      *models.DeviceAuthResponse
 	cli           client.Client
 	serverInfo    *models.Info
 	serverAddress *url.URL
 	sessions      []string
+
+	mu          sync.Mutex // guards below
+	authData    *models.DeviceAuthResponse
+	listener    *revdial.Listener
+	connectedAt time.Time
+	reconnects  int
+	update      string
 }
 
 func NewAgent(opts *ConfigOptions) (*Agent, error) {
@@ -37,9 +46,24 @@ func NewAgent(opts *ConfigOptions) (*Agent, error) {
 
 	a.serverAddress = serverAddress
 
+	options := []client.Opt{client.WithURL(serverAddress)}
+	if opts.Proxy != "" {
+		proxy, err := url.Parse(opts.Proxy)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse the proxy address")
+		}
+
+		options = append(options, client.WithProxy(proxy))
+	}
+
+	cli := client.NewClient(options...)
+	if cli == nil {
+		return nil, client.ErrProxyScheme
+	}
+
 	return &Agent{
 		opts: opts,
-		cli:  client.NewClient(client.WithURL(serverAddress)),
+		cli:  cli,
 	}, nil
 }
 
@@ -142,6 +166,49 @@ func (a *Agent) checkUpdate() (*semver.Version, error) {
 	return semver.NewVersion(info.Version)
 }
 
+// UpdateCheckInterval is the interval to check if the agent must be updated, by the server's version or its
+// namespace's policy.
+const UpdateCheckInterval = time.Hour
+
+// nextUpdate returns the version the agent must be updated to, or nil when it must not, following its namespace's
+// policy: a held agent is not updated, a pinned one is updated, or downgraded, to its pinned version and the others
+// are updated to the server's version when it is newer.
+func (a *Agent) nextUpdate(current *semver.Version) (*semver.Version, error) {
+	policy := a.getAuthData().AgentUpdate
+	switch {
+	case policy != nil && policy.Hold:
+		a.setUpdateStatus(UpdateHeld)
+
+		return nil, nil
+	case policy != nil && policy.Version != "":
+		pinned, err := semver.NewVersion(policy.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		if pinned.Equal(current) {
+			a.setUpdateStatus(fmt.Sprintf("pinned to %s", pinned.Original()))
+
+			return nil, nil
+		}
+
+		return pinned, nil
+	}
+
+	next, err := a.checkUpdate()
+	if err != nil {
+		return nil, err
+	}
+
+	a.setUpdateStatus(UpdateUpToDate)
+
+	if !next.GreaterThan(current) {
+		return nil, nil
+	}
+
+	return next, nil
+}
+
 // probeServerInfo probe server information.
 func (a *Agent) probeServerInfo() error {
 	info, err := a.cli.GetInfo(AgentVersion)
@@ -152,8 +219,15 @@ func (a *Agent) probeServerInfo() error {
 
 // authorize send auth request to the server.
 func (a *Agent) authorize() error {
+	fingerprint, err := keygen.FingerprintPublicKey(a.pubKey)
+	if err != nil {
+		return err
+	}
+
 	authData, err := a.cli.AuthDevice(&models.DeviceAuthRequest{
-		Info: a.Info,
+		Info:               a.Info,
+		Attributes:         a.opts.Attributes,
+		HostKeyFingerprint: fingerprint,
 		DeviceAuth: &models.DeviceAuth{
 			Hostname:  a.opts.PreferredHostname,
 			Identity:  a.Identity,
@@ -162,11 +236,25 @@ func (a *Agent) authorize() error {
 		},
 	})
 
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
 	a.authData = authData
 
-	return err
+	return nil
+}
+
+func (a *Agent) getAuthData() *models.DeviceAuthResponse {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.authData
 }
 
 func (a *Agent) newReverseListener() (*revdial.Listener, error) {
-	return a.cli.NewReverseListener(a.authData.Token)
+	return a.cli.NewReverseListener(a.getAuthData().Token)
 }
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/shellhub-io/shellhub/agent/server"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFile is the path to the agent's configuration file, set by the `--config` flag or the SHELLHUB_CONFIG_FILE
+// environment variable. When it is empty, the agent is only configured by the environment.
+var ConfigFile string
+
+// configFileEnv are the environment variables exported from the configuration file, replaced when it is reloaded.
+var configFileEnv = map[string]bool{}
+
+// loadConfigOptions loads the agent's options from the environment and the configuration file. An option set in the
+// environment takes precedence over the file's, which takes precedence over its default value.
+func loadConfigOptions() (*ConfigOptions, error) {
+	if ConfigFile != "" {
+		if err := loadConfigFile(ConfigFile); err != nil {
+			return nil, err
+		}
+	}
+
+	opts := new(ConfigOptions)
+
+	// Process unprefixed env vars for backward compatibility
+	envconfig.Process("", opts) // nolint:errcheck
+
+	if err := envconfig.Process("shellhub", opts); err != nil {
+		return nil, err
+	}
+
+	return opts, nil
+}
+
+// loadConfigFile exports the options of a YAML configuration file as environment variables, so they are processed as
+// the ones set in the environment. Its keys are the names of the options' variables without the SHELLHUB_ prefix,
+// e.g. keepalive_interval, and the options already set in the environment are kept.
+func loadConfigFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to parse the configuration file: %w", err)
+	}
+
+	for name := range configFileEnv {
+		os.Unsetenv(name) // nolint:errcheck
+	}
+
+	configFileEnv = make(map[string]bool)
+
+	options := configOptionNames()
+	for key, value := range values {
+		key = strings.ToUpper(key)
+		if !options[key] {
+			log.WithField("option", key).Warn("Unknown option in the configuration file")
+
+			continue
+		}
+
+		name := "SHELLHUB_" + key
+		if _, ok := os.LookupEnv(name); ok {
+			continue
+		}
+
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+
+		if err := os.Setenv(name, configValue(value)); err != nil {
+			return err
+		}
+
+		configFileEnv[name] = true
+	}
+
+	return nil
+}
+
+// configOptionNames returns the names, without prefix, of the options' environment variables.
+func configOptionNames() map[string]bool {
+	names := make(map[string]bool)
+
+	options := reflect.TypeOf(ConfigOptions{}) // nolint:exhaustruct
+	for i := 0; i < options.NumField(); i++ {
+		if name := options.Field(i).Tag.Get("envconfig"); name != "" {
+			names[strings.ToUpper(name)] = true
+		}
+	}
+
+	return names
+}
+
+// configValue formats a value of the configuration file as envconfig parses it from an environment variable: a list
+// as comma separated items and a map as comma separated key:value pairs.
+func configValue(value interface{}) string {
+	switch value := value.(type) {
+	case []interface{}:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			items = append(items, fmt.Sprint(item))
+		}
+
+		return strings.Join(items, ",")
+	case map[string]interface{}:
+		pairs := make([]string, 0, len(value))
+		for key, item := range value {
+			pairs = append(pairs, fmt.Sprintf("%s:%v", key, item))
+		}
+
+		sort.Strings(pairs)
+
+		return strings.Join(pairs, ",")
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// reloadConfig reloads the options that can be changed without dropping the agent's reverse tunnel: the keep alive
+// interval, the log level, the preferred hostname and the attributes. The changes to the other options are only
+// applied when the agent is restarted.
+func (a *Agent) reloadConfig(serv *server.Server) {
+	opts, err := loadConfigOptions()
+	if err != nil {
+		log.WithError(err).Error("Failed to reload the configuration")
+
+		return
+	}
+
+	level, err := log.ParseLevel(opts.LogLevel)
+	if err != nil {
+		log.WithError(err).Error("Invalid log level has been provided.")
+
+		return
+	}
+
+	restart := *opts
+	restart.KeepAliveInterval = a.opts.KeepAliveInterval
+	restart.LogLevel = a.opts.LogLevel
+	restart.PreferredHostname = a.opts.PreferredHostname
+	restart.Attributes = a.opts.Attributes
+
+	if !reflect.DeepEqual(restart, *a.opts) {
+		log.Warn("Some of the changed options are only applied when the agent is restarted")
+	}
+
+	log.SetLevel(level)
+	a.opts.LogLevel = opts.LogLevel
+
+	a.opts.KeepAliveInterval = opts.KeepAliveInterval
+	serv.SetKeepAliveInterval(opts.KeepAliveInterval)
+
+	if opts.PreferredHostname != a.opts.PreferredHostname || !reflect.DeepEqual(opts.Attributes, a.opts.Attributes) {
+		a.opts.PreferredHostname = opts.PreferredHostname
+		a.opts.Attributes = opts.Attributes
+
+		if err := a.authorize(); err != nil {
+			log.WithError(err).Error("Failed to authorize device with the reloaded configuration")
+
+			return
+		}
+
+		authData := a.getAuthData()
+
+		serv.SetDeviceName(authData.Name)
+		serv.SetAuthData(authData)
+	}
+
+	log.WithFields(log.Fields{
+		"keepalive_interval": opts.KeepAliveInterval,
+		"log_level":          opts.LogLevel,
+	}).Info("Configuration reloaded")
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// unsetenv unsets environment variables for the test, restoring them when it ends.
+func unsetenv(t *testing.T, names ...string) {
+	t.Helper()
+
+	for _, name := range names {
+		t.Setenv(name, "")
+		os.Unsetenv(name) // nolint:errcheck
+	}
+}
+
+// writeConfigFile writes a configuration file to the test's temporary directory, returning its path.
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "agent.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestConfigValue(t *testing.T) {
+	cases := []struct {
+		description string
+		value       interface{}
+		expected    string
+	}{
+		{
+			description: "formats a scalar value",
+			value:       60,
+			expected:    "60",
+		},
+		{
+			description: "formats a list as comma separated items",
+			value:       []interface{}{"a", 1, true},
+			expected:    "a,1,true",
+		},
+		{
+			description: "formats a map as sorted comma separated key:value pairs",
+			value:       map[string]interface{}{"zone": "b", "env": "prod"},
+			expected:    "env:prod,zone:b",
+		},
+		{
+			description: "formats an empty value as empty",
+			value:       nil,
+			expected:    "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, configValue(tc.value))
+		})
+	}
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	cases := []struct {
+		description string
+		content     string
+		env         map[string]string
+		expected    map[string]string
+		fails       bool
+	}{
+		{
+			description: "fails when the file is not valid YAML",
+			content:     "keepalive_interval: [",
+			fails:       true,
+		},
+		{
+			description: "exports the options of the file",
+			content:     "keepalive_interval: 60\nattributes:\n  env: prod\n",
+			expected: map[string]string{
+				"SHELLHUB_KEEPALIVE_INTERVAL": "60",
+				"SHELLHUB_ATTRIBUTES":         "env:prod",
+			},
+		},
+		{
+			description: "ignores the unknown options",
+			content:     "unknown: value\n",
+			expected: map[string]string{
+				"SHELLHUB_UNKNOWN": "",
+			},
+		},
+		{
+			description: "keeps the options set in the environment",
+			content:     "keepalive_interval: 60\nlog_level: debug\n",
+			env: map[string]string{
+				"SHELLHUB_KEEPALIVE_INTERVAL": "10",
+				"LOG_LEVEL":                   "warn",
+			},
+			expected: map[string]string{
+				"SHELLHUB_KEEPALIVE_INTERVAL": "10",
+				"SHELLHUB_LOG_LEVEL":          "",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			unsetenv(t, "SHELLHUB_KEEPALIVE_INTERVAL", "SHELLHUB_ATTRIBUTES", "SHELLHUB_LOG_LEVEL", "SHELLHUB_UNKNOWN", "LOG_LEVEL")
+			configFileEnv = map[string]bool{}
+
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+
+			err := loadConfigFile(writeConfigFile(t, tc.content))
+			if tc.fails {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			for name, value := range tc.expected {
+				assert.Equal(t, value, os.Getenv(name))
+			}
+		})
+	}
+}
+
+func TestLoadConfigFileNotFound(t *testing.T) {
+	assert.Error(t, loadConfigFile(filepath.Join(t.TempDir(), "agent.yaml")))
+}
+
+func TestLoadConfigOptions(t *testing.T) {
+	unsetenv(t,
+		"SHELLHUB_SERVER_ADDRESS", "SHELLHUB_PRIVATE_KEY", "SHELLHUB_TENANT_ID",
+		"SHELLHUB_KEEPALIVE_INTERVAL", "SHELLHUB_LOG_LEVEL", "SHELLHUB_HEALTH_INTERVAL",
+		"KEEPALIVE_INTERVAL", "LOG_LEVEL", "HEALTH_INTERVAL",
+	)
+	configFileEnv = map[string]bool{}
+
+	ConfigFile = writeConfigFile(t, `
+server_address: http://localhost
+private_key: /tmp/shellhub.key
+tenant_id: 00000000-0000-4000-0000-000000000000
+keepalive_interval: 60
+log_level: debug
+`)
+	t.Cleanup(func() {
+		ConfigFile = ""
+	})
+
+	t.Setenv("SHELLHUB_LOG_LEVEL", "warn")
+
+	opts, err := loadConfigOptions()
+	assert.NoError(t, err)
+
+	// The environment takes precedence over the file, which takes precedence over the defaults.
+	assert.Equal(t, "warn", opts.LogLevel)
+	assert.Equal(t, 60, opts.KeepAliveInterval)
+	assert.Equal(t, 300, opts.HealthInterval)
+	assert.Equal(t, "http://localhost", opts.ServerAddress)
+}
+
+func TestLoadConfigOptionsReload(t *testing.T) {
+	unsetenv(t,
+		"SHELLHUB_SERVER_ADDRESS", "SHELLHUB_PRIVATE_KEY", "SHELLHUB_TENANT_ID", "SHELLHUB_KEEPALIVE_INTERVAL",
+		"KEEPALIVE_INTERVAL",
+	)
+	configFileEnv = map[string]bool{}
+
+	required := `
+server_address: http://localhost
+private_key: /tmp/shellhub.key
+tenant_id: 00000000-0000-4000-0000-000000000000
+`
+
+	ConfigFile = writeConfigFile(t, required+"keepalive_interval: 60\n")
+	t.Cleanup(func() {
+		ConfigFile = ""
+	})
+
+	opts, err := loadConfigOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, 60, opts.KeepAliveInterval)
+
+	// An option removed from the file is unset when it is reloaded, going back to its default.
+	assert.NoError(t, os.WriteFile(ConfigFile, []byte(required), 0o600))
+
+	opts, err = loadConfigOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, 30, opts.KeepAliveInterval)
+
+	_, ok := os.LookupEnv("SHELLHUB_KEEPALIVE_INTERVAL")
+	assert.False(t, ok)
+}
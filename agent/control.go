@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/shellhub-io/shellhub/agent/pkg/control"
+	"github.com/shellhub-io/shellhub/agent/server"
+	"github.com/shellhub-io/shellhub/pkg/revdial"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// Update statuses reported by the agent's status.
+const (
+	UpdateDisabled = "disabled"
+	UpdateUnknown  = "unknown"
+	UpdateUpToDate = "up to date"
+	UpdateHeld     = "held by the namespace"
+)
+
+// setListener records the reverse listener of the tunnel established to the server, counting it as a reconnection
+// when it is not the first one.
+func (a *Agent) setListener(listener *revdial.Listener) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.connectedAt.IsZero() {
+		a.reconnects++
+	}
+
+	a.listener = listener
+	a.connectedAt = time.Now()
+}
+
+func (a *Agent) setUpdateStatus(status string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.update = status
+}
+
+// controlHandler answers the requests to the agent's control socket.
+type controlHandler struct {
+	agent  *Agent
+	server *server.Server
+}
+
+var _ control.Handler = (*controlHandler)(nil)
+
+func (h *controlHandler) Status() *control.Status {
+	a := h.agent
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	status := &control.Status{
+		ServerAddress: a.opts.ServerAddress,
+		Tunnel:        control.TunnelDisconnected,
+		ConnectedAt:   a.connectedAt,
+		Reconnects:    a.reconnects,
+		Version:       AgentVersion,
+		Update:        a.update,
+	}
+
+	if a.authData != nil {
+		status.DeviceUID = a.authData.UID
+		status.DeviceName = a.authData.Name
+		status.Namespace = a.authData.Namespace
+	}
+
+	if a.listener != nil {
+		status.LastKeepAlive = a.listener.LastKeepAlive()
+
+		if !a.listener.Closed() {
+			status.Tunnel = control.TunnelConnected
+		}
+	}
+
+	return status
+}
+
+func (h *controlHandler) Sessions() []server.SessionInfo {
+	return h.server.ListSessions()
+}
+
+func (h *controlHandler) KillSession(id string) error {
+	if !h.server.CloseSession(id) {
+		return control.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (h *controlHandler) Reconnect() error {
+	h.agent.mu.Lock()
+	defer h.agent.mu.Unlock()
+
+	if h.agent.listener == nil {
+		return nil
+	}
+
+	return h.agent.listener.Close()
+}
+
+// newControlCommands creates the commands that request the control socket of the running agent.
+func newControlCommands() []*cobra.Command {
+	socket := os.Getenv("SHELLHUB_CONTROL_SOCKET")
+	if socket == "" {
+		socket = control.DefaultSocket
+	}
+
+	statusCmd := &cobra.Command{ // nolint: exhaustruct
+		Use:   "status",
+		Short: "Show the status of the running agent",
+		Run: func(cmd *cobra.Command, args []string) {
+			status, err := control.NewClient(socket).Status()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "Server address:\t%s\n", status.ServerAddress)
+			fmt.Fprintf(w, "Device UID:\t%s\n", status.DeviceUID)
+			fmt.Fprintf(w, "Device name:\t%s.%s\n", status.Namespace, status.DeviceName)
+			fmt.Fprintf(w, "Tunnel:\t%s\n", status.Tunnel)
+			fmt.Fprintf(w, "Connected at:\t%s\n", formatTime(status.ConnectedAt))
+			fmt.Fprintf(w, "Last keep alive:\t%s\n", formatTime(status.LastKeepAlive))
+			fmt.Fprintf(w, "Reconnects:\t%d\n", status.Reconnects)
+			fmt.Fprintf(w, "Version:\t%s\n", status.Version)
+			fmt.Fprintf(w, "Update:\t%s\n", status.Update)
+			w.Flush()
+		},
+	}
+
+	sessionsCmd := &cobra.Command{ // nolint: exhaustruct
+		Use:   "sessions",
+		Short: "List the active sessions of the running agent",
+		Run: func(cmd *cobra.Command, args []string) {
+			sessions, err := control.NewClient(socket).Sessions()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tUSER\tREMOTE IP\tSTARTED AT")
+			for _, session := range sessions {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", session.ID, session.User, session.RemoteAddr, formatTime(session.StartedAt))
+			}
+			w.Flush()
+		},
+	}
+
+	sessionsCmd.AddCommand(&cobra.Command{ // nolint: exhaustruct
+		Use:   "kill <id>",
+		Short: "Close an active session of the running agent",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := control.NewClient(socket).KillSession(args[0]); err != nil {
+				log.Fatal(err)
+			}
+		},
+	})
+
+	reconnectCmd := &cobra.Command{ // nolint: exhaustruct
+		Use:   "reconnect",
+		Short: "Drop the connection of the running agent to the server, which is established again",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := control.NewClient(socket).Reconnect(); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+
+	commands := []*cobra.Command{statusCmd, sessionsCmd, reconnectCmd}
+	for _, command := range commands {
+		command.PersistentFlags().StringVar(&socket, "socket", socket, "path to the agent's control socket")
+	}
+
+	return commands
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	return t.Local().Format(time.RFC1123)
+}
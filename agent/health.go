@@ -0,0 +1,72 @@
+package main
+
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/agent/pkg/sysinfo"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// collectHealth collects a snapshot of the device's health. A metric that cannot be collected is left empty, so the
+// others are still reported.
+func collectHealth() *models.DeviceHealth {
+	health := &models.DeviceHealth{
+		Disks:     []models.DeviceDisk{},
+		Addresses: []string{},
+	}
+
+	if uptime, err := sysinfo.GetUptime(); err == nil {
+		health.Uptime = uint64(uptime / time.Second)
+	} else {
+		log.WithError(err).Debug("Failed to get the uptime")
+	}
+
+	if load, err := sysinfo.GetLoadAverage(); err == nil {
+		health.Load = models.DeviceLoad{Load1: load[0], Load5: load[1], Load15: load[2]}
+	} else {
+		log.WithError(err).Debug("Failed to get the load average")
+	}
+
+	if memory, err := sysinfo.GetMemory(); err == nil {
+		health.Memory = models.DeviceMemory{Total: memory.Total, Available: memory.Available}
+	} else {
+		log.WithError(err).Debug("Failed to get the memory usage")
+	}
+
+	if disks, err := sysinfo.GetDisks(); err == nil {
+		for _, disk := range disks {
+			health.Disks = append(health.Disks, models.DeviceDisk{Mount: disk.Mount, Total: disk.Total, Used: disk.Used})
+		}
+	} else {
+		log.WithError(err).Debug("Failed to get the disks usage")
+	}
+
+	if temperature, err := sysinfo.GetTemperature(); err == nil {
+		health.Temperature = &temperature
+	} else {
+		log.WithError(err).Debug("Failed to get the temperature")
+	}
+
+	if addresses, err := sysinfo.PrimaryAddresses(); err == nil {
+		health.Addresses = addresses
+	} else {
+		log.WithError(err).Debug("Failed to get the primary addresses")
+	}
+
+	return health
+}
+
+// reportHealth periodically reports the device's health to the server, every interval seconds.
+func (a *Agent) reportHealth(interval int) {
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		if err := a.cli.ReportDeviceHealth(collectHealth(), a.getAuthData().Token); err != nil {
+			log.WithError(err).Warn("Failed to report the device health")
+		}
+
+		<-ticker.C
+	}
+}
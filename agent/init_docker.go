@@ -21,4 +21,7 @@ func init() {
 
 	osauth.DefaultShadowFilename = "/host/etc/shadow"
 	sysinfo.DefaultOSReleaseFilename = "/host/etc/os-release"
+	// The agent shares the host's PID namespace, so its init process lists the host's mounts.
+	sysinfo.DefaultMountsFilename = "/proc/1/mounts"
+	sysinfo.DefaultMountsRoot = "/host"
 }
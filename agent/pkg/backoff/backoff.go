@@ -0,0 +1,44 @@
+// Package backoff computes the exponentially growing, and jittered, intervals between the retries of an operation,
+// so many agents retrying against the same server do not do it at the same time.
+package backoff
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff doubles the interval between the retries from its minimum up to its maximum. It is not safe for concurrent
+// use.
+type Backoff struct {
+	min     time.Duration
+	max     time.Duration
+	attempt int
+}
+
+// New creates a backoff whose intervals grow from min up to max.
+func New(min, max time.Duration) *Backoff {
+	return &Backoff{min: min, max: max}
+}
+
+// Next returns the interval to wait before the next retry. It is randomly chosen between the half and the whole of
+// the attempt's exponential interval.
+func (b *Backoff) Next() time.Duration {
+	interval := b.max
+	// Above 32 doublings the interval overflows, and it is already capped long before that.
+	if b.attempt < 32 {
+		if exp := b.min << b.attempt; exp > 0 && exp < b.max {
+			interval = exp
+		}
+	}
+
+	b.attempt++
+
+	half := interval / 2
+
+	return half + time.Duration(rand.Int63n(int64(half)+1)) // nolint:gosec
+}
+
+// Reset restarts the intervals from the minimum, after the operation succeeds.
+func (b *Backoff) Reset() {
+	b.attempt = 0
+}
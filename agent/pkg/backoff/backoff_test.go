@@ -0,0 +1,34 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNext(t *testing.T) {
+	b := New(time.Second, time.Minute)
+
+	for _, interval := range []time.Duration{
+		time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		8 * time.Second,
+		16 * time.Second,
+		32 * time.Second,
+		time.Minute,
+		time.Minute,
+	} {
+		next := b.Next()
+		assert.GreaterOrEqual(t, next, interval/2)
+		assert.LessOrEqual(t, next, interval)
+	}
+
+	for i := 0; i < 100; i++ {
+		assert.LessOrEqual(t, b.Next(), time.Minute)
+	}
+
+	b.Reset()
+	assert.LessOrEqual(t, b.Next(), time.Second)
+}
@@ -0,0 +1,184 @@
+// Package control implements the agent's local control endpoint, a HTTP server listening on a unix socket, which
+// reports the agent's status and acts on its sessions and connection, and the client used by the agent's commands.
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/shellhub-io/shellhub/agent/server"
+)
+
+// DefaultSocket is the path of the control socket when none is configured.
+const DefaultSocket = "/var/run/shellhub-agent.sock"
+
+// Tunnel states reported by the agent's status.
+const (
+	TunnelConnected    = "connected"
+	TunnelDisconnected = "disconnected"
+)
+
+// ErrSessionNotFound is returned when the session to be killed is not active.
+var ErrSessionNotFound = errors.New("session not found")
+
+// Status is the agent's status.
+type Status struct {
+	ServerAddress string    `json:"server_address"`
+	DeviceUID     string    `json:"device_uid"`
+	DeviceName    string    `json:"device_name"`
+	Namespace     string    `json:"namespace"`
+	Tunnel        string    `json:"tunnel"`
+	ConnectedAt   time.Time `json:"connected_at"`
+	LastKeepAlive time.Time `json:"last_keepalive"`
+	Reconnects    int       `json:"reconnects"`
+	Version       string    `json:"version"`
+	Update        string    `json:"update"`
+}
+
+// Handler is implemented by the agent to answer the control endpoint's requests.
+type Handler interface {
+	Status() *Status
+	Sessions() []server.SessionInfo
+	// KillSession closes an active session, returning ErrSessionNotFound when there is none with id.
+	KillSession(id string) error
+	// Reconnect drops the agent's reverse tunnel, which is established again.
+	Reconnect() error
+}
+
+// Listen serves the control endpoint on the unix socket at path, which is only accessible by the agent's user, until
+// the listener fails.
+func Listen(path string, handler Handler) error {
+	// A socket left by a previous run of the agent cannot be listened on.
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// The socket is created only accessible by the agent's user, instead of being accessible by anyone until it is
+	// changed.
+	umask := syscall.Umask(0o177)
+	listener, err := net.Listen("unix", path)
+	syscall.Umask(umask)
+	if err != nil {
+		return err
+	}
+
+	defer listener.Close()
+
+	if err := os.Chmod(path, 0o600); err != nil {
+		return err
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		reply(w, http.StatusOK, handler.Status())
+	}).Methods(http.MethodGet)
+	router.HandleFunc("/sessions", func(w http.ResponseWriter, r *http.Request) {
+		reply(w, http.StatusOK, handler.Sessions())
+	}).Methods(http.MethodGet)
+	router.HandleFunc("/sessions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		switch err := handler.KillSession(mux.Vars(r)["id"]); {
+		case errors.Is(err, ErrSessionNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}).Methods(http.MethodDelete)
+	router.HandleFunc("/reconnect", func(w http.ResponseWriter, r *http.Request) {
+		if err := handler.Reconnect(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}).Methods(http.MethodPost)
+
+	return http.Serve(listener, router) // nolint:gosec
+}
+
+func reply(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(body) // nolint:errcheck
+}
+
+// Client requests the control endpoint of a running agent.
+type Client struct {
+	http *http.Client
+}
+
+// NewClient creates a client to the control endpoint on the unix socket at path.
+func NewClient(path string) *Client {
+	return &Client{
+		http: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					return (&net.Dialer{}).DialContext(ctx, "unix", path)
+				},
+			},
+		},
+	}
+}
+
+func (c *Client) Status() (*Status, error) {
+	status := new(Status)
+	if err := c.do(http.MethodGet, "/status", status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (c *Client) Sessions() ([]server.SessionInfo, error) {
+	var sessions []server.SessionInfo
+	if err := c.do(http.MethodGet, "/sessions", &sessions); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (c *Client) KillSession(id string) error {
+	return c.do(http.MethodDelete, "/sessions/"+id, nil)
+}
+
+func (c *Client) Reconnect() error {
+	return c.do(http.MethodPost, "/reconnect", nil)
+}
+
+func (c *Client) do(method, path string, result interface{}) error {
+	req, err := http.NewRequest(method, "http://agent"+path, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the agent, check if it is running: %w", err)
+	}
+
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound && method == http.MethodDelete:
+		return ErrSessionNotFound
+	case res.StatusCode >= http.StatusBadRequest:
+		return fmt.Errorf("the agent failed to handle the request: %s", res.Status)
+	case result == nil:
+		return nil
+	default:
+		return json.NewDecoder(res.Body).Decode(result)
+	}
+}
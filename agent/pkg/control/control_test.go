@@ -0,0 +1,171 @@
+package control
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/agent/server"
+	"github.com/stretchr/testify/assert"
+)
+
+// handler is a Handler answering with fixed values, which records the sessions killed and the reconnections.
+type handler struct {
+	status     *Status
+	sessions   []server.SessionInfo
+	killed     []string
+	reconnects int
+	err        error
+}
+
+func (h *handler) Status() *Status {
+	return h.status
+}
+
+func (h *handler) Sessions() []server.SessionInfo {
+	return h.sessions
+}
+
+func (h *handler) KillSession(id string) error {
+	if h.err != nil {
+		return h.err
+	}
+
+	for _, session := range h.sessions {
+		if session.ID == id {
+			h.killed = append(h.killed, id)
+
+			return nil
+		}
+	}
+
+	return ErrSessionNotFound
+}
+
+func (h *handler) Reconnect() error {
+	if h.err != nil {
+		return h.err
+	}
+
+	h.reconnects++
+
+	return nil
+}
+
+// listen serves the control endpoint, for the handler, on a socket in a temporary directory, returning a client to it.
+func listen(t *testing.T, h Handler) (*Client, string) {
+	t.Helper()
+
+	// The socket's path is kept short, as the unix sockets' paths are limited.
+	dir, err := os.MkdirTemp("", "control")
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		os.RemoveAll(dir)
+	})
+
+	path := filepath.Join(dir, "agent.sock")
+
+	go Listen(path, h) //nolint:errcheck
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(path)
+
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	return NewClient(path), path
+}
+
+func TestListen(t *testing.T) {
+	_, path := listen(t, &handler{})
+
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestStatus(t *testing.T) {
+	status := &Status{
+		ServerAddress: "https://cloud.shellhub.io",
+		DeviceUID:     "uid",
+		DeviceName:    "device",
+		Tunnel:        TunnelConnected,
+		ConnectedAt:   time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		Version:       "v0.13.0",
+	}
+
+	client, _ := listen(t, &handler{status: status})
+
+	received, err := client.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, status, received)
+}
+
+func TestSessions(t *testing.T) {
+	sessions := []server.SessionInfo{
+		{ID: "id", User: "root", RemoteAddr: "127.0.0.1:22", StartedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	client, _ := listen(t, &handler{sessions: sessions})
+
+	received, err := client.Sessions()
+	assert.NoError(t, err)
+	assert.Equal(t, sessions, received)
+}
+
+func TestKillSession(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		err         error
+		expected    error
+	}{
+		{
+			description: "fails when the session is not active",
+			id:          "unknown",
+			expected:    ErrSessionNotFound,
+		},
+		{
+			description: "fails when the agent fails to kill the session",
+			id:          "id",
+			err:         errors.New("error"),
+			expected:    errors.New("the agent failed to handle the request: 500 Internal Server Error"),
+		},
+		{
+			description: "succeeds to kill the session",
+			id:          "id",
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			h := &handler{sessions: []server.SessionInfo{{ID: "id"}}, err: tc.err}
+			client, _ := listen(t, h)
+
+			assert.Equal(t, tc.expected, client.KillSession(tc.id))
+			if tc.expected == nil {
+				assert.Equal(t, []string{tc.id}, h.killed)
+			}
+		})
+	}
+}
+
+func TestReconnect(t *testing.T) {
+	h := &handler{}
+	client, _ := listen(t, h)
+
+	assert.NoError(t, client.Reconnect())
+	assert.Equal(t, 1, h.reconnects)
+
+	h.err = errors.New("error")
+	assert.Error(t, client.Reconnect())
+	assert.Equal(t, 1, h.reconnects)
+}
+
+func TestClientNotRunning(t *testing.T) {
+	_, err := NewClient(filepath.Join(t.TempDir(), "agent.sock")).Status()
+	assert.Error(t, err)
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 
 	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
 )
 
 var ErrPemDecode = errors.New("PEM decode error")
@@ -74,3 +75,14 @@ func EncodePublicKeyToPem(key *rsa.PublicKey) []byte {
 		Bytes: x509.MarshalPKCS1PublicKey(key),
 	})
 }
+
+// FingerprintPublicKey returns the SHA256 fingerprint of the key as a SSH host key, as the agent's SSH server uses the
+// device's private key as its host key.
+func FingerprintPublicKey(key *rsa.PublicKey) (string, error) {
+	pub, err := ssh.NewPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	return ssh.FingerprintSHA256(pub), nil
+}
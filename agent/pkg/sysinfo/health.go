@@ -0,0 +1,238 @@
+package sysinfo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var (
+	DefaultProcPath       = "/proc"
+	DefaultMountsFilename = "/proc/mounts"
+	// DefaultMountsRoot is where the mount points listed by DefaultMountsFilename are accessible.
+	DefaultMountsRoot  = "/"
+	DefaultThermalPath = "/sys/class/thermal"
+)
+
+var (
+	ErrInvalidProcFile = errors.New("invalid proc file")
+	ErrNoTemperature   = errors.New("no temperature sensor found")
+)
+
+// Memory is the system's memory, in bytes.
+type Memory struct {
+	Total     uint64
+	Available uint64
+}
+
+// Disk is the size and usage, in bytes, of a mounted disk.
+type Disk struct {
+	Mount string
+	Total uint64
+	Used  uint64
+}
+
+// GetUptime returns the time since the system was booted.
+func GetUptime() (time.Duration, error) {
+	fields, err := readProcFields("uptime")
+	if err != nil {
+		return 0, err
+	}
+
+	seconds, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// GetLoadAverage returns the system's load average over the last 1, 5 and 15 minutes.
+func GetLoadAverage() ([3]float64, error) {
+	var load [3]float64
+
+	fields, err := readProcFields("loadavg")
+	if err != nil {
+		return load, err
+	}
+
+	if len(fields) < len(load) {
+		return load, ErrInvalidProcFile
+	}
+
+	for i := range load {
+		if load[i], err = strconv.ParseFloat(fields[i], 64); err != nil {
+			return load, err
+		}
+	}
+
+	return load, nil
+}
+
+// GetMemory returns the system's total memory and the memory available to start new applications.
+func GetMemory() (*Memory, error) {
+	file, err := os.Open(filepath.Join(DefaultProcPath, "meminfo"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	memory := new(Memory)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		var value *uint64
+		switch fields[0] {
+		case "MemTotal:":
+			value = &memory.Total
+		case "MemAvailable:":
+			value = &memory.Available
+		default:
+			continue
+		}
+
+		kb, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		*value = kb * 1024
+	}
+
+	if memory.Total == 0 {
+		return nil, ErrInvalidProcFile
+	}
+
+	return memory, scanner.Err()
+}
+
+// GetDisks returns the usage of the mounted block devices, once per device when it is mounted many times. Virtual
+// file systems and loop devices, like the ones of snaps, are not disks.
+func GetDisks() ([]Disk, error) {
+	file, err := os.Open(DefaultMountsFilename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	// The mount points escape their spaces, tabs, newlines and backslashes in octal.
+	unescape := strings.NewReplacer(`\040`, " ", `\011`, "\t", `\012`, "\n", `\134`, `\`)
+
+	disks := make([]Disk, 0)
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		device, mount := fields[0], unescape.Replace(fields[1])
+		if !strings.HasPrefix(device, "/dev/") || strings.HasPrefix(device, "/dev/loop") || seen[device] {
+			continue
+		}
+
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(filepath.Join(DefaultMountsRoot, mount), &stat); err != nil || stat.Blocks == 0 {
+			continue
+		}
+
+		seen[device] = true
+
+		disks = append(disks, Disk{
+			Mount: mount,
+			Total: stat.Blocks * uint64(stat.Bsize),
+			Used:  (stat.Blocks - stat.Bfree) * uint64(stat.Bsize),
+		})
+	}
+
+	return disks, scanner.Err()
+}
+
+// GetTemperature returns the highest temperature, in degrees Celsius, of the system's thermal zones. It returns
+// ErrNoTemperature when the system has none, as usual in virtual machines.
+func GetTemperature() (float64, error) {
+	zones, err := filepath.Glob(filepath.Join(DefaultThermalPath, "thermal_zone*", "temp"))
+	if err != nil {
+		return 0, err
+	}
+
+	found := false
+
+	var highest float64
+	for _, zone := range zones {
+		data, err := os.ReadFile(zone)
+		if err != nil {
+			continue
+		}
+
+		millidegrees, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+		if err != nil || millidegrees <= 0 {
+			continue
+		}
+
+		if temperature := float64(millidegrees) / 1000; !found || temperature > highest {
+			highest = temperature
+			found = true
+		}
+	}
+
+	if !found {
+		return 0, ErrNoTemperature
+	}
+
+	return highest, nil
+}
+
+// PrimaryAddresses returns the IP addresses of the primary network interface, except the link-local ones.
+func PrimaryAddresses() ([]string, error) {
+	iface, err := PrimaryInterface()
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		ip, _, err := net.ParseCIDR(addr.String())
+		if err != nil || ip.IsLinkLocalUnicast() {
+			continue
+		}
+
+		addresses = append(addresses, ip.String())
+	}
+
+	return addresses, nil
+}
+
+// readProcFields returns the fields of the first line of a proc file.
+func readProcFields(name string) ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(DefaultProcPath, name))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidProcFile, name)
+	}
+
+	return fields, nil
+}
@@ -0,0 +1,406 @@
+package selfupdater
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Masterminds/semver"
+	log "github.com/sirupsen/logrus"
+)
+
+// PublicKey is the base64 encoded ed25519 public key which signs the releases' checksums, along with their version, as
+// returned by signedChecksums. It is embedded inside the
+// binary at build time (e.g. `go build -ldflags "-X github.com/shellhub-io/shellhub/agent/selfupdater.PublicKey=..."`)
+// and, without it, the native agent does not update itself.
+var PublicKey string
+
+const (
+	checksumsFile = "SHA256SUMS"
+	signatureFile = "SHA256SUMS.sig"
+)
+
+var (
+	ErrNoPublicKey         = errors.New("no public key to verify the updates")
+	ErrInvalidSignature    = errors.New("invalid signature of the release checksums")
+	ErrChecksumNotFound    = errors.New("release checksum not found")
+	ErrChecksumMismatch    = errors.New("release checksum mismatch")
+	ErrVersionRolledBack   = errors.New("version was rolled back")
+	ErrUnexpectedStatus    = errors.New("unexpected status downloading the release")
+	ErrUpdateNotConfigured = errors.New("update URL is not configured")
+)
+
+// Options configures the updates of the native agent.
+type Options struct {
+	// URL is where the releases are downloaded from, as {URL}/{version}/shellhub-agent-linux-{arch}, along with the
+	// SHA256SUMS of the version's binaries and its detached signature, SHA256SUMS.sig.
+	URL string
+	// Timeout is how long a new version has to connect to the server before it is rolled back.
+	Timeout time.Duration
+	// Proxy is the proxy the releases are downloaded through, instead of the one set by the environment.
+	Proxy *url.URL
+}
+
+// nativeUpdater replaces the agent's binary by the release's one and re-executes it. The replaced binary is kept as a
+// backup, with the update's state, until the new version connects to the server, or is restored when it does not in
+// time.
+type nativeUpdater struct {
+	version string
+	opts    Options
+	binary  string
+
+	mu       sync.Mutex // guards below
+	pending  *updateState
+	rollback *time.Timer
+}
+
+// updateState is the state of an update whose version did not connect to the server yet.
+type updateState struct {
+	Version  string    `json:"version"`
+	Previous string    `json:"previous"`
+	Deadline time.Time `json:"deadline"`
+	// Started is set when the version is started, so a version started again before connecting to the server is known
+	// to have stopped, or crashed, without confirming the update.
+	Started bool `json:"started"`
+}
+
+func newNativeUpdater(version string, opts Options) *nativeUpdater {
+	binary, err := os.Executable()
+	if err == nil {
+		binary, err = filepath.EvalSymlinks(binary)
+	}
+
+	if err != nil {
+		log.WithError(err).Warn("Failed to find the agent's binary")
+	}
+
+	return &nativeUpdater{version: version, opts: opts, binary: binary}
+}
+
+func (n *nativeUpdater) CurrentVersion() (*semver.Version, error) {
+	return semver.NewVersion(n.version)
+}
+
+// ApplyUpdate downloads the release binary of version v for the agent's architecture, verifying it against the
+// signed checksums, and atomically replaces the agent's binary by it, which is then re-executed.
+func (n *nativeUpdater) ApplyUpdate(v *semver.Version) error {
+	if PublicKey == "" {
+		return ErrNoPublicKey
+	}
+
+	if n.opts.URL == "" || n.binary == "" {
+		return ErrUpdateNotConfigured
+	}
+
+	if failed, err := os.ReadFile(n.binary + ".failed"); err == nil && strings.TrimSpace(string(failed)) == v.Original() {
+		return fmt.Errorf("%w: %s", ErrVersionRolledBack, v.Original())
+	}
+
+	client := &http.Client{
+		Timeout:   10 * time.Minute,
+		Transport: &http.Transport{Proxy: http.ProxyFromEnvironment},
+	}
+
+	if n.opts.Proxy != nil {
+		client.Transport = &http.Transport{Proxy: http.ProxyURL(n.opts.Proxy)}
+	}
+
+	release := strings.TrimSuffix(n.opts.URL, "/") + "/" + v.Original()
+	name := "shellhub-agent-linux-" + runtime.GOARCH
+
+	checksum, err := releaseChecksum(client, release, v.Original(), name)
+	if err != nil {
+		return err
+	}
+
+	// The new binary is downloaded next to the current one, so it can be renamed over it.
+	if err := download(client, release+"/"+name, n.binary+".new", checksum); err != nil {
+		os.Remove(n.binary + ".new") // nolint:errcheck
+
+		return err
+	}
+
+	os.Remove(n.binary + ".old") // nolint:errcheck
+	if err := os.Link(n.binary, n.binary+".old"); err != nil {
+		return err
+	}
+
+	if err := n.writeState(&updateState{Version: v.Original(), Previous: n.version, Deadline: time.Now().Add(n.opts.Timeout)}); err != nil {
+		return err
+	}
+
+	if err := os.Rename(n.binary+".new", n.binary); err != nil {
+		os.Remove(n.binary + ".update") // nolint:errcheck
+
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"version": v.Original(),
+		"binary":  n.binary,
+	}).Info("Restarting the agent with the new version")
+
+	return syscall.Exec(n.binary, os.Args, os.Environ()) // nolint:gosec
+}
+
+// recoverUpdate is called as the agent starts, before anything that could fail, rolling back the version of a pending
+// update that is started again, as when it crashed or failed to start, or after the update's deadline. Otherwise, it
+// records that the version was started.
+func (n *nativeUpdater) recoverUpdate() error {
+	state, err := n.readState()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if state.Version != n.version {
+		return nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if state.Started || time.Now().After(state.Deadline) {
+		n.pending = state
+		n.rollbackUpdate()
+
+		return nil
+	}
+
+	state.Started = true
+
+	return n.writeState(state)
+}
+
+// CompleteUpdate watches the version started by an update, rolling it back when it does not call ConfirmUpdate before
+// the update's deadline. A version reaching it after the update's deadline is rolled back at once.
+func (n *nativeUpdater) CompleteUpdate() error {
+	state, err := n.readState()
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.WithError(err).Warn("Failed to read the update state")
+		}
+
+		return nil
+	}
+
+	// The update failed before the new version was executed, or the previous one was already restored.
+	if state.Version != n.version {
+		os.Remove(n.binary + ".update") // nolint:errcheck
+
+		return nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.pending = state
+
+	if time.Now().After(state.Deadline) {
+		n.rollbackUpdate()
+
+		return nil
+	}
+
+	n.rollback = time.AfterFunc(time.Until(state.Deadline), func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+
+		n.rollbackUpdate()
+	})
+
+	return nil
+}
+
+// ConfirmUpdate keeps the version started by an update, after it connects to the server, removing its backup.
+func (n *nativeUpdater) ConfirmUpdate() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.pending == nil {
+		return nil
+	}
+
+	// A version reaching CompleteUpdate after its deadline is rolled back without a timer.
+	if n.rollback != nil {
+		n.rollback.Stop()
+	}
+
+	if err := os.Remove(n.binary + ".update"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.Remove(n.binary + ".old"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"version":  n.pending.Version,
+		"previous": n.pending.Previous,
+	}).Info("Agent update completed")
+
+	n.pending = nil
+
+	return nil
+}
+
+// rollbackUpdate restores the binary replaced by the pending update, recording its version as failed so it is not
+// applied again, and re-executes it. It must be called with the mutex held.
+func (n *nativeUpdater) rollbackUpdate() {
+	if n.pending == nil {
+		return
+	}
+
+	logger := log.WithFields(log.Fields{
+		"version":  n.pending.Version,
+		"previous": n.pending.Previous,
+	})
+
+	logger.Warn("The new version did not connect to the server, rolling it back")
+
+	if err := os.WriteFile(n.binary+".failed", []byte(n.pending.Version), 0o600); err != nil {
+		logger.WithError(err).Warn("Failed to record the rolled back version")
+	}
+
+	if err := os.Rename(n.binary+".old", n.binary); err != nil {
+		logger.WithError(err).Error("Failed to restore the previous version")
+
+		return
+	}
+
+	os.Remove(n.binary + ".update") // nolint:errcheck
+
+	if err := syscall.Exec(n.binary, os.Args, os.Environ()); err != nil { // nolint:gosec
+		logger.WithError(err).Error("Failed to restart the previous version")
+	}
+}
+
+func (n *nativeUpdater) readState() (*updateState, error) {
+	data, err := os.ReadFile(n.binary + ".update")
+	if err != nil {
+		return nil, err
+	}
+
+	state := new(updateState)
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+func (n *nativeUpdater) writeState(state *updateState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(n.binary+".update", data, 0o600)
+}
+
+// releaseChecksum returns the SHA256 checksum of a release's binary, after verifying the signature of the release's
+// checksums, and of its version, against the embedded public key.
+func releaseChecksum(client *http.Client, release, version, name string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(PublicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, ErrNoPublicKey
+	}
+
+	sums, err := fetch(client, release+"/"+checksumsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := fetch(client, release+"/"+signatureFile)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil || !ed25519.Verify(key, signedChecksums(version, sums), signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	// Each line is formatted as sha256sum does: the checksum and the file's name, marked by an asterisk in binary mode.
+	scanner := bufio.NewScanner(bytes.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.TrimPrefix(fields[1], "*") != name {
+			continue
+		}
+
+		return hex.DecodeString(fields[0])
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrChecksumNotFound, name)
+}
+
+// signedChecksums returns the payload signed by a release: its version, in a line, followed by its checksums. As the
+// version is signed, the checksums of an older release can not be served as a newer one's to downgrade the agent.
+func signedChecksums(version string, sums []byte) []byte {
+	return append([]byte(version+"\n"), sums...)
+}
+
+func fetch(client *http.Client, url string) ([]byte, error) {
+	res, err := client.Get(url) // nolint:noctx
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s: %s", ErrUnexpectedStatus, url, res.Status)
+	}
+
+	// The checksums and their signature are small files.
+	return io.ReadAll(io.LimitReader(res.Body, 1<<20))
+}
+
+// download writes the file at url to path, executable, when its SHA256 checksum is the expected one.
+func download(client *http.Client, url, path string, checksum []byte) error {
+	res, err := client.Get(url) // nolint:noctx
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s: %s", ErrUnexpectedStatus, url, res.Status)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o755) // nolint:gosec
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(file, hash), res.Body); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(hash.Sum(nil), checksum) {
+		return ErrChecksumMismatch
+	}
+
+	return file.Sync()
+}
@@ -0,0 +1,164 @@
+package selfupdater
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseChecksum(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	_, other, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	checksum := sha256.Sum256([]byte("binary"))
+	sums := []byte(fmt.Sprintf("%x *shellhub-agent-linux-amd64\n", checksum))
+
+	cases := []struct {
+		description string
+		key         ed25519.PrivateKey
+		version     string
+		name        string
+		expected    error
+	}{
+		{
+			description: "fails when the checksums are not signed by the public key",
+			key:         other,
+			version:     "v0.13.0",
+			name:        "shellhub-agent-linux-amd64",
+			expected:    ErrInvalidSignature,
+		},
+		{
+			description: "fails when the checksums are signed for another version",
+			key:         private,
+			version:     "v0.12.0",
+			name:        "shellhub-agent-linux-amd64",
+			expected:    ErrInvalidSignature,
+		},
+		{
+			description: "fails when the binary has no checksum",
+			key:         private,
+			version:     "v0.13.0",
+			name:        "shellhub-agent-linux-arm64",
+			expected:    ErrChecksumNotFound,
+		},
+		{
+			description: "succeeds to return the binary's checksum",
+			key:         private,
+			version:     "v0.13.0",
+			name:        "shellhub-agent-linux-amd64",
+			expected:    nil,
+		},
+	}
+
+	PublicKey = base64.StdEncoding.EncodeToString(public)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v0.13.0/" + checksumsFile:
+					w.Write(sums) // nolint:errcheck
+				case "/v0.13.0/" + signatureFile:
+					w.Write([]byte(base64.StdEncoding.EncodeToString(ed25519.Sign(tc.key, signedChecksums(tc.version, sums))))) // nolint:errcheck
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			sum, err := releaseChecksum(server.Client(), server.URL+"/v0.13.0", "v0.13.0", tc.name)
+			assert.ErrorIs(t, err, tc.expected)
+			if tc.expected == nil {
+				assert.Equal(t, checksum[:], sum)
+			}
+		})
+	}
+}
+
+func TestConfirmUpdate(t *testing.T) {
+	binary := filepath.Join(t.TempDir(), "agent")
+	assert.NoError(t, os.WriteFile(binary+".update", []byte("{}"), 0o600))
+	assert.NoError(t, os.WriteFile(binary+".old", []byte("binary"), 0o600))
+
+	// The pending update has no rollback timer, as when CompleteUpdate rolls it back at once.
+	updater := &nativeUpdater{binary: binary, pending: &updateState{Version: "v0.13.0", Previous: "v0.12.0"}}
+
+	assert.NoError(t, updater.ConfirmUpdate())
+	assert.Nil(t, updater.pending)
+	assert.NoFileExists(t, binary+".update")
+	assert.NoFileExists(t, binary+".old")
+}
+
+func TestRecoverUpdate(t *testing.T) {
+	cases := []struct {
+		description string
+		state       updateState
+		rolledBack  bool
+	}{
+		{
+			description: "ignores the update of another version",
+			state:       updateState{Version: "v0.12.0", Previous: "v0.11.0", Deadline: time.Now().Add(time.Minute)},
+			rolledBack:  false,
+		},
+		{
+			description: "records the first start of the version",
+			state:       updateState{Version: "v0.13.0", Previous: "v0.12.0", Deadline: time.Now().Add(time.Minute)},
+			rolledBack:  false,
+		},
+		{
+			description: "rolls back the version started again before confirming the update",
+			state:       updateState{Version: "v0.13.0", Previous: "v0.12.0", Deadline: time.Now().Add(time.Minute), Started: true},
+			rolledBack:  true,
+		},
+		{
+			description: "rolls back the version started after the update's deadline",
+			state:       updateState{Version: "v0.13.0", Previous: "v0.12.0", Deadline: time.Now().Add(-time.Minute)},
+			rolledBack:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			// The binaries are not executable, so the restored one is not re-executed.
+			binary := filepath.Join(t.TempDir(), "agent")
+			assert.NoError(t, os.WriteFile(binary, []byte("new"), 0o600))
+			assert.NoError(t, os.WriteFile(binary+".old", []byte("old"), 0o600))
+
+			updater := &nativeUpdater{version: "v0.13.0", binary: binary}
+			assert.NoError(t, updater.writeState(&tc.state))
+
+			assert.NoError(t, updater.recoverUpdate())
+
+			if !tc.rolledBack {
+				state, err := updater.readState()
+				assert.NoError(t, err)
+				assert.Equal(t, tc.state.Version == "v0.13.0", state.Started)
+
+				return
+			}
+
+			data, err := os.ReadFile(binary)
+			assert.NoError(t, err)
+			assert.Equal(t, "old", string(data))
+
+			failed, err := os.ReadFile(binary + ".failed")
+			assert.NoError(t, err)
+			assert.Equal(t, "v0.13.0", string(failed))
+
+			assert.NoFileExists(t, binary+".update")
+		})
+	}
+}
@@ -34,6 +34,12 @@ func (c *dockerContainer) splitImageVersion() (image, version string) {
 	return
 }
 
+// RecoverUpdate does nothing, as the container of the previous version is only stopped by the new version's
+// CompleteUpdate.
+func RecoverUpdate(version string) error {
+	return nil
+}
+
 type dockerUpdater struct {
 	api client.CommonAPIClient
 }
@@ -137,6 +143,11 @@ func (d *dockerUpdater) CompleteUpdate() error {
 	return nil
 }
 
+// ConfirmUpdate does nothing, as the parent container is stopped by the new one when it is started.
+func (d *dockerUpdater) ConfirmUpdate() error {
+	return nil
+}
+
 func (d *dockerUpdater) getContainer(id string) (*dockerContainer, error) {
 	ctx := context.Background()
 
@@ -219,10 +230,10 @@ func (d *dockerUpdater) updateContainer(container *dockerContainer, image, name
 	return d.getContainer(clone.ID)
 }
 
-func NewUpdater(version string) (Updater, error) {
-	// ensure we are running inside a docker container, otherwise returns a dummy updater implementation
+func NewUpdater(version string, opts Options) (Updater, error) {
+	// ensure we are running inside a docker container, otherwise the agent's binary is updated as a native one
 	if _, err := os.Stat("/.dockerenv"); os.IsNotExist(err) {
-		return &nativeUpdater{version}, nil
+		return newNativeUpdater(version, opts), nil
 	}
 
 	api, err := client.NewClientWithOpts(client.FromEnv)
@@ -3,6 +3,17 @@
 
 package selfupdater
 
-func NewUpdater(version string) (Updater, error) {
-	return &nativeUpdater{version}, nil
+func NewUpdater(version string, opts Options) (Updater, error) {
+	return newNativeUpdater(version, opts), nil
+}
+
+// RecoverUpdate rolls back an updated version that stopped before connecting to the server. It must be called as soon
+// as the agent starts, so a version failing to start is rolled back too.
+func RecoverUpdate(version string) error {
+	updater := newNativeUpdater(version, Options{})
+	if updater.binary == "" {
+		return nil
+	}
+
+	return updater.recoverUpdate()
 }
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/agent/pkg/osauth"
+	log "github.com/sirupsen/logrus"
+)
+
+// startAgentForwarding exposes the client's forwarded SSH agent to the session's processes through a Unix socket owned
+// by the session's user. It returns the SSH_AUTH_SOCK environment variable pointing to the socket and a function to
+// stop the forwarding, removing the socket.
+//
+// When the client has not requested the agent forwarding, or the device's namespace or the user certificate does not
+// allow it, the returned variable is empty. The namespace's policy is also evaluated by the ShellHub's SSH server, but
+// the connections not relayed by it, like the ones of a jump-host, reach the agent straight. The socket is created in
+// the agent's temporary directory, so it is not reachable by the processes entering the host's mount namespace when
+// the agent runs in a container.
+func (s *Server) startAgentForwarding(session gliderssh.Session, user *osauth.User) (string, func()) {
+	if !gliderssh.AgentRequested(session) {
+		return "", func() {}
+	}
+
+	if !s.getAuthData().AgentForwarding {
+		log.WithFields(log.Fields{
+			"user": session.User(),
+		}).Info("agent forwarding is not allowed for the namespace")
+
+		return "", func() {}
+	}
+
+	if !permitted(session.Context(), extensionPermitAgentForwarding) {
+		log.WithFields(log.Fields{
+			"user": session.User(),
+		}).Info("agent forwarding is not permitted by the user certificate")
+
+		return "", func() {}
+	}
+
+	if user == nil {
+		log.WithFields(log.Fields{
+			"user": session.User(),
+		}).Warn("agent forwarding is skipped as the user was not found")
+
+		return "", func() {}
+	}
+
+	listener, err := gliderssh.NewAgentListener()
+	if err != nil {
+		log.WithError(err).Warn("failed to create the agent forwarding socket")
+
+		return "", func() {}
+	}
+
+	socket := listener.Addr().String()
+	dir := filepath.Dir(socket)
+
+	stop := func() {
+		listener.Close()
+		os.RemoveAll(dir)
+	}
+
+	if os.Geteuid() == 0 {
+		for _, path := range []string{dir, socket} {
+			if err := os.Chown(path, int(user.UID), int(user.GID)); err != nil {
+				log.WithError(err).Warn("failed to change the owner of the agent forwarding socket")
+				stop()
+
+				return "", func() {}
+			}
+		}
+	}
+
+	go gliderssh.ForwardAgentConnections(listener, session)
+
+	return "SSH_AUTH_SOCK=" + socket, stop
+}
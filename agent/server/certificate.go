@@ -0,0 +1,121 @@
+package server
+
+import (
+	"errors"
+	"net"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// Extensions of a user certificate enforced by the agent. A connection authenticated by a certificate without one of
+// them is not permitted to do what it allows.
+const (
+	extensionPermitPortForwarding  = "permit-port-forwarding"
+	extensionPermitAgentForwarding = "permit-agent-forwarding"
+	extensionPermitPTY             = "permit-pty"
+)
+
+// extensionCertificate is the extension of the permissions granted to a user certificate, which tells them apart from
+// the ones of a public key.
+const extensionCertificate = "certificate"
+
+var errPublicKeyRejected = errors.New("permission denied")
+
+// publicKeyCallback authenticates a connection by a public key or a user certificate. Each key is granted its own
+// permissions, so the extensions of a certificate are the ones of the key the client signs with, even when the server
+// reuses the result of a previous callback to it.
+func (s *Server) publicKeyCallback(conn gossh.ConnMetadata, key gossh.PublicKey) (*gossh.Permissions, error) {
+	if cert, ok := key.(*gossh.Certificate); ok {
+		return s.certificateCallback(conn, cert)
+	}
+
+	if !s.publicKeyHandler(conn.User(), key) {
+		return nil, errPublicKeyRejected
+	}
+
+	return &gossh.Permissions{}, nil
+}
+
+// certificateCallback authenticates a connection by a user certificate signed by an authority trusted by the device's
+// namespace, as the ShellHub's SSH server does, since the connections not relayed by it, like the ones of a jump-host,
+// reach the agent straight.
+//
+// As the agent does not replace the commands requested by the client, the certificates forcing a command are refused.
+func (s *Server) certificateCallback(conn gossh.ConnMetadata, cert *gossh.Certificate) (*gossh.Permissions, error) {
+	if osauth.LookupUser(conn.User()) == nil {
+		return nil, errPublicKeyRejected
+	}
+
+	evaluation, err := s.api.AuthCertificate(&models.CertificateAuthRequest{
+		Certificate: gossh.MarshalAuthorizedKey(cert),
+		Username:    conn.User(),
+		Address:     conn.RemoteAddr().String(),
+	}, s.getAuthData().Token)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"user":   conn.User(),
+			"key_id": cert.KeyId,
+		}).Info("Failed user certificate")
+
+		return nil, errPublicKeyRejected
+	}
+
+	if evaluation.ForceCommand != "" {
+		log.WithFields(log.Fields{
+			"user":   conn.User(),
+			"key_id": cert.KeyId,
+		}).Info("User certificate forcing a command is not supported")
+
+		return nil, errPublicKeyRejected
+	}
+
+	permissions := &gossh.Permissions{
+		Extensions: map[string]string{
+			extensionCertificate: "",
+		},
+	}
+
+	for _, extension := range []string{extensionPermitPortForwarding, extensionPermitAgentForwarding, extensionPermitPTY} {
+		if _, ok := cert.Extensions[extension]; ok {
+			permissions.Extensions[extension] = ""
+		}
+	}
+
+	log.WithFields(log.Fields{
+		"user":   conn.User(),
+		"key_id": cert.KeyId,
+	}).Info("Accepted user certificate")
+
+	return permissions, nil
+}
+
+// permitted checks if the connection of ctx is permitted to do what extension allows. Only the connections
+// authenticated by a user certificate are restricted by its extensions.
+func permitted(ctx gliderssh.Context, extension string) bool {
+	conn, ok := ctx.Value(gliderssh.ContextKeyConn).(*gossh.ServerConn)
+	if !ok || conn.Permissions == nil {
+		return true
+	}
+
+	if _, ok := conn.Permissions.Extensions[extensionCertificate]; !ok {
+		return true
+	}
+
+	_, ok = conn.Permissions.Extensions[extension]
+
+	return ok
+}
+
+// relayedConn is a connection relayed by the ShellHub's SSH server, whose remote address is the one of the client.
+type relayedConn struct {
+	net.Conn
+	remoteAddr net.Addr
+}
+
+func (c *relayedConn) RemoteAddr() net.Addr {
+	return c.remoteAddr
+}
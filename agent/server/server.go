@@ -12,6 +12,7 @@ import (
 	"os"
 	"os/exec"
 	"os/user"
+	"sort"
 	"sync"
 	"time"
 
@@ -45,12 +46,22 @@ func (c *sshConn) Close() error {
 	return c.Conn.Close()
 }
 
+// SessionInfo describes a session opened to the agent through its tunnel.
+type SessionInfo struct {
+	// ID is the session's UID in ShellHub.
+	ID         string    `json:"id"`
+	User       string    `json:"user"`
+	RemoteAddr string    `json:"remote_addr"`
+	StartedAt  time.Time `json:"started_at"`
+}
+
 type Server struct {
 	sshd               *gliderssh.Server
 	api                client.Client
 	authData           *models.DeviceAuthResponse
 	cmds               map[string]*exec.Cmd
 	Sessions           map[string]net.Conn
+	sessionsInfo       map[string]SessionInfo
 	deviceName         string
 	mu                 sync.Mutex
 	keepAliveInterval  int
@@ -64,15 +75,30 @@ func NewServer(api client.Client, authData *models.DeviceAuthResponse, privateKe
 		authData:          authData,
 		cmds:              make(map[string]*exec.Cmd),
 		Sessions:          make(map[string]net.Conn),
+		sessionsInfo:      make(map[string]SessionInfo),
 		keepAliveInterval: keepAliveInterval,
 	}
 
+	forwardHandler := &gliderssh.ForwardedTCPHandler{}
+
 	server.sshd = &gliderssh.Server{
-		PasswordHandler:        server.passwordHandler,
-		PublicKeyHandler:       server.publicKeyHandler,
+		PasswordHandler: server.passwordHandler,
+		// The public key authentication is set in the server's configuration, instead of by a PublicKeyHandler, so
+		// each key is granted its own permissions, like the extensions of a user certificate.
+		ServerConfigCallback: func(ctx gliderssh.Context) *gossh.ServerConfig {
+			return &gossh.ServerConfig{
+				PublicKeyCallback: server.publicKeyCallback,
+			}
+		},
+		PtyCallback: func(ctx gliderssh.Context, pty gliderssh.Pty) bool {
+			return permitted(ctx, extensionPermitPTY)
+		},
 		Handler:                server.sessionHandler,
 		SessionRequestCallback: server.sessionRequestCallback,
-		RequestHandlers:        gliderssh.DefaultRequestHandlers,
+		RequestHandlers: map[string]gliderssh.RequestHandler{
+			"tcpip-forward":        forwardHandler.HandleSSHRequest,
+			"cancel-tcpip-forward": forwardHandler.HandleSSHRequest,
+		},
 		SubsystemHandlers: map[string]gliderssh.SubsystemHandler{
 			SFTPSubsystemName: server.sftpSubsystemHandler,
 		},
@@ -90,10 +116,13 @@ func NewServer(api client.Client, authData *models.DeviceAuthResponse, privateKe
 			return &sshConn{conn, closeCallback, ctx}
 		},
 		LocalPortForwardingCallback: func(ctx gliderssh.Context, destinationHost string, destinationPort uint32) bool {
-			return true
+			return permitted(ctx, extensionPermitPortForwarding)
 		},
+		// The remote port forwarding is allowed by the namespace's policy, which is evaluated by the ShellHub's SSH server
+		// too, as the connections not relayed by it, like the ones of a jump-host, reach the agent straight.
 		ReversePortForwardingCallback: func(ctx gliderssh.Context, destinationHost string, destinationPort uint32) bool {
-			return false
+			return permitted(ctx, extensionPermitPortForwarding) &&
+				server.getAuthData().ReverseForwarding.AllowsBind(destinationHost, destinationPort)
 		},
 		ChannelHandlers: map[string]gliderssh.ChannelHandler{
 			"session":       gliderssh.DefaultSessionHandler,
@@ -112,7 +141,7 @@ func NewServer(api client.Client, authData *models.DeviceAuthResponse, privateKe
 
 // startKeepAlive sends a keep alive message to the server every in keepAliveInterval seconds.
 func (s *Server) startKeepAliveLoop(session gliderssh.Session) {
-	interval := time.Duration(s.keepAliveInterval) * time.Second
+	interval := s.getKeepAliveInterval()
 
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
@@ -130,6 +159,12 @@ loop:
 					log.Error(err)
 				}
 			}
+
+			// The interval is reloaded with the agent's configuration.
+			if current := s.getKeepAliveInterval(); current != interval {
+				interval = current
+				ticker.Reset(interval)
+			}
 		case <-session.Context().Done():
 			log.Debug("Stopping keep alive loop after session closed")
 			ticker.Stop()
@@ -145,19 +180,37 @@ func (s *Server) sessionHandler(session gliderssh.Session) {
 	log.Info("New session request")
 
 	go s.startKeepAliveLoop(session)
+
+	// The user may be removed from the device after it is authenticated.
+	u := osauth.LookupUser(session.User())
+	if u == nil {
+		log.WithFields(log.Fields{
+			"user": session.User(),
+		}).Error("Session's user was not found")
+
+		_ = session.Exit(1)
+
+		return
+	}
+
 	requestType := session.Context().Value("request_type").(string) //nolint:forcetypeassert
 
 	switch {
 	case isPty:
-		scmd := newShellCmd(s, session.User(), sspty.Term)
+		scmd := newShellCmd(s, u, sspty.Term)
+
+		authSock, stopAgentForwarding := s.startAgentForwarding(session, u)
+		defer stopAgentForwarding()
+
+		if authSock != "" {
+			scmd.Env = append(scmd.Env, authSock)
+		}
 
 		pts, err := startPty(scmd, session, winCh)
 		if err != nil {
 			log.Warn(err)
 		}
 
-		u := osauth.LookupUser(session.User())
-
 		err = os.Chown(pts.Name(), int(u.UID), -1)
 		if err != nil {
 			log.Warn(err)
@@ -196,7 +249,14 @@ func (s *Server) sessionHandler(session gliderssh.Session) {
 
 		utmp.UtmpEndSession(ut)
 	case !isPty && requestType == "shell":
-		cmd := newShellCmd(s, session.User(), "")
+		cmd := newShellCmd(s, u, "")
+
+		authSock, stopAgentForwarding := s.startAgentForwarding(session, u)
+		defer stopAgentForwarding()
+
+		if authSock != "" {
+			cmd.Env = append(cmd.Env, authSock)
+		}
 
 		stdout, _ := cmd.StdoutPipe()
 		stdin, _ := cmd.StdinPipe()
@@ -254,7 +314,6 @@ func (s *Server) sessionHandler(session gliderssh.Session) {
 			"Raw command": session.RawCommand(),
 		}).Info("Command ended")
 	default:
-		u := osauth.LookupUser(session.User())
 		if len(session.Command()) == 0 {
 			log.WithFields(log.Fields{
 				"user":      session.User(),
@@ -269,6 +328,13 @@ func (s *Server) sessionHandler(session gliderssh.Session) {
 
 		cmd := command.NewCmd(u, "", "", s.deviceName, session.Command()...)
 
+		authSock, stopAgentForwarding := s.startAgentForwarding(session, u)
+		defer stopAgentForwarding()
+
+		if authSock != "" {
+			cmd.Env = append(cmd.Env, authSock)
+		}
+
 		stdout, _ := cmd.StdoutPipe()
 		stdin, _ := cmd.StdinPipe()
 		stderr, _ := cmd.StderrPipe()
@@ -354,8 +420,8 @@ func (s *Server) passwordHandler(ctx gliderssh.Context, pass string) bool {
 	return ok
 }
 
-func (s *Server) publicKeyHandler(ctx gliderssh.Context, key gliderssh.PublicKey) bool {
-	if osauth.LookupUser(ctx.User()) == nil {
+func (s *Server) publicKeyHandler(username string, key gossh.PublicKey) bool {
+	if osauth.LookupUser(username) == nil {
 		return false
 	}
 
@@ -365,7 +431,7 @@ func (s *Server) publicKeyHandler(ctx gliderssh.Context, key gliderssh.PublicKey
 	}
 
 	sig := &Signature{
-		Username:  ctx.User(),
+		Username:  username,
 		Namespace: s.deviceName,
 	}
 
@@ -379,7 +445,7 @@ func (s *Server) publicKeyHandler(ctx gliderssh.Context, key gliderssh.PublicKey
 	res, err := s.api.AuthPublicKey(&models.PublicKeyAuthRequest{
 		Fingerprint: gossh.FingerprintLegacyMD5(key),
 		Data:        string(sigBytes),
-	}, s.authData.Token)
+	}, s.getAuthData().Token)
 	if err != nil {
 		return false
 	}
@@ -546,7 +612,12 @@ func (s *Server) sftpSubsystemHandler(session gliderssh.Session) {
 	}).Info("SFTP session closed")
 }
 
-func (s *Server) HandleConn(conn net.Conn) {
+// HandleConn serves a SSH connection relayed by the ShellHub's SSH server from a client whose IP address is remoteIP.
+func (s *Server) HandleConn(conn net.Conn, remoteIP string) {
+	if ip := net.ParseIP(remoteIP); ip != nil {
+		conn = &relayedConn{Conn: conn, remoteAddr: &net.TCPAddr{IP: ip}}
+	}
+
 	s.sshd.HandleConn(conn)
 }
 
@@ -554,22 +625,89 @@ func (s *Server) SetDeviceName(name string) {
 	s.deviceName = name
 }
 
-func (s *Server) CloseSession(id string) {
-	if session, ok := s.Sessions[id]; ok {
+// SetAuthData replaces the data of the device's authentication, along with the namespace's policies enforced by the
+// server, when the device is authenticated again.
+func (s *Server) SetAuthData(authData *models.DeviceAuthResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.authData = authData
+}
+
+func (s *Server) getAuthData() *models.DeviceAuthResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.authData
+}
+
+// SetKeepAliveInterval changes the interval, in seconds, of the keep alive messages, including the ones of the
+// sessions already started.
+func (s *Server) SetKeepAliveInterval(interval int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keepAliveInterval = interval
+}
+
+func (s *Server) getKeepAliveInterval() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return time.Duration(s.keepAliveInterval) * time.Second
+}
+
+// RegisterSession records the user and the remote address of a session opened through the tunnel, until it is
+// unregistered when it is closed.
+func (s *Server) RegisterSession(id, user, remoteAddr string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessionsInfo[id] = SessionInfo{ID: id, User: user, RemoteAddr: remoteAddr, StartedAt: time.Now()}
+}
+
+func (s *Server) UnregisterSession(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessionsInfo, id)
+}
+
+// ListSessions returns the active sessions, from the oldest to the newest.
+func (s *Server) ListSessions() []SessionInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sessions := make([]SessionInfo, 0, len(s.sessionsInfo))
+	for _, info := range s.sessionsInfo {
+		sessions = append(sessions, info)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+
+	return sessions
+}
+
+// CloseSession closes the connection of a session opened through the tunnel, reporting if it was open.
+func (s *Server) CloseSession(id string) bool {
+	session, ok := s.Sessions[id]
+	if ok {
 		session.Close()
 		delete(s.Sessions, id)
 	}
+
+	return ok
 }
 
 func (s *Server) ListenAndServe() error {
 	return s.sshd.ListenAndServe()
 }
 
-func newShellCmd(s *Server, username, term string) *exec.Cmd {
+func newShellCmd(s *Server, user *osauth.User, term string) *exec.Cmd {
 	shell := os.Getenv("SHELL")
 
-	user := osauth.LookupUser(username)
-
 	if shell == "" {
 		shell = user.Shell
 	}
@@ -56,6 +56,12 @@ func (c *Context) ID() *models.ID {
 	return nil
 }
 
+// APIKey returns the ID of the API key the request was authenticated with, got through gateway.
+// Notice: it is empty when the request was authenticated by a user's token, what sets ID instead.
+func (c *Context) APIKey() string {
+	return c.Request().Header.Get("X-API-Key-ID")
+}
+
 func (c *Context) Ctx() context.Context {
 	return c.Request().Context()
 }
@@ -54,3 +54,30 @@ func IDFromContext(ctx context.Context) *models.ID {
 
 	return nil
 }
+
+// RoleFromContext returns the namespace's role of who performed the request, a member or an API key.
+func RoleFromContext(ctx context.Context) string {
+	if c, ok := ctx.Value("ctx").(*Context); ok {
+		return c.Role()
+	}
+
+	return ""
+}
+
+// APIKeyFromContext returns the ID of the API key who performed the request, when it was authenticated by one.
+func APIKeyFromContext(ctx context.Context) string {
+	if c, ok := ctx.Value("ctx").(*Context); ok {
+		return c.APIKey()
+	}
+
+	return ""
+}
+
+// IPFromContext returns the IP address of the client who performed the request.
+func IPFromContext(ctx context.Context) string {
+	if c, ok := ctx.Value("ctx").(*Context); ok {
+		return c.RealIP()
+	}
+
+	return ""
+}
@@ -9,15 +9,18 @@ type AllActions struct {
 	Firewall  FirewallActions
 	PublicKey PublicKeyActions
 	Namespace NamespaceActions
+	Audit     AuditActions
+	APIKey    APIKeyActions
+	Webhook   WebhookActions
 	Billing   BillingActions
 }
 
 type DeviceActions struct {
-	Accept, Reject, Update, Remove, Connect, Rename, CreateTag, UpdateTag, RemoveTag, RenameTag, DeleteTag int
+	Accept, Reject, Update, Remove, Connect, Rename, ResetHostKey, CreateTag, UpdateTag, RemoveTag, RenameTag, DeleteTag int
 }
 
 type SessionActions struct {
-	Play, Close, Remove, Details int
+	Play, Close, Remove, Details, Approve int
 }
 
 type FirewallActions struct {
@@ -29,7 +32,19 @@ type PublicKeyActions struct {
 }
 
 type NamespaceActions struct {
-	Rename, AddMember, RemoveMember, EditMember, EnableSessionRecord, Delete int
+	Rename, AddMember, RemoveMember, EditMember, EnableSessionRecord, Delete, RequireMFA, EditReverseForwarding, EditAgentForwarding, EditSessionTimeouts, EditProtectedTags, EditAgentUpdate int
+}
+
+type AuditActions struct {
+	List int
+}
+
+type APIKeyActions struct {
+	Create, List, Edit, Remove int
+}
+
+type WebhookActions struct {
+	Create, List, Edit, Remove int
 }
 
 type BillingActions struct {
@@ -40,23 +55,25 @@ type BillingActions struct {
 // You should use it to get the code's action.
 var Actions = AllActions{
 	Device: DeviceActions{
-		Accept:    DeviceAccept,
-		Reject:    DeviceReject,
-		Update:    DeviceUpdate,
-		Remove:    DeviceRemove,
-		Connect:   DeviceConnect,
-		Rename:    DeviceRename,
-		CreateTag: DeviceCreateTag,
-		UpdateTag: DeviceUpdateTag,
-		RemoveTag: DeviceRemoveTag,
-		RenameTag: DeviceRenameTag,
-		DeleteTag: DeviceDeleteTag,
+		Accept:       DeviceAccept,
+		Reject:       DeviceReject,
+		Update:       DeviceUpdate,
+		Remove:       DeviceRemove,
+		Connect:      DeviceConnect,
+		Rename:       DeviceRename,
+		ResetHostKey: DeviceResetHostKey,
+		CreateTag:    DeviceCreateTag,
+		UpdateTag:    DeviceUpdateTag,
+		RemoveTag:    DeviceRemoveTag,
+		RenameTag:    DeviceRenameTag,
+		DeleteTag:    DeviceDeleteTag,
 	},
 	Session: SessionActions{
 		Play:    SessionPlay,
 		Close:   SessionClose,
 		Remove:  SessionRemove,
 		Details: SessionDetails,
+		Approve: SessionApprove,
 	},
 	Firewall: FirewallActions{
 		Create: FirewallCreate,
@@ -72,12 +89,33 @@ var Actions = AllActions{
 		UpdateTag: PublicKeyUpdateTag,
 	},
 	Namespace: NamespaceActions{
-		Rename:              NamespaceRename,
-		AddMember:           NamespaceAddMember,
-		RemoveMember:        NamespaceRemoveMember,
-		EditMember:          NamespaceEditMember,
-		EnableSessionRecord: NamespaceEnableSessionRecord,
-		Delete:              NamespaceDelete,
+		Rename:                NamespaceRename,
+		AddMember:             NamespaceAddMember,
+		RemoveMember:          NamespaceRemoveMember,
+		EditMember:            NamespaceEditMember,
+		EnableSessionRecord:   NamespaceEnableSessionRecord,
+		Delete:                NamespaceDelete,
+		RequireMFA:            NamespaceRequireMFA,
+		EditReverseForwarding: NamespaceEditReverseForwarding,
+		EditAgentForwarding:   NamespaceEditAgentForwarding,
+		EditSessionTimeouts:   NamespaceEditSessionTimeouts,
+		EditProtectedTags:     NamespaceEditProtectedTags,
+		EditAgentUpdate:       NamespaceEditAgentUpdate,
+	},
+	Audit: AuditActions{
+		List: AuditList,
+	},
+	APIKey: APIKeyActions{
+		Create: APIKeyCreate,
+		List:   APIKeyList,
+		Edit:   APIKeyEdit,
+		Remove: APIKeyRemove,
+	},
+	Webhook: WebhookActions{
+		Create: WebhookCreate,
+		List:   WebhookList,
+		Edit:   WebhookEdit,
+		Remove: WebhookRemove,
 	},
 	Billing: BillingActions{
 		ChooseDevices:       BillingChooseDevices,
@@ -131,3 +131,13 @@ func EvaluateNamespace(namespace *models.Namespace, userID string, action int, c
 
 	return EvaluatePermission(member.Role, action, callback)
 }
+
+// EvaluateAPIKey checks if an API key, scoped to a tenant with a role, allows an action over a namespace. As an API key
+// is not a member of its namespace, its own role is evaluated instead of a membership.
+func EvaluateAPIKey(namespace *models.Namespace, tenant, role string, action int, callback func() error) error {
+	if namespace.TenantID != tenant {
+		return ErrForbidden
+	}
+
+	return EvaluatePermission(role, action, callback)
+}
@@ -301,6 +301,50 @@ func TestEvaluateNamespace(t *testing.T) {
 	}
 }
 
+func TestEvaluateAPIKey(t *testing.T) {
+	namespace := &models.Namespace{Name: "namespace", TenantID: "tenantID"}
+
+	cases := []struct {
+		description string
+		tenant      string
+		role        string
+		action      int
+		expected    error
+	}{
+		{
+			description: "Fails when the API key is scoped to another namespace",
+			tenant:      "otherTenantID",
+			role:        RoleAdministrator,
+			action:      Actions.Namespace.Rename,
+			expected:    ErrForbidden,
+		},
+		{
+			description: "Fails when the API key's role does not allow the action",
+			tenant:      "tenantID",
+			role:        RoleObserver,
+			action:      Actions.Namespace.Rename,
+			expected:    ErrForbidden,
+		},
+		{
+			description: "Success when the API key's role allows the action",
+			tenant:      "tenantID",
+			role:        RoleAdministrator,
+			action:      Actions.Namespace.Rename,
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			err := EvaluateAPIKey(namespace, tc.tenant, tc.role, tc.action, func() error {
+				return nil
+			})
+
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
 func TestCheckPermission(t *testing.T) {
 	mock := &mocks.Store{}
 
@@ -355,6 +399,7 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Device.Connect,
 				Actions.Device.Rename,
 				Actions.Device.Update,
+				Actions.Device.ResetHostKey,
 
 				Actions.Device.CreateTag,
 				Actions.Device.UpdateTag,
@@ -366,6 +411,7 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Session.Close,
 				Actions.Session.Remove,
 				Actions.Session.Details,
+				Actions.Session.Approve,
 
 				Actions.Firewall.Create,
 				Actions.Firewall.Edit,
@@ -380,6 +426,11 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Namespace.RemoveMember,
 				Actions.Namespace.EditMember,
 				Actions.Namespace.EnableSessionRecord,
+				Actions.Namespace.EditReverseForwarding,
+				Actions.Namespace.EditAgentForwarding,
+				Actions.Namespace.EditSessionTimeouts,
+				Actions.Namespace.EditProtectedTags,
+				Actions.Namespace.EditAgentUpdate,
 			},
 			requiredMocks: func() {
 			},
@@ -395,6 +446,7 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Device.Connect,
 				Actions.Device.Rename,
 				Actions.Device.Update,
+				Actions.Device.ResetHostKey,
 
 				Actions.Device.CreateTag,
 				Actions.Device.UpdateTag,
@@ -406,6 +458,7 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Session.Close,
 				Actions.Session.Remove,
 				Actions.Session.Details,
+				Actions.Session.Approve,
 
 				Actions.Firewall.Create,
 				Actions.Firewall.Edit,
@@ -421,6 +474,11 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Namespace.EditMember,
 				Actions.Namespace.EnableSessionRecord,
 				Actions.Namespace.Delete,
+				Actions.Namespace.EditReverseForwarding,
+				Actions.Namespace.EditAgentForwarding,
+				Actions.Namespace.EditSessionTimeouts,
+				Actions.Namespace.EditProtectedTags,
+				Actions.Namespace.EditAgentUpdate,
 
 				Actions.Billing.AddPaymentMethod,
 				Actions.Billing.UpdatePaymentMethod,
@@ -10,6 +10,7 @@ const (
 	DeviceConnect
 	DeviceRename
 	DeviceDetails
+	DeviceResetHostKey
 
 	DeviceCreateTag
 	DeviceUpdateTag
@@ -21,6 +22,7 @@ const (
 	SessionClose
 	SessionRemove
 	SessionDetails
+	SessionApprove
 
 	FirewallCreate
 	FirewallEdit
@@ -44,6 +46,24 @@ const (
 	NamespaceEditMember
 	NamespaceEnableSessionRecord
 	NamespaceDelete
+	NamespaceRequireMFA
+	NamespaceEditReverseForwarding
+	NamespaceEditAgentForwarding
+	NamespaceEditSessionTimeouts
+	NamespaceEditProtectedTags
+	NamespaceEditAgentUpdate
+
+	AuditList
+
+	APIKeyCreate
+	APIKeyList
+	APIKeyEdit
+	APIKeyRemove
+
+	WebhookCreate
+	WebhookList
+	WebhookEdit
+	WebhookRemove
 
 	BillingChooseDevices
 	BillingAddPaymentMethod
@@ -86,6 +106,7 @@ var adminPermissions = Permissions{
 	DeviceRename,
 	DeviceDetails,
 	DeviceUpdate,
+	DeviceResetHostKey,
 
 	DeviceCreateTag,
 	DeviceUpdateTag,
@@ -99,6 +120,7 @@ var adminPermissions = Permissions{
 	SessionClose,
 	SessionRemove,
 	SessionDetails,
+	SessionApprove,
 
 	FirewallCreate,
 	FirewallEdit,
@@ -119,6 +141,23 @@ var adminPermissions = Permissions{
 	NamespaceRemoveMember,
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
+	NamespaceEditReverseForwarding,
+	NamespaceEditAgentForwarding,
+	NamespaceEditSessionTimeouts,
+	NamespaceEditProtectedTags,
+	NamespaceEditAgentUpdate,
+
+	AuditList,
+
+	APIKeyCreate,
+	APIKeyList,
+	APIKeyEdit,
+	APIKeyRemove,
+
+	WebhookCreate,
+	WebhookList,
+	WebhookEdit,
+	WebhookRemove,
 }
 
 var ownerPermissions = Permissions{
@@ -129,6 +168,7 @@ var ownerPermissions = Permissions{
 	DeviceRename,
 	DeviceDetails,
 	DeviceUpdate,
+	DeviceResetHostKey,
 
 	DeviceCreateTag,
 	DeviceUpdateTag,
@@ -142,6 +182,7 @@ var ownerPermissions = Permissions{
 	SessionClose,
 	SessionRemove,
 	SessionDetails,
+	SessionApprove,
 
 	FirewallCreate,
 	FirewallEdit,
@@ -163,6 +204,24 @@ var ownerPermissions = Permissions{
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
 	NamespaceDelete,
+	NamespaceRequireMFA,
+	NamespaceEditReverseForwarding,
+	NamespaceEditAgentForwarding,
+	NamespaceEditSessionTimeouts,
+	NamespaceEditProtectedTags,
+	NamespaceEditAgentUpdate,
+
+	AuditList,
+
+	APIKeyCreate,
+	APIKeyList,
+	APIKeyEdit,
+	APIKeyRemove,
+
+	WebhookCreate,
+	WebhookList,
+	WebhookEdit,
+	WebhookRemove,
 
 	BillingChooseDevices,
 	BillingAddPaymentMethod,
@@ -0,0 +1,108 @@
+// Package metrics defines the Prometheus metrics exposed by the API server.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.mongodb.org/mongo-driver/event"
+)
+
+const namespace = "shellhub_api"
+
+// Job outcomes.
+const (
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+	JobSkipped   = "skipped"
+)
+
+var (
+	requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Time taken to handle an HTTP request by method, route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	mongoDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "mongo_command_duration_seconds",
+		Help:      "Time taken to execute a MongoDB command by command and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"command", "result"})
+
+	jobs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jobs_total",
+		Help:      "Number of processed background jobs by task and outcome.",
+	}, []string{"task", "outcome"})
+)
+
+// Middleware records the latency of the HTTP requests.
+//
+// Like the log middleware, it handles the error returned by the next handler, so the response status is known. It must
+// be registered before any other middleware that also handles the errors.
+func Middleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		if err := next(c); err != nil {
+			c.Error(err)
+		}
+
+		route := c.Path()
+		if route == "" {
+			// Requests that do not match any route are grouped together to keep the cardinality bounded.
+			route = "unmatched"
+		}
+
+		requestDuration.
+			WithLabelValues(c.Request().Method, route, strconv.Itoa(c.Response().Status)).
+			Observe(time.Since(start).Seconds())
+
+		return nil
+	}
+}
+
+// CommandMonitor returns a MongoDB monitor that records the latency of the executed commands.
+func CommandMonitor() *event.CommandMonitor {
+	return &event.CommandMonitor{
+		Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+			mongoDuration.WithLabelValues(e.CommandName, "success").Observe(time.Duration(e.DurationNanos).Seconds())
+		},
+		Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+			mongoDuration.WithLabelValues(e.CommandName, "failure").Observe(time.Duration(e.DurationNanos).Seconds())
+		},
+	}
+}
+
+// JobMiddleware counts the outcome of the asynq jobs.
+func JobMiddleware(next asynq.Handler) asynq.Handler {
+	return asynq.HandlerFunc(func(ctx context.Context, task *asynq.Task) error {
+		err := next.ProcessTask(ctx, task)
+
+		switch {
+		case err == nil:
+			jobs.WithLabelValues(task.Type(), JobSucceeded).Inc()
+		case errors.Is(err, asynq.SkipRetry):
+			jobs.WithLabelValues(task.Type(), JobSkipped).Inc()
+		default:
+			jobs.WithLabelValues(task.Type(), JobFailed).Inc()
+		}
+
+		return err
+	})
+}
+
+// Handler returns the HTTP handler that serves the metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(Middleware)
+	e.GET("/api/devices/:uid", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusNotFound)
+	})
+
+	for _, uid := range []string{"a", "b"} {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/devices/"+uid, nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	}
+
+	// Both requests are grouped by the route, not by the requested path.
+	assert.Equal(t, 1, testutil.CollectAndCount(requestDuration))
+}
+
+func TestJobMiddleware(t *testing.T) {
+	cases := []struct {
+		name    string
+		err     error
+		outcome string
+	}{
+		{
+			name:    "counts a succeeded job",
+			err:     nil,
+			outcome: JobSucceeded,
+		},
+		{
+			name:    "counts a failed job",
+			err:     errors.New("error"),
+			outcome: JobFailed,
+		},
+		{
+			name:    "counts a skipped job",
+			err:     fmt.Errorf("invalid payload: %w", asynq.SkipRetry),
+			outcome: JobSkipped,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := JobMiddleware(asynq.HandlerFunc(func(context.Context, *asynq.Task) error {
+				return tc.err
+			}))
+
+			before := testutil.ToFloat64(jobs.WithLabelValues("test", tc.outcome))
+			assert.Equal(t, tc.err, handler.ProcessTask(context.TODO(), asynq.NewTask("test", nil)))
+			assert.Equal(t, before+1, testutil.ToFloat64(jobs.WithLabelValues("test", tc.outcome)))
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+)
+
+const (
+	ListAccessRequestsURL   = "/access-requests"
+	ApproveAccessRequestURL = "/access-requests/:id/approve"
+	DenyAccessRequestURL    = "/access-requests/:id/deny"
+)
+
+const (
+	CreateAccessRequestURL = "/access-requests"     // Request the access of a session to a device.
+	GetAccessRequestURL    = "/access-requests/:id" // Get the access request waited by a session.
+)
+
+func (h *Handler) ListAccessRequests(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var req request.AccessRequestList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	requests, count, err := h.service.ListAccessRequests(c.Ctx(), tenant, req.Status, *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, requests)
+}
+
+func (h *Handler) ApproveAccessRequest(c gateway.Context) error {
+	var req request.AccessRequestApprove
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var username string
+	if c.Username() != nil {
+		username = c.Username().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Approve, func() error {
+		return h.service.ApproveAccessRequest(c.Ctx(), tenant, req.ID, username, time.Duration(req.Duration)*time.Minute)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) DenyAccessRequest(c gateway.Context) error {
+	var req request.AccessRequestDeny
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var username string
+	if c.Username() != nil {
+		username = c.Username().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Approve, func() error {
+		return h.service.DenyAccessRequest(c.Ctx(), tenant, req.ID, username)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// CreateAccessRequest is used by the SSH server to request the access of a session to a device with a protected tag.
+// It responds with no content when the device's connections do not need to be approved.
+func (h *Handler) CreateAccessRequest(c gateway.Context) error {
+	var req request.AccessRequestCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	created, err := h.service.CreateAccessRequest(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	if created == nil {
+		return c.NoContent(http.StatusNoContent)
+	}
+
+	return c.JSON(http.StatusOK, created)
+}
+
+// GetAccessRequest is used by the SSH server to wait for an access request to be decided.
+func (h *Handler) GetAccessRequest(c gateway.Context) error {
+	var req request.AccessRequestGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	access, err := h.service.GetAccessRequest(c.Ctx(), req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, access)
+}
@@ -0,0 +1,141 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/api/response"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListAPIKeysURL  = "/api-keys"
+	CreateAPIKeyURL = "/api-keys"
+	UpdateAPIKeyURL = "/api-keys/:id"
+	DeleteAPIKeyURL = "/api-keys/:id"
+)
+
+// APIKeyHeader is the header used to authenticate a request with an API key instead of a user's token.
+const APIKeyHeader = "X-API-Key"
+
+func (h *Handler) CreateAPIKey(c gateway.Context) error {
+	var req request.APIKeyCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var creator string
+	if c.ID() != nil {
+		creator = c.ID().ID
+	}
+
+	var res *response.APIKeyCreate
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.APIKey.Create, func() error {
+		var err error
+		res, err = h.service.CreateAPIKey(c.Ctx(), tenant, creator, c.Role(), &req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) ListAPIKeys(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var keys []models.APIKey
+	var count int
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.APIKey.List, func() error {
+		var err error
+		keys, count, err = h.service.ListAPIKeys(c.Ctx(), tenant, *query)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, keys)
+}
+
+func (h *Handler) UpdateAPIKey(c gateway.Context) error {
+	var req request.APIKeyUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var key *models.APIKey
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.APIKey.Edit, func() error {
+		var err error
+		key, err = h.service.UpdateAPIKey(c.Ctx(), tenant, c.Role(), &req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, key)
+}
+
+func (h *Handler) DeleteAPIKey(c gateway.Context) error {
+	var req request.APIKeyDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.APIKey.Remove, func() error {
+		return h.service.DeleteAPIKey(c.Ctx(), tenant, c.Role(), req.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	GetAuditLogsURL = "/audit"
+)
+
+func (h *Handler) GetAuditLogs(c gateway.Context) error {
+	var req request.AuditList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	req.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var audits []models.AuditLog
+	var count int
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Audit.List, func() error {
+		var err error
+		audits, count, err = h.service.ListAuditLogs(c.Ctx(), tenant, req.Query, models.AuditFilter{
+			Action:   req.Action,
+			ActorID:  req.Actor,
+			TargetID: req.Target,
+			From:     req.From,
+			To:       req.To,
+		})
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, audits)
+}
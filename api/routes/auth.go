@@ -26,7 +26,26 @@ const (
 	AuthPublicKeyURL = "/auth/ssh"
 )
 
+// AuthCertificateURL is used by a device's agent to authenticate a user certificate.
+const AuthCertificateURL = "/auth/ssh/certificate"
+
 func (h *Handler) AuthRequest(c gateway.Context) error {
+	if key := c.Request().Header.Get(APIKeyHeader); key != "" {
+		apiKey, err := h.service.AuthAPIKey(c.Ctx(), key)
+		if err != nil {
+			return err
+		}
+
+		// An API key acts on its namespace with its own role. It is not a user, so it is forwarded without X-ID, and
+		// the routes acting on the user reject it.
+		c.Response().Header().Set("X-Tenant-ID", apiKey.TenantID)
+		c.Response().Header().Set("X-Username", apiKey.Name)
+		c.Response().Header().Set("X-API-Key-ID", apiKey.ID)
+		c.Response().Header().Set("X-Role", apiKey.Role)
+
+		return c.NoContent(http.StatusOK)
+	}
+
 	token, ok := c.Get(middleware.DefaultJWTConfig.ContextKey).(*jwt.Token)
 	if !ok {
 		return svc.ErrTypeAssertion
@@ -194,6 +213,31 @@ func (h *Handler) AuthPublicKey(c gateway.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+// AuthCertificate is used by a device's agent to check if a user certificate grants access to the device. The device is
+// the one authenticated by the request's token.
+func (h *Handler) AuthCertificate(c gateway.Context) error {
+	uid := c.Request().Header.Get(client.DeviceUIDHeader)
+	if uid == "" {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	var req request.CertificateAuth
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	evaluation, err := h.service.AuthCertificate(c.Ctx(), models.UID(uid), req.Certificate, req.Username, req.Address)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, evaluation)
+}
+
 func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		ctx, ok := c.Get("ctx").(*gateway.Context)
@@ -201,6 +245,11 @@ func AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 			return svc.ErrTypeAssertion
 		}
 
+		// Requests authenticated by an API key don't carry a JWT; the key is checked by AuthRequest itself.
+		if c.Request().Header.Get(APIKeyHeader) != "" {
+			return next(c)
+		}
+
 		jwt := middleware.JWTWithConfig(middleware.JWTConfig{ //nolint:staticcheck
 			Claims:        &jwt.MapClaims{},
 			SigningKey:    ctx.Service().(svc.Service).PublicKey(),
@@ -0,0 +1,91 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+)
+
+const (
+	EvaluateAuthAttemptURL = "/auth/ssh/attempts/evaluate" // Evaluate a SSH authentication attempt.
+	RecordAuthFailureURL   = "/auth/ssh/failures"          // Record a failed SSH authentication.
+)
+
+// The authentication bans are only managed through the internal API and the CLI, by the instance's administrators.
+const (
+	ListAuthBansURL = "/auth/bans"     // List the authentication bans.
+	LiftAuthBanURL  = "/auth/bans/:id" // Lift an authentication ban.
+)
+
+// EvaluateAuthAttempt is used by the SSH server to check if an authentication attempt is banned or must be delayed.
+func (h *Handler) EvaluateAuthAttempt(c gateway.Context) error {
+	var req request.AuthAttempt
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	evaluation, err := h.service.EvaluateAuthAttempt(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, evaluation)
+}
+
+// RecordAuthFailure is used by the SSH server to record a failed authentication.
+func (h *Handler) RecordAuthFailure(c gateway.Context) error {
+	var req request.AuthFailure
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.RecordAuthFailure(c.Ctx(), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ListAuthBans is used by the CLI to list the unexpired authentication bans.
+func (h *Handler) ListAuthBans(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	bans, count, err := h.service.ListAuthBans(c.Ctx(), *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, bans)
+}
+
+// LiftAuthBan is used by the CLI to lift an authentication ban before it expires.
+func (h *Handler) LiftAuthBan(c gateway.Context) error {
+	var req request.AuthBanLift
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.LiftAuthBan(c.Ctx(), req.ID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,128 @@
+package routes
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestAuthRequestAPIKey(t *testing.T) {
+	mock := new(mocks.Service)
+	h := NewHandler(mock)
+
+	Err := errors.New("error")
+	apiKey := &models.APIKey{ID: "id", TenantID: "tenant", Name: "key", Role: guard.RoleOperator}
+
+	cases := []struct {
+		description   string
+		key           string
+		requiredMocks func()
+		expected      error
+		headers       map[string]string
+	}{
+		{
+			description: "fails when the API key is not valid",
+			key:         "invalid",
+			requiredMocks: func() {
+				mock.On("AuthAPIKey", testifymock.Anything, "invalid").Return(nil, Err).Once()
+			},
+			expected: Err,
+			headers:  map[string]string{},
+		},
+		{
+			description: "succeeds to forward the API key's namespace and role without a user",
+			key:         "valid",
+			requiredMocks: func() {
+				mock.On("AuthAPIKey", testifymock.Anything, "valid").Return(apiKey, nil).Once()
+			},
+			expected: nil,
+			headers: map[string]string{
+				"X-Tenant-ID":  "tenant",
+				"X-Username":   "key",
+				"X-API-Key-ID": "id",
+				"X-Role":       guard.RoleOperator,
+				"X-ID":         "",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, AuthRequestURL, nil)
+			req.Header.Set(APIKeyHeader, tc.key)
+			rec := httptest.NewRecorder()
+
+			c := gateway.NewContext(mock, echo.New().NewContext(req, rec))
+
+			assert.Equal(t, tc.expected, h.AuthRequest(*c))
+			for header, value := range tc.headers {
+				assert.Equal(t, value, rec.Header().Get(header))
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	mock := new(mocks.Service)
+
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	mock.On("PublicKey").Return(&private.PublicKey)
+
+	cases := []struct {
+		description string
+		key         string
+		called      bool
+	}{
+		{
+			description: "fails when the request has neither a JWT nor an API key",
+			key:         "",
+			called:      false,
+		},
+		{
+			description: "succeeds without a JWT when the request has an API key",
+			key:         "key",
+			called:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, AuthRequestURL, nil)
+			if tc.key != "" {
+				req.Header.Set(APIKeyHeader, tc.key)
+			}
+
+			c := gateway.NewContext(mock, echo.New().NewContext(req, httptest.NewRecorder()))
+			c.Set("ctx", c)
+
+			var called bool
+			err := AuthMiddleware(func(echo.Context) error {
+				called = true
+
+				return nil
+			})(c)
+
+			assert.Equal(t, tc.called, called)
+			if !tc.called {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
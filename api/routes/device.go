@@ -3,13 +3,18 @@ package routes
 import (
 	"encoding/base64"
 	"encoding/json"
+	"net"
 	"net/http"
 	"strconv"
 
+	"github.com/labstack/echo/v4"
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/inventory"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
@@ -26,6 +31,15 @@ const (
 	UpdateTagURL       = "/devices/:uid/tags"      // Update device's tags with a new set.
 	RemoveTagURL       = "/devices/:uid/tags/:tag" // Delete a tag from a device.
 	UpdateDevice       = "/devices/:uid"
+	BulkDevicesURL     = "/devices/bulk"
+)
+
+const (
+	UpdateDeviceAttributesURL = "/devices/:uid/attributes" // Update device's attributes with a new set.
+	GetDeviceAttributeKeysURL = "/attributes"              // Get the attribute keys used by the namespace's devices.
+	GetInventoryURL           = "/inventory"               // Get the namespace's accepted devices as an inventory.
+	ResetDeviceHostKeyURL     = "/devices/:uid/host-key"   // Unpin the device's SSH host key.
+	ReportDeviceHealthURL     = "/devices/health"          // Report the health of the device authenticated by its token.
 )
 
 const (
@@ -50,16 +64,11 @@ func (h *Handler) GetDeviceList(c gateway.Context) error {
 
 	query.Normalize()
 
-	raw, err := base64.StdEncoding.DecodeString(query.Filter)
+	filter, err := decodeFilter(query.Filter)
 	if err != nil {
 		return err
 	}
 
-	var filter []models.Filter
-	if err := json.Unmarshal(raw, &filter); len(raw) > 0 && err != nil {
-		return err
-	}
-
 	var tenant string
 	if c.Tenant() != nil {
 		tenant = c.Tenant().ID
@@ -311,3 +320,218 @@ func (h *Handler) UpdateDevice(c gateway.Context) error {
 
 	return c.NoContent(http.StatusOK)
 }
+
+func (h *Handler) UpdateDeviceAttributes(c gateway.Context) error {
+	var req request.DeviceUpdateAttributes
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Update, func() error {
+		return h.service.UpdateDeviceAttributes(c.Ctx(), tenant, models.UID(req.UID), req.Attributes)
+	}); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ResetDeviceHostKey(c gateway.Context) error {
+	var req request.DeviceResetHostKey
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.ResetHostKey, func() error {
+		return h.service.ResetDeviceHostKey(c.Ctx(), tenant, models.UID(req.UID))
+	}); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ReportDeviceHealth records the health reported by a device's agent. The device is the one authenticated by the
+// request's token, whose UID is forwarded by the gateway.
+func (h *Handler) ReportDeviceHealth(c gateway.Context) error {
+	uid := c.Request().Header.Get(internalclient.DeviceUIDHeader)
+	if uid == "" {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	var req request.DeviceHealth
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.ReportDeviceHealth(c.Ctx(), models.UID(uid), &req.DeviceHealth); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) GetDeviceAttributeKeys(c gateway.Context) error {
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	keys, count, err := h.service.GetDeviceAttributeKeys(c.Ctx(), tenant)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, keys)
+}
+
+// GetInventory renders the namespace's accepted devices as an Ansible dynamic inventory or as an OpenSSH client
+// configuration, grouped by tag and reached through the SSH gateway.
+func (h *Handler) GetInventory(c gateway.Context) error {
+	var req request.DeviceInventory
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	devices, err := h.service.ListInventoryDevices(c.Ctx(), tenant)
+	if err != nil {
+		return err
+	}
+
+	gw := inventory.Gateway{Host: req.Host, Port: req.Port, User: req.User}
+	if gw.Host == "" {
+		gw.Host = inventoryHost(c)
+	}
+
+	if gw.Port == 0 {
+		gw.Port = 22
+	}
+
+	if gw.User == "" {
+		gw.User = "root"
+	}
+
+	if req.Format == inventory.FormatSSHConfig {
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMETextPlainCharsetUTF8)
+		c.Response().WriteHeader(http.StatusOK)
+
+		return inventory.SSHConfig(c.Response(), devices, gw)
+	}
+
+	return c.JSON(http.StatusOK, inventory.Ansible(devices, gw))
+}
+
+// inventoryHost returns the host, without the port, the request was sent to, preferring the one forwarded by the
+// gateway's reverse proxy.
+func inventoryHost(c gateway.Context) string {
+	host := c.Request().Header.Get("X-Forwarded-Host")
+	if host == "" {
+		host = c.Request().Host
+	}
+
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+
+	return host
+}
+
+func (h *Handler) BulkDevices(c gateway.Context) error {
+	var req request.DeviceBulk
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	filter, err := decodeFilter(req.Filter)
+	if err != nil {
+		return err
+	}
+
+	uids := make([]models.UID, len(req.UIDs))
+	for i, uid := range req.UIDs {
+		uids[i] = models.UID(uid)
+	}
+
+	var publicURL bool
+	if req.PublicURL != nil {
+		publicURL = *req.PublicURL
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	actions := map[string]int{
+		models.DeviceBulkAccept:    guard.Actions.Device.Accept,
+		models.DeviceBulkReject:    guard.Actions.Device.Reject,
+		models.DeviceBulkRemove:    guard.Actions.Device.Remove,
+		models.DeviceBulkAddTag:    guard.Actions.Device.CreateTag,
+		models.DeviceBulkRemoveTag: guard.Actions.Device.RemoveTag,
+		models.DeviceBulkPublicURL: guard.Actions.Device.Update,
+	}
+
+	var results []models.DeviceBulkResult
+	if err := guard.EvaluatePermission(c.Role(), actions[req.Action], func() error {
+		var err error
+		results, err = h.service.BulkDevices(c.Ctx(), tenant, uids, filter, req.Action, req.Tag, publicURL)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, results)
+}
+
+// decodeFilter decodes a base64 encoded list of filters. An empty string decodes to no filter.
+func decodeFilter(encoded string) ([]models.Filter, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter []models.Filter
+	if err := json.Unmarshal(raw, &filter); len(raw) > 0 && err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,91 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+)
+
+const (
+	GenerateMFAURL = "/mfa/generate"
+	EnableMFAURL   = "/mfa/enable"
+	DisableMFAURL  = "/mfa/disable"
+	AuthMFAURL     = "/auth/mfa"
+)
+
+func (h *Handler) GenerateMFA(c gateway.Context) error {
+	var id string
+	if c.ID() != nil {
+		id = c.ID().ID
+	}
+
+	res, err := h.service.GenerateMFA(c.Ctx(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) EnableMFA(c gateway.Context) error {
+	var req request.UserMFACode
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var id string
+	if c.ID() != nil {
+		id = c.ID().ID
+	}
+
+	if err := h.service.EnableMFA(c.Ctx(), id, req.Code); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) DisableMFA(c gateway.Context) error {
+	var req request.UserMFACode
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var id string
+	if c.ID() != nil {
+		id = c.ID().ID
+	}
+
+	if err := h.service.DisableMFA(c.Ctx(), id, req.Code); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) AuthMFA(c gateway.Context) error {
+	var req request.UserMFAAuth
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	res, err := h.service.AuthMFA(c.Ctx(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
@@ -21,3 +21,15 @@ func Authorize(next echo.HandlerFunc) echo.HandlerFunc {
 		return next(c)
 	}
 }
+
+// RejectAPIKey rejects the requests authenticated by an API key to the routes acting on the user who performs them,
+// as an API key is not a user.
+func RejectAPIKey(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if c.(*gateway.Context).APIKey() != "" {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		return next(c)
+	}
+}
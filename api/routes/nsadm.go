@@ -13,16 +13,26 @@ import (
 )
 
 const (
-	ListNamespaceURL           = "/namespaces"
-	CreateNamespaceURL         = "/namespaces"
-	GetNamespaceURL            = "/namespaces/:tenant"
-	DeleteNamespaceURL         = "/namespaces/:tenant"
-	EditNamespaceURL           = "/namespaces/:tenant"
-	AddNamespaceUserURL        = "/namespaces/:tenant/members"
-	RemoveNamespaceUserURL     = "/namespaces/:tenant/members/:uid"
-	EditNamespaceUserURL       = "/namespaces/:tenant/members/:uid"
-	GetSessionRecordURL        = "/users/security"
-	EditSessionRecordStatusURL = "/users/security/:tenant"
+	ListNamespaceURL                      = "/namespaces"
+	CreateNamespaceURL                    = "/namespaces"
+	GetNamespaceURL                       = "/namespaces/:tenant"
+	DeleteNamespaceURL                    = "/namespaces/:tenant"
+	EditNamespaceURL                      = "/namespaces/:tenant"
+	AddNamespaceUserURL                   = "/namespaces/:tenant/members"
+	RemoveNamespaceUserURL                = "/namespaces/:tenant/members/:uid"
+	EditNamespaceUserURL                  = "/namespaces/:tenant/members/:uid"
+	GetSessionRecordURL                   = "/users/security"
+	EditSessionRecordStatusURL            = "/users/security/:tenant"
+	EditNamespaceMFAURL                   = "/namespaces/:tenant/mfa"
+	EditNamespaceReverseForwardingURL     = "/namespaces/:tenant/forwarding"
+	EvaluateNamespaceReverseForwardingURL = "/namespaces/:tenant/forwarding/evaluate"
+	EditNamespaceAgentForwardingURL       = "/namespaces/:tenant/agent-forwarding"
+	EvaluateNamespaceAgentForwardingURL   = "/namespaces/:tenant/agent-forwarding/evaluate"
+	EditNamespaceSessionTimeoutsURL       = "/namespaces/:tenant/session-timeouts"
+	GetNamespaceSessionTimeoutsURL        = "/namespaces/:tenant/session-timeouts"
+	GetNamespaceSessionRecordURL          = "/namespaces/:tenant/session-record"
+	EditNamespaceProtectedTagsURL         = "/namespaces/:tenant/protected-tags"
+	EditNamespaceAgentUpdateURL           = "/namespaces/:tenant/agent-update"
 )
 
 const (
@@ -102,6 +112,11 @@ func (h *Handler) GetNamespace(c gateway.Context) error {
 		}
 	}
 
+	// An API key only gets its own namespace.
+	if c.APIKey() != "" && (c.Tenant() == nil || c.Tenant().ID != ns.TenantID) {
+		return c.NoContent(http.StatusForbidden)
+	}
+
 	return c.JSON(http.StatusOK, ns)
 }
 
@@ -115,17 +130,12 @@ func (h *Handler) DeleteNamespace(c gateway.Context) error {
 		return err
 	}
 
-	var uid string
-	if c.ID() != nil {
-		uid = c.ID().ID
-	}
-
 	ns, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
 	if err != nil || ns == nil {
 		return c.NoContent(http.StatusNotFound)
 	}
 
-	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.Delete, func() error {
+	err = evaluateNamespace(c, ns, guard.Actions.Namespace.Delete, func() error {
 		err := h.service.DeleteNamespace(c.Ctx(), ns.TenantID)
 
 		return err
@@ -147,18 +157,13 @@ func (h *Handler) EditNamespace(c gateway.Context) error {
 		return err
 	}
 
-	var uid string
-	if c.ID() != nil {
-		uid = c.ID().ID
-	}
-
 	namespace, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
 	if err != nil || namespace == nil {
 		return c.NoContent(http.StatusNotFound)
 	}
 
 	var nns *models.Namespace
-	err = guard.EvaluateNamespace(namespace, uid, guard.Actions.Namespace.Rename, func() error {
+	err = evaluateNamespace(c, namespace, guard.Actions.Namespace.Rename, func() error {
 		var err error
 		nns, err = h.service.EditNamespace(c.Ctx(), namespace.TenantID, req.Name)
 
@@ -192,7 +197,7 @@ func (h *Handler) AddNamespaceUser(c gateway.Context) error {
 	}
 
 	var namespace *models.Namespace
-	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.AddMember, func() error {
+	err = evaluateNamespace(c, ns, guard.Actions.Namespace.AddMember, func() error {
 		var err error
 		namespace, err = h.service.AddNamespaceUser(c.Ctx(), req.Username, req.Role, ns.TenantID, uid)
 
@@ -226,7 +231,7 @@ func (h *Handler) RemoveNamespaceUser(c gateway.Context) error {
 	}
 
 	var nns *models.Namespace
-	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.RemoveMember, func() error {
+	err = evaluateNamespace(c, ns, guard.Actions.Namespace.RemoveMember, func() error {
 		var err error
 		nns, err = h.service.RemoveNamespaceUser(c.Ctx(), ns.TenantID, req.MemberUID, uid)
 
@@ -259,7 +264,7 @@ func (h *Handler) EditNamespaceUser(c gateway.Context) error {
 		return c.NoContent(http.StatusNotFound)
 	}
 
-	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.EditMember, func() error {
+	err = evaluateNamespace(c, ns, guard.Actions.Namespace.EditMember, func() error {
 		err := h.service.EditNamespaceUser(c.Ctx(), ns.TenantID, uid, req.MemberUID, req.Role)
 
 		return err
@@ -281,6 +286,33 @@ func (h *Handler) EditSessionRecordStatus(c gateway.Context) error {
 		return err
 	}
 
+	ns, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	err = evaluateNamespace(c, ns, guard.Actions.Namespace.EnableSessionRecord, func() error {
+		err := h.service.EditSessionRecordStatus(c.Ctx(), req.SessionRecord, ns.TenantID)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) EditNamespaceMFA(c gateway.Context) error {
+	var req request.NamespaceEditMFA
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
 	var uid string
 	if c.ID() != nil {
 		uid = c.ID().ID
@@ -291,10 +323,145 @@ func (h *Handler) EditSessionRecordStatus(c gateway.Context) error {
 		return c.NoContent(http.StatusNotFound)
 	}
 
-	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.EnableSessionRecord, func() error {
-		err := h.service.EditSessionRecordStatus(c.Ctx(), req.SessionRecord, ns.TenantID)
+	err = evaluateNamespace(c, ns, guard.Actions.Namespace.RequireMFA, func() error {
+		return h.service.EditNamespaceMFA(c.Ctx(), req.Required, ns.TenantID, uid)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) EditNamespaceReverseForwarding(c gateway.Context) error {
+	var req request.NamespaceEditReverseForwarding
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if req.Rules == nil {
+		req.Rules = []models.ReverseForwardingRule{}
+	}
+
+	return h.editNamespaceSettings(c, req.Tenant, guard.Actions.Namespace.EditReverseForwarding, &models.NamespaceSettingsUpdate{
+		ReverseForwarding: &models.ReverseForwarding{Enabled: req.Enabled, Rules: req.Rules},
+	})
+}
+
+// EvaluateNamespaceReverseForwarding is used by the SSH server to check if a remote port forwarding can bind a port on
+// the namespace's devices.
+func (h *Handler) EvaluateNamespaceReverseForwarding(c gateway.Context) error {
+	var req request.NamespaceEvaluateReverseForwarding
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	allowed, err := h.service.EvaluateReverseForwarding(c.Ctx(), req.Tenant, req.Port)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, allowed)
+}
+
+func (h *Handler) EditNamespaceAgentForwarding(c gateway.Context) error {
+	var req request.NamespaceEditAgentForwarding
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	return h.editNamespaceSettings(c, req.Tenant, guard.Actions.Namespace.EditAgentForwarding, &models.NamespaceSettingsUpdate{
+		AgentForwarding: &req.Enabled,
+	})
+}
+
+// EvaluateNamespaceAgentForwarding is used by the SSH server to check if the client's SSH agent can be forwarded to the
+// namespace's devices.
+func (h *Handler) EvaluateNamespaceAgentForwarding(c gateway.Context) error {
+	var req request.NamespaceEvaluateAgentForwarding
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
 
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	allowed, err := h.service.EvaluateAgentForwarding(c.Ctx(), req.Tenant)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, allowed)
+}
+
+func (h *Handler) EditNamespaceSessionTimeouts(c gateway.Context) error {
+	var req request.NamespaceEditSessionTimeouts
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	return h.editNamespaceSettings(c, req.Tenant, guard.Actions.Namespace.EditSessionTimeouts, &models.NamespaceSettingsUpdate{
+		SessionTimeouts: &models.SessionTimeouts{IdleTimeout: req.IdleTimeout, MaxDuration: req.MaxDuration},
+	})
+}
+
+func (h *Handler) EditNamespaceProtectedTags(c gateway.Context) error {
+	var req request.NamespaceEditProtectedTags
+	if err := c.Bind(&req); err != nil {
 		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	return h.editNamespaceSettings(c, req.Tenant, guard.Actions.Namespace.EditProtectedTags, &models.NamespaceSettingsUpdate{
+		ProtectedTags: &req.Tags,
+	})
+}
+
+func (h *Handler) EditNamespaceAgentUpdate(c gateway.Context) error {
+	var req request.NamespaceEditAgentUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	return h.editNamespaceSettings(c, req.Tenant, guard.Actions.Namespace.EditAgentUpdate, &models.NamespaceSettingsUpdate{
+		AgentUpdate: &models.AgentUpdate{Hold: req.Hold, Version: req.Version},
+	})
+}
+
+// editNamespaceSettings updates the settings of the namespace when the action is allowed for who performed the
+// request.
+func (h *Handler) editNamespaceSettings(c gateway.Context, tenant string, action int, settings *models.NamespaceSettingsUpdate) error {
+	ns, err := h.service.GetNamespace(c.Ctx(), tenant)
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	err = evaluateNamespace(c, ns, action, func() error {
+		return h.service.EditNamespaceSettings(c.Ctx(), ns.TenantID, settings)
 	})
 	if err != nil {
 		return err
@@ -303,6 +470,45 @@ func (h *Handler) EditSessionRecordStatus(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+// GetNamespaceSessionTimeouts is used by the SSH server to get the limits of how long the SSH sessions to the
+// namespace's devices can last.
+func (h *Handler) GetNamespaceSessionTimeouts(c gateway.Context) error {
+	var req request.NamespaceGetSessionTimeouts
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	timeouts, err := h.service.GetSessionTimeouts(c.Ctx(), req.Tenant)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, timeouts)
+}
+
+// GetNamespaceSessionRecord is used by the SSH server to check if the sessions to the namespace's devices are recorded.
+func (h *Handler) GetNamespaceSessionRecord(c gateway.Context) error {
+	var req request.NamespaceGetSessionRecord
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	status, err := h.service.GetSessionRecord(c.Ctx(), req.Tenant)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
 func (h *Handler) GetSessionRecord(c gateway.Context) error {
 	var tenant string
 	if v := c.Tenant(); v != nil {
@@ -316,3 +522,23 @@ func (h *Handler) GetSessionRecord(c gateway.Context) error {
 
 	return c.JSON(http.StatusOK, status)
 }
+
+// evaluateNamespace evaluates an action over a namespace by who performed the request: the API key's own role, when
+// it was authenticated by one, or the user's membership.
+func evaluateNamespace(c gateway.Context, namespace *models.Namespace, action int, callback func() error) error {
+	if c.APIKey() != "" {
+		var tenant string
+		if c.Tenant() != nil {
+			tenant = c.Tenant().ID
+		}
+
+		return guard.EvaluateAPIKey(namespace, tenant, c.Role(), action, callback)
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	return guard.EvaluateNamespace(namespace, uid, action, callback)
+}
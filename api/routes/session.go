@@ -1,12 +1,16 @@
 package routes
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/labstack/echo/v4"
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/asciicast"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
@@ -19,6 +23,10 @@ const (
 	KeepAliveSessionURL        = "/sessions/:uid/keepalive"
 	RecordSessionURL           = "/sessions/:uid/record"
 	PlaySessionURL             = "/sessions/:uid/play"
+	ExportSessionRecordURL     = "/sessions/:uid/record/export"
+	ShadowSessionURL           = "/sessions/:uid/shadow"
+	RecordSessionActivityURL   = "/sessions/:uid/activity"
+	SetSessionClosureURL       = "/sessions/:uid/closure"
 )
 
 const (
@@ -120,13 +128,161 @@ func (h *Handler) KeepAliveSession(c gateway.Context) error {
 }
 
 func (h *Handler) RecordSession(c gateway.Context) error {
+	var req request.SessionRecord
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.RecordSession(c.Ctx(), models.UID(req.UID), req.Message, req.Width, req.Height); err != nil {
+		return err
+	}
+
 	return c.NoContent(http.StatusOK)
 }
 
-func (h *Handler) PlaySession(c gateway.Context) error {
+func (h *Handler) RecordSessionActivity(c gateway.Context) error {
+	var req request.SessionActivityRecord
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.RecordSessionActivity(c.Ctx(), models.UID(req.UID), &req.SessionActivity); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// SetSessionClosure is used by the SSH server to record why it closed a session.
+func (h *Handler) SetSessionClosure(c gateway.Context) error {
+	var req request.SessionClosure
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.SetSessionClosureReason(c.Ctx(), models.UID(req.UID), req.Reason); err != nil {
+		return err
+	}
+
 	return c.NoContent(http.StatusOK)
 }
 
+func (h *Handler) PlaySession(c gateway.Context) error {
+	var req request.SessionRecordPlay
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var frames []models.RecordedSession
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Play, func() error {
+		var err error
+		frames, err = h.service.GetSessionRecordFrames(c.Ctx(), models.UID(req.UID))
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, frames)
+}
+
+func (h *Handler) ExportSessionRecord(c gateway.Context) error {
+	var req request.SessionRecordExport
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var frames []models.RecordedSession
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Play, func() error {
+		var err error
+		frames, err = h.service.GetSessionRecordFrames(c.Ctx(), models.UID(req.UID))
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, asciicast.ContentType)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", req.UID+".cast"))
+	c.Response().WriteHeader(http.StatusOK)
+
+	return asciicast.Encode(c.Response(), frames)
+}
+
+// ShadowSession grants the member access to join an active session. The returned token is used to open the
+// session's websocket in the SSH server.
+func (h *Handler) ShadowSession(c gateway.Context) error {
+	var req request.SessionShadow
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var username string
+	if c.Username() != nil {
+		username = c.Username().ID
+	}
+
+	var shadow *models.SessionShadow
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Play, func() error {
+		var err error
+		shadow, err = h.service.ShadowSession(c.Ctx(), tenant, models.UID(req.UID), username, req.Writable)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, shadow)
+}
+
 func (h *Handler) DeleteRecordedSession(c gateway.Context) error {
+	var req request.SessionRecordDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Remove, func() error {
+		return h.service.DeleteSessionRecord(c.Ctx(), models.UID(req.UID))
+	})
+	if err != nil {
+		return err
+	}
+
 	return c.NoContent(http.StatusOK)
 }
@@ -24,6 +24,11 @@ const (
 	AddPublicKeyTagURL     = "/sshkeys/public-keys/:fingerprint/tags"      // Add a tag to a public key.
 	RemovePublicKeyTagURL  = "/sshkeys/public-keys/:fingerprint/tags/:tag" // Remove a tag to a public key.
 	UpdatePublicKeyTagsURL = "/sshkeys/public-keys/:fingerprint/tags"      // Update all tags from a public key.
+	GetUserCAsURL          = "/sshkeys/user-cas"                           // List the trusted user certificate authorities.
+	CreateUserCAURL        = "/sshkeys/user-cas"                           // Trust a user certificate authority.
+	DeleteUserCAURL        = "/sshkeys/user-cas/:fingerprint"              // Stop trusting a user certificate authority.
+	EvaluateCertificateURL = "/sshkeys/certificates/evaluate/:username"    // Evaluate a user certificate.
+	KeyRegisteredURL       = "/sshkeys/registered/:fingerprint"            // Check if a public key, or user CA, is registered.
 )
 
 const (
@@ -192,6 +197,21 @@ func (h *Handler) EvaluateKey(c gateway.Context) error {
 	return c.JSON(http.StatusOK, usernameOk && filterOk)
 }
 
+// KeyRegistered responds with 404 when neither a public key nor a trusted user certificate authority with the
+// fingerprint is registered in any namespace.
+func (h *Handler) KeyRegistered(c gateway.Context) error {
+	registered, err := h.service.KeyRegistered(c.Ctx(), c.Param(ParamPublicKeyFingerprint))
+	if err != nil {
+		return err
+	}
+
+	if !registered {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) AddPublicKeyTag(c gateway.Context) error {
 	var req request.PublicKeyTagAdd
 	if err := c.Bind(&req); err != nil {
@@ -266,3 +286,99 @@ func (h *Handler) UpdatePublicKeyTags(c gateway.Context) error {
 
 	return c.NoContent(http.StatusOK)
 }
+
+func (h *Handler) GetUserCAs(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	list, count, err := h.service.ListUserCAs(c.Ctx(), tenant, *query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, list)
+}
+
+func (h *Handler) CreateUserCA(c gateway.Context) error {
+	var req request.UserCACreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var ca *models.UserCA
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.PublicKey.Create, func() error {
+		var err error
+		ca, err = h.service.CreateUserCA(c.Ctx(), tenant, req.Name, req.Data)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, ca)
+}
+
+func (h *Handler) DeleteUserCA(c gateway.Context) error {
+	var req request.UserCADelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.PublicKey.Remove, func() error {
+		return h.service.DeleteUserCA(c.Ctx(), tenant, req.Fingerprint)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// EvaluateCertificate is used by the SSH server to check if a user certificate grants access to a device.
+func (h *Handler) EvaluateCertificate(c gateway.Context) error {
+	var req request.CertificateEvaluate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	evaluation, err := h.service.EvaluateCertificate(c.Ctx(), req.Certificate, req.Device, req.Username, req.Address)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, evaluation)
+}
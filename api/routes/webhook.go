@@ -0,0 +1,203 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/api/response"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListWebhooksURL          = "/webhooks"
+	CreateWebhookURL         = "/webhooks"
+	UpdateWebhookURL         = "/webhooks/:id"
+	DeleteWebhookURL         = "/webhooks/:id"
+	ListWebhookDeliveriesURL = "/webhooks/:id/deliveries"
+	RedeliverWebhookURL      = "/webhooks/:id/deliveries/:delivery/redeliver"
+)
+
+func (h *Handler) CreateWebhook(c gateway.Context) error {
+	var req request.WebhookCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var res *response.WebhookCreate
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Webhook.Create, func() error {
+		var err error
+		res, err = h.service.CreateWebhook(c.Ctx(), tenant, &req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) ListWebhooks(c gateway.Context) error {
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var webhooks []models.Webhook
+	var count int
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Webhook.List, func() error {
+		var err error
+		webhooks, count, err = h.service.ListWebhooks(c.Ctx(), tenant, *query)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, webhooks)
+}
+
+func (h *Handler) UpdateWebhook(c gateway.Context) error {
+	var req request.WebhookUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var webhook *models.Webhook
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Webhook.Edit, func() error {
+		var err error
+		webhook, err = h.service.UpdateWebhook(c.Ctx(), tenant, &req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, webhook)
+}
+
+func (h *Handler) DeleteWebhook(c gateway.Context) error {
+	var req request.WebhookDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Webhook.Remove, func() error {
+		return h.service.DeleteWebhook(c.Ctx(), tenant, req.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ListWebhookDeliveries(c gateway.Context) error {
+	var req request.WebhookDeliveryList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	query := paginator.NewQuery()
+	if err := c.Bind(query); err != nil {
+		return err
+	}
+
+	query.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var deliveries []models.WebhookDelivery
+	var count int
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Webhook.List, func() error {
+		var err error
+		deliveries, count, err = h.service.ListWebhookDeliveries(c.Ctx(), tenant, req.ID, *query)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, deliveries)
+}
+
+func (h *Handler) RedeliverWebhook(c gateway.Context) error {
+	var req request.WebhookRedeliver
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var delivery *models.WebhookDelivery
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Webhook.Edit, func() error {
+		var err error
+		delivery, err = h.service.RedeliverWebhook(c.Ctx(), tenant, req.ID, req.Delivery)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, delivery)
+}
@@ -6,14 +6,17 @@ import (
 	"os"
 
 	"github.com/getsentry/sentry-go"
+	"github.com/hibiken/asynq"
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/shellhub-io/shellhub/api/pkg/echo/handlers"
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/metrics"
 	"github.com/shellhub-io/shellhub/api/routes"
 	apiMiddleware "github.com/shellhub-io/shellhub/api/routes/middleware"
 	"github.com/shellhub-io/shellhub/api/services"
 	"github.com/shellhub-io/shellhub/api/store/mongo"
+	"github.com/shellhub-io/shellhub/api/store/record"
 	"github.com/shellhub-io/shellhub/api/workers"
 	requests "github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	storecache "github.com/shellhub-io/shellhub/pkg/cache"
@@ -67,6 +70,8 @@ type config struct {
 	SessionRecordCleanupSchedule string `envconfig:"session_record_cleanup_schedule" default:"@daily"`
 	// Sentry DSN.
 	SentryDSN string `envconfig:"sentry_dsn" default:""`
+	// Session record storage.
+	record.Config
 }
 
 func init() {
@@ -114,6 +119,7 @@ func startServer(cfg *config) error {
 	log.Info("Starting API server")
 
 	e := echo.New()
+	e.Use(metrics.Middleware)
 	e.Use(middleware.Log)
 	e.Use(echoMiddleware.RequestID())
 	e.Binder = handlers.NewBinder()
@@ -136,7 +142,7 @@ func startServer(cfg *config) error {
 		log.WithError(err).Fatal("Invalid Mongo URI format")
 	}
 
-	clientOptions := options.Client().ApplyURI(cfg.MongoURI)
+	clientOptions := options.Client().ApplyURI(cfg.MongoURI).SetMonitor(metrics.CommandMonitor())
 	client, err := mongodriver.Connect(ctx, clientOptions)
 	if err != nil {
 		log.WithError(err).Fatal("Failed to connect to MongoDB")
@@ -168,8 +174,35 @@ func startServer(cfg *config) error {
 		locator = geoip.NewNullGeoLite()
 	}
 
-	store := mongo.NewStore(client.Database(connStr.Database), cache)
-	service := services.NewService(store, nil, nil, cache, requestClient, locator)
+	var opts []mongo.Option
+
+	storage, err := record.NewStorage(cfg.Config)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to configure the session record storage")
+	}
+
+	if storage != nil {
+		log.WithField("storage", cfg.Storage).Info("Session records are kept outside the database")
+
+		opts = append(opts, mongo.WithRecorder(record.NewRecorder(storage)))
+	}
+
+	store := mongo.NewStore(client.Database(connStr.Database), cache, opts...)
+	redis, err := asynq.ParseRedisURI(cfg.RedisURI)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to parse redis uri")
+	}
+
+	tasks := asynq.NewClient(redis)
+	defer tasks.Close()
+
+	service := services.NewService(store, nil, nil, cache, requestClient, locator, services.WithEnqueuer(tasks))
+
+	go func() {
+		if err := workers.StartWebhooks(ctx, redis, service); err != nil {
+			log.WithError(err).Fatal("Failed to start webhook worker")
+		}
+	}()
 	handler := routes.NewHandler(service)
 
 	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -180,6 +213,8 @@ func startServer(cfg *config) error {
 		}
 	})
 
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+
 	// Public routes for external access through API gateway
 	publicAPI := e.Group("/api")
 
@@ -191,15 +226,33 @@ func startServer(cfg *config) error {
 	publicAPI.POST(routes.AuthDeviceURLV2, gateway.Handler(handler.AuthDevice))
 	publicAPI.POST(routes.AuthUserURL, gateway.Handler(handler.AuthUser))
 	publicAPI.POST(routes.AuthUserURLV2, gateway.Handler(handler.AuthUser))
-	publicAPI.GET(routes.AuthUserURLV2, gateway.Handler(handler.AuthUserInfo))
+	publicAPI.GET(routes.AuthUserURLV2,
+		apiMiddleware.RejectAPIKey(gateway.Handler(handler.AuthUserInfo)))
 	internalAPI.GET(routes.AuthUserTokenURL, gateway.Handler(handler.AuthGetToken))
 	publicAPI.POST(routes.AuthPublicKeyURL, gateway.Handler(handler.AuthPublicKey))
-	publicAPI.GET(routes.AuthUserTokenURL, gateway.Handler(handler.AuthSwapToken))
-
-	publicAPI.PATCH(routes.UpdateUserDataURL, gateway.Handler(handler.UpdateUserData))
-	publicAPI.PATCH(routes.UpdateUserPasswordURL, gateway.Handler(handler.UpdateUserPassword))
+	publicAPI.POST(routes.AuthCertificateURL, gateway.Handler(handler.AuthCertificate))
+	publicAPI.GET(routes.AuthUserTokenURL,
+		apiMiddleware.RejectAPIKey(gateway.Handler(handler.AuthSwapToken)))
+	publicAPI.POST(routes.AuthMFAURL, gateway.Handler(handler.AuthMFA))
+	internalAPI.POST(routes.EvaluateAuthAttemptURL, gateway.Handler(handler.EvaluateAuthAttempt))
+	internalAPI.POST(routes.RecordAuthFailureURL, gateway.Handler(handler.RecordAuthFailure))
+	internalAPI.GET(routes.ListAuthBansURL, gateway.Handler(handler.ListAuthBans))
+	internalAPI.DELETE(routes.LiftAuthBanURL, gateway.Handler(handler.LiftAuthBan))
+
+	publicAPI.POST(routes.GenerateMFAURL,
+		apiMiddleware.RejectAPIKey(gateway.Handler(handler.GenerateMFA)))
+	publicAPI.PUT(routes.EnableMFAURL,
+		apiMiddleware.RejectAPIKey(gateway.Handler(handler.EnableMFA)))
+	publicAPI.PUT(routes.DisableMFAURL,
+		apiMiddleware.RejectAPIKey(gateway.Handler(handler.DisableMFA)))
+
+	publicAPI.PATCH(routes.UpdateUserDataURL,
+		apiMiddleware.RejectAPIKey(gateway.Handler(handler.UpdateUserData)))
+	publicAPI.PATCH(routes.UpdateUserPasswordURL,
+		apiMiddleware.RejectAPIKey(gateway.Handler(handler.UpdateUserPassword)))
 	publicAPI.PUT(routes.EditSessionRecordStatusURL, gateway.Handler(handler.EditSessionRecordStatus))
 	publicAPI.GET(routes.GetSessionRecordURL, gateway.Handler(handler.GetSessionRecord))
+	internalAPI.GET(routes.GetNamespaceSessionRecordURL, gateway.Handler(handler.GetNamespaceSessionRecord))
 
 	publicAPI.GET(routes.GetDeviceListURL,
 		apiMiddleware.Authorize(gateway.Handler(handler.GetDeviceList)))
@@ -212,6 +265,17 @@ func startServer(cfg *config) error {
 	internalAPI.POST(routes.HeartbeatDeviceURL, gateway.Handler(handler.HeartbeatDevice))
 	internalAPI.GET(routes.LookupDeviceURL, gateway.Handler(handler.LookupDevice))
 	publicAPI.PATCH(routes.UpdateStatusURL, gateway.Handler(handler.UpdatePendingStatus))
+	publicAPI.POST(routes.BulkDevicesURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.BulkDevices)))
+	publicAPI.PUT(routes.UpdateDeviceAttributesURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.UpdateDeviceAttributes)))
+	publicAPI.DELETE(routes.ResetDeviceHostKeyURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.ResetDeviceHostKey)))
+	publicAPI.POST(routes.ReportDeviceHealthURL, gateway.Handler(handler.ReportDeviceHealth))
+	publicAPI.GET(routes.GetDeviceAttributeKeysURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetDeviceAttributeKeys)))
+	publicAPI.GET(routes.GetInventoryURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetInventory)))
 
 	publicAPI.POST(routes.CreateTagURL, gateway.Handler(handler.CreateDeviceTag))
 	publicAPI.DELETE(routes.RemoveTagURL, gateway.Handler(handler.RemoveDeviceTag))
@@ -230,8 +294,14 @@ func startServer(cfg *config) error {
 	internalAPI.POST(routes.FinishSessionURL, gateway.Handler(handler.FinishSession))
 	internalAPI.POST(routes.KeepAliveSessionURL, gateway.Handler(handler.KeepAliveSession))
 	internalAPI.POST(routes.RecordSessionURL, gateway.Handler(handler.RecordSession))
+	internalAPI.POST(routes.RecordSessionActivityURL, gateway.Handler(handler.RecordSessionActivity))
+	internalAPI.POST(routes.SetSessionClosureURL, gateway.Handler(handler.SetSessionClosure))
 	publicAPI.GET(routes.PlaySessionURL, gateway.Handler(handler.PlaySession))
 	publicAPI.DELETE(routes.RecordSessionURL, gateway.Handler(handler.DeleteRecordedSession))
+	publicAPI.GET(routes.ExportSessionRecordURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.ExportSessionRecord)))
+	publicAPI.POST(routes.ShadowSessionURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.ShadowSession)))
 
 	publicAPI.GET(routes.GetStatsURL,
 		apiMiddleware.Authorize(gateway.Handler(handler.GetStats)))
@@ -248,14 +318,69 @@ func startServer(cfg *config) error {
 	publicAPI.DELETE(routes.RemovePublicKeyTagURL, gateway.Handler(handler.RemovePublicKeyTag))
 	publicAPI.PUT(routes.UpdatePublicKeyTagsURL, gateway.Handler(handler.UpdatePublicKeyTags))
 
-	publicAPI.GET(routes.ListNamespaceURL, gateway.Handler(handler.GetNamespaceList))
+	publicAPI.GET(routes.GetUserCAsURL, gateway.Handler(handler.GetUserCAs))
+	publicAPI.POST(routes.CreateUserCAURL, gateway.Handler(handler.CreateUserCA))
+	publicAPI.DELETE(routes.DeleteUserCAURL, gateway.Handler(handler.DeleteUserCA))
+	internalAPI.POST(routes.EvaluateCertificateURL, gateway.Handler(handler.EvaluateCertificate))
+	internalAPI.GET(routes.KeyRegisteredURL, gateway.Handler(handler.KeyRegistered))
+
+	publicAPI.GET(routes.ListNamespaceURL,
+		apiMiddleware.RejectAPIKey(gateway.Handler(handler.GetNamespaceList)))
 	publicAPI.GET(routes.GetNamespaceURL, gateway.Handler(handler.GetNamespace))
-	publicAPI.POST(routes.CreateNamespaceURL, gateway.Handler(handler.CreateNamespace))
+	publicAPI.POST(routes.CreateNamespaceURL,
+		apiMiddleware.RejectAPIKey(gateway.Handler(handler.CreateNamespace)))
 	publicAPI.DELETE(routes.DeleteNamespaceURL, gateway.Handler(handler.DeleteNamespace))
 	publicAPI.PUT(routes.EditNamespaceURL, gateway.Handler(handler.EditNamespace))
-	publicAPI.POST(routes.AddNamespaceUserURL, gateway.Handler(handler.AddNamespaceUser))
-	publicAPI.DELETE(routes.RemoveNamespaceUserURL, gateway.Handler(handler.RemoveNamespaceUser))
-	publicAPI.PATCH(routes.EditNamespaceUserURL, gateway.Handler(handler.EditNamespaceUser))
+	publicAPI.POST(routes.AddNamespaceUserURL,
+		apiMiddleware.RejectAPIKey(gateway.Handler(handler.AddNamespaceUser)))
+	publicAPI.DELETE(routes.RemoveNamespaceUserURL,
+		apiMiddleware.RejectAPIKey(gateway.Handler(handler.RemoveNamespaceUser)))
+	publicAPI.PATCH(routes.EditNamespaceUserURL,
+		apiMiddleware.RejectAPIKey(gateway.Handler(handler.EditNamespaceUser)))
+	publicAPI.PUT(routes.EditNamespaceMFAURL,
+		apiMiddleware.RejectAPIKey(gateway.Handler(handler.EditNamespaceMFA)))
+	publicAPI.PUT(routes.EditNamespaceReverseForwardingURL, gateway.Handler(handler.EditNamespaceReverseForwarding))
+	internalAPI.GET(routes.EvaluateNamespaceReverseForwardingURL, gateway.Handler(handler.EvaluateNamespaceReverseForwarding))
+	publicAPI.PUT(routes.EditNamespaceAgentForwardingURL, gateway.Handler(handler.EditNamespaceAgentForwarding))
+	internalAPI.GET(routes.EvaluateNamespaceAgentForwardingURL, gateway.Handler(handler.EvaluateNamespaceAgentForwarding))
+	publicAPI.PUT(routes.EditNamespaceSessionTimeoutsURL, gateway.Handler(handler.EditNamespaceSessionTimeouts))
+	internalAPI.GET(routes.GetNamespaceSessionTimeoutsURL, gateway.Handler(handler.GetNamespaceSessionTimeouts))
+	publicAPI.PUT(routes.EditNamespaceProtectedTagsURL, gateway.Handler(handler.EditNamespaceProtectedTags))
+	publicAPI.PUT(routes.EditNamespaceAgentUpdateURL, gateway.Handler(handler.EditNamespaceAgentUpdate))
+
+	publicAPI.GET(routes.ListAccessRequestsURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.ListAccessRequests)))
+	publicAPI.POST(routes.ApproveAccessRequestURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.ApproveAccessRequest)))
+	publicAPI.POST(routes.DenyAccessRequestURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.DenyAccessRequest)))
+	internalAPI.POST(routes.CreateAccessRequestURL, gateway.Handler(handler.CreateAccessRequest))
+	internalAPI.GET(routes.GetAccessRequestURL, gateway.Handler(handler.GetAccessRequest))
+
+	publicAPI.GET(routes.GetAuditLogsURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.GetAuditLogs)))
+
+	publicAPI.GET(routes.ListAPIKeysURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.ListAPIKeys)))
+	publicAPI.POST(routes.CreateAPIKeyURL,
+		apiMiddleware.RejectAPIKey(apiMiddleware.Authorize(gateway.Handler(handler.CreateAPIKey))))
+	publicAPI.PATCH(routes.UpdateAPIKeyURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.UpdateAPIKey)))
+	publicAPI.DELETE(routes.DeleteAPIKeyURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.DeleteAPIKey)))
+
+	publicAPI.GET(routes.ListWebhooksURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.ListWebhooks)))
+	publicAPI.POST(routes.CreateWebhookURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.CreateWebhook)))
+	publicAPI.PATCH(routes.UpdateWebhookURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.UpdateWebhook)))
+	publicAPI.DELETE(routes.DeleteWebhookURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.DeleteWebhook)))
+	publicAPI.GET(routes.ListWebhookDeliveriesURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.ListWebhookDeliveries)))
+	publicAPI.POST(routes.RedeliverWebhookURL,
+		apiMiddleware.Authorize(gateway.Handler(handler.RedeliverWebhook)))
 
 	e.Logger.Fatal(e.Start(":8080"))
 
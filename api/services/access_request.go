@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+// AccessRequestService contains the service's functions to require a namespace's administrator to approve the
+// connections to the devices with the namespace's protected tags.
+type AccessRequestService interface {
+	ListAccessRequests(ctx context.Context, tenant, status string, pagination paginator.Query) ([]models.AccessRequest, int, error)
+	CreateAccessRequest(ctx context.Context, req request.AccessRequestCreate) (*models.AccessRequest, error)
+	GetAccessRequest(ctx context.Context, id string) (*models.AccessRequest, error)
+	ApproveAccessRequest(ctx context.Context, tenant, id, username string, duration time.Duration) error
+	DenyAccessRequest(ctx context.Context, tenant, id, username string) error
+}
+
+// ListAccessRequests lists the namespace's access requests with status, or all of them when status is empty. The
+// pending requests that were not decided in time are returned as expired.
+func (s *service) ListAccessRequests(ctx context.Context, tenant, status string, pagination paginator.Query) ([]models.AccessRequest, int, error) {
+	requests, count, err := s.store.AccessRequestList(ctx, tenant, status, pagination)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range requests {
+		expireAccessRequest(&requests[i])
+	}
+
+	return requests, count, nil
+}
+
+// CreateAccessRequest requests the access of a session to a device. It returns nil when the device has none of its
+// namespace's protected tags, as its connections do not need to be approved, and the approved request granting the
+// access when there is one.
+//
+// It returns NewErrDeviceNotFound when the device does not exist.
+func (s *service) CreateAccessRequest(ctx context.Context, req request.AccessRequestCreate) (*models.AccessRequest, error) {
+	device, err := s.store.DeviceGet(ctx, models.UID(req.DeviceUID))
+	if err != nil {
+		return nil, NewErrDeviceNotFound(models.UID(req.DeviceUID), err)
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, device.TenantID)
+	if err != nil || namespace == nil {
+		return nil, NewErrNamespaceNotFound(device.TenantID, err)
+	}
+
+	if !isProtected(namespace, device) {
+		return nil, nil
+	}
+
+	grant, err := s.store.AccessRequestGetGrant(ctx, models.UID(device.UID), req.Username, req.IPAddress)
+	switch {
+	case err == nil:
+		return grant, nil
+	case err != store.ErrNoDocuments:
+		return nil, err
+	}
+
+	created := &models.AccessRequest{
+		ID:         uuid.Generate(),
+		TenantID:   device.TenantID,
+		DeviceUID:  models.UID(device.UID),
+		SessionUID: req.SessionUID,
+		Username:   req.Username,
+		IPAddress:  req.IPAddress,
+		Status:     models.AccessRequestPending,
+		CreatedAt:  clock.Now(),
+	}
+
+	if err := s.store.AccessRequestCreate(ctx, created); err != nil {
+		return nil, err
+	}
+
+	s.emit(ctx, created.TenantID, webhook.EventAccessRequested, created)
+
+	return created, nil
+}
+
+// GetAccessRequest returns an access request, as expired when it was not decided in time.
+//
+// It returns NewErrAccessRequestNotFound when the request does not exist.
+func (s *service) GetAccessRequest(ctx context.Context, id string) (*models.AccessRequest, error) {
+	req, err := s.store.AccessRequestGet(ctx, id)
+	if err != nil {
+		return nil, NewErrAccessRequestNotFound(id, err)
+	}
+
+	expireAccessRequest(req)
+
+	return req, nil
+}
+
+// ApproveAccessRequest lets the waiting session connect to the device. When duration is not zero, the approval also
+// grants the same user, from the same address, access to the device for duration without a new request.
+//
+// It returns NewErrAccessRequestNotFound when the request does not belong to the namespace and
+// NewErrAccessRequestNotPending when it was already decided or expired.
+func (s *service) ApproveAccessRequest(ctx context.Context, tenant, id, username string, duration time.Duration) error {
+	decision := &models.AccessRequestDecision{
+		Status:    models.AccessRequestApproved,
+		DecidedBy: username,
+		DecidedAt: clock.Now(),
+	}
+
+	if duration > 0 {
+		until := decision.DecidedAt.Add(duration)
+		decision.GrantUntil = &until
+	}
+
+	_, err := s.decideAccessRequest(ctx, tenant, id, decision)
+
+	return err
+}
+
+// DenyAccessRequest refuses the connection of the waiting session to the device, recording it as a closed session.
+//
+// It returns NewErrAccessRequestNotFound when the request does not belong to the namespace and
+// NewErrAccessRequestNotPending when it was already decided or expired.
+func (s *service) DenyAccessRequest(ctx context.Context, tenant, id, username string) error {
+	req, err := s.decideAccessRequest(ctx, tenant, id, &models.AccessRequestDecision{
+		Status:    models.AccessRequestDenied,
+		DecidedBy: username,
+		DecidedAt: clock.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// As a denied session is never established, it is only recorded here.
+	session, err := s.store.SessionCreate(ctx, models.Session{
+		UID:           req.SessionUID,
+		DeviceUID:     req.DeviceUID,
+		Username:      req.Username,
+		IPAddress:     req.IPAddress,
+		Type:          "unknown",
+		AccessRequest: req,
+	})
+	if err != nil {
+		return NewErrDeviceNotFound(req.DeviceUID, err)
+	}
+
+	return s.store.SessionDeleteActives(ctx, models.UID(session.UID))
+}
+
+// decideAccessRequest records a decision over a namespace's pending request, returning the decided request.
+func (s *service) decideAccessRequest(ctx context.Context, tenant, id string, decision *models.AccessRequestDecision) (*models.AccessRequest, error) {
+	req, err := s.store.AccessRequestGet(ctx, id)
+	if err != nil || req.TenantID != tenant {
+		return nil, NewErrAccessRequestNotFound(id, err)
+	}
+
+	if err := s.store.AccessRequestDecide(ctx, id, decision); err != nil {
+		return nil, NewErrAccessRequestNotPending(id, err)
+	}
+
+	before := map[string]interface{}{"status": req.Status}
+
+	req.Status = decision.Status
+	req.DecidedBy = decision.DecidedBy
+	req.DecidedAt = &decision.DecidedAt
+	req.GrantUntil = decision.GrantUntil
+
+	s.audit(ctx, tenant, models.AuditAccessRequestDecide, models.AuditTarget{Type: models.AuditTargetAccessRequest, ID: id}, before, map[string]interface{}{"status": req.Status, "grant_until": req.GrantUntil})
+
+	return req, nil
+}
+
+// expireAccessRequest sets the status of a pending request that was not decided in time as expired.
+func expireAccessRequest(req *models.AccessRequest) {
+	if req.Status == models.AccessRequestPending && clock.Now().After(req.CreatedAt.Add(models.AccessRequestTimeout)) {
+		req.Status = models.AccessRequestExpired
+	}
+}
+
+// protectedTags returns the tags of a namespace's devices whose connections must be approved.
+func protectedTags(namespace *models.Namespace) []string {
+	if namespace.Settings == nil {
+		return nil
+	}
+
+	return namespace.Settings.ProtectedTags
+}
+
+// isProtected checks if the device has any of its namespace's protected tags.
+func isProtected(namespace *models.Namespace, device *models.Device) bool {
+	for _, protected := range protectedTags(namespace) {
+		for _, tag := range device.Tags {
+			if tag == protected {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,194 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateAccessRequest(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	req := request.AccessRequestCreate{DeviceUID: "device", SessionUID: "session", Username: "root", IPAddress: "10.0.0.1"}
+
+	device := &models.Device{UID: "device", TenantID: "tenant", Tags: []string{"production"}}
+	protected := &models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{ProtectedTags: []string{"production"}}}
+	grant := &models.AccessRequest{ID: "grant", Status: models.AccessRequestApproved}
+
+	type Expected struct {
+		req *models.AccessRequest
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("device")).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound("device", Err)},
+		},
+		{
+			description: "succeeds without a request when the device is not protected",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("device")).Return(device, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+			},
+			expected: Expected{nil, nil},
+		},
+		{
+			description: "succeeds to return the request granting the access",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("device")).Return(device, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(protected, nil).Once()
+				storeMock.On("AccessRequestGetGrant", ctx, models.UID("device"), "root", "10.0.0.1").Return(grant, nil).Once()
+			},
+			expected: Expected{grant, nil},
+		},
+		{
+			description: "succeeds to create a pending request",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("device")).Return(device, nil).Once()
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(protected, nil).Once()
+				storeMock.On("AccessRequestGetGrant", ctx, models.UID("device"), "root", "10.0.0.1").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("AccessRequestCreate", ctx, mock.MatchedBy(func(created *models.AccessRequest) bool {
+					return created.Status == models.AccessRequestPending && created.SessionUID == "session"
+				})).Return(nil).Once()
+			},
+			expected: Expected{&models.AccessRequest{
+				TenantID:   "tenant",
+				DeviceUID:  "device",
+				SessionUID: "session",
+				Username:   "root",
+				IPAddress:  "10.0.0.1",
+				Status:     models.AccessRequestPending,
+				CreatedAt:  now,
+			}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			created, err := s.CreateAccessRequest(ctx, req)
+			if created != nil && created.Status == models.AccessRequestPending {
+				// The request's ID is randomly generated.
+				created.ID = ""
+			}
+
+			assert.Equal(t, tc.expected, Expected{created, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestApproveAccessRequest(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	pending := func() *models.AccessRequest {
+		return &models.AccessRequest{ID: "id", TenantID: "tenant", Status: models.AccessRequestPending}
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the request belongs to another namespace",
+			tenant:      "other",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("AccessRequestGet", ctx, "id").Return(pending(), nil).Once()
+			},
+			expected: NewErrAccessRequestNotFound("id", nil),
+		},
+		{
+			description: "fails when the request is not pending",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("AccessRequestGet", ctx, "id").Return(pending(), nil).Once()
+				storeMock.On("AccessRequestDecide", ctx, "id", mock.Anything).Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrAccessRequestNotPending("id", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds to approve the request with a grant",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("AccessRequestGet", ctx, "id").Return(pending(), nil).Once()
+				storeMock.On("AccessRequestDecide", ctx, "id", mock.MatchedBy(func(decision *models.AccessRequestDecision) bool {
+					return decision.Status == models.AccessRequestApproved &&
+						decision.DecidedBy == "admin" &&
+						decision.GrantUntil != nil && decision.GrantUntil.Equal(now.Add(time.Hour))
+				})).Return(nil).Once()
+				storeMock.On("AuditCreate", ctx, mock.MatchedBy(func(entry *models.AuditLog) bool {
+					return entry.Action == models.AuditAccessRequestDecide && entry.After["status"] == models.AccessRequestApproved
+				})).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.ApproveAccessRequest(ctx, tc.tenant, "id", "admin", time.Hour)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestDenyAccessRequest(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	clockMock.On("Now").Return(now).Once()
+	storeMock.On("AccessRequestGet", ctx, "id").Return(&models.AccessRequest{ID: "id", TenantID: "tenant", DeviceUID: "device", SessionUID: "session", Status: models.AccessRequestPending}, nil).Once()
+	storeMock.On("AccessRequestDecide", ctx, "id", mock.Anything).Return(nil).Once()
+	storeMock.On("AuditCreate", ctx, mock.Anything).Return(nil).Once()
+	storeMock.On("SessionCreate", ctx, mock.MatchedBy(func(session models.Session) bool {
+		return session.UID == "session" &&
+			session.AccessRequest != nil &&
+			session.AccessRequest.Status == models.AccessRequestDenied &&
+			session.AccessRequest.DecidedBy == "admin"
+	})).Return(&models.Session{UID: "session"}, nil).Once()
+	storeMock.On("SessionDeleteActives", ctx, models.UID("session")).Return(nil).Once()
+
+	err := s.DenyAccessRequest(ctx, "tenant", "id", "admin")
+	assert.NoError(t, err)
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// AgentForwardingService contains the service's functions to enforce the forwarding of the members' SSH agents to the
+// namespace's devices.
+type AgentForwardingService interface {
+	EvaluateAgentForwarding(ctx context.Context, tenantID string) (bool, error)
+}
+
+// EvaluateAgentForwarding checks if the members' SSH agents can be forwarded to the namespace's devices. It is
+// disabled until enabled by the namespace.
+//
+// If the namespace does not exist, a NewErrNamespaceNotFound error will be returned.
+func (s *service) EvaluateAgentForwarding(ctx context.Context, tenantID string) (bool, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenantID)
+	if err != nil || namespace == nil {
+		return false, NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	return agentForwarding(namespace), nil
+}
+
+// agentForwarding returns if the agent forwarding is enabled in a namespace.
+func agentForwarding(namespace *models.Namespace) bool {
+	return namespace.Settings != nil && namespace.Settings.AgentForwarding
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateAgentForwarding(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		allowed bool
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace is not found",
+			tenant:      "invalid",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "invalid").Return(nil, Err).Once()
+			},
+			expected: Expected{false, NewErrNamespaceNotFound("invalid", Err)},
+		},
+		{
+			description: "denies when the namespace has no settings",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+			},
+			expected: Expected{false, nil},
+		},
+		{
+			description: "allows when the namespace enabled it",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{AgentForwarding: true}}, nil).Once()
+			},
+			expected: Expected{true, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			allowed, err := s.EvaluateAgentForwarding(ctx, tc.tenant)
+			assert.Equal(t, tc.expected, Expected{allowed, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/api/response"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// apiKeySize is the number of random bytes used to generate an API key's secret.
+const apiKeySize = 32
+
+type APIKeyService interface {
+	CreateAPIKey(ctx context.Context, tenant, creator, role string, req *request.APIKeyCreate) (*response.APIKeyCreate, error)
+	ListAPIKeys(ctx context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error)
+	UpdateAPIKey(ctx context.Context, tenant, role string, req *request.APIKeyUpdate) (*models.APIKey, error)
+	DeleteAPIKey(ctx context.Context, tenant, role, id string) error
+	AuthAPIKey(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+// CreateAPIKey creates a new API key to a namespace.
+//
+// It receives a context, used to "control" the request flow, the tenant ID from models.Namespace, the ID of who is
+// creating the key, its role in the namespace and the request data. As a member cannot add another member with a role
+// equal or higher than its own, the creator's role must be able to act over the key's role.
+//
+// CreateAPIKey returns the created models.APIKey with its secret in plain text, what is never returned again, and an
+// error. When error is not nil, the response is nil.
+func (s *service) CreateAPIKey(ctx context.Context, tenant, creator, role string, req *request.APIKeyCreate) (*response.APIKeyCreate, error) {
+	if _, err := s.store.NamespaceGet(ctx, tenant); err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	if !guard.CheckRole(role, req.Role) {
+		return nil, guard.ErrForbidden
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(clock.Now()) {
+		return nil, NewErrAPIKeyInvalid(map[string]interface{}{"expires_at": req.ExpiresAt}, nil)
+	}
+
+	if key, _ := s.store.APIKeyGetByName(ctx, tenant, req.Name); key != nil {
+		return nil, NewErrAPIKeyDuplicated(req.Name, nil)
+	}
+
+	secret := make([]byte, apiKeySize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	plain := hex.EncodeToString(secret)
+
+	key := &models.APIKey{
+		ID:        uuid.Generate(),
+		TenantID:  tenant,
+		Name:      req.Name,
+		Role:      req.Role,
+		Digest:    digestAPIKey(plain),
+		CreatedBy: creator,
+		CreatedAt: clock.Now(),
+		ExpiresAt: req.ExpiresAt,
+	}
+
+	if err := s.store.APIKeyCreate(ctx, key); err != nil {
+		if err == store.ErrDuplicate {
+			return nil, NewErrAPIKeyDuplicated(req.Name, err)
+		}
+
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditAPIKeyCreate, models.AuditTarget{Type: models.AuditTargetAPIKey, ID: key.ID}, nil, map[string]interface{}{"name": key.Name, "role": key.Role})
+
+	return &response.APIKeyCreate{APIKey: *key, Key: plain}, nil
+}
+
+// ListAPIKeys lists the API keys of a namespace.
+//
+// It receives a context, used to "control" the request flow, the tenant ID from models.Namespace and a pagination query.
+//
+// ListAPIKeys returns a slice of models.APIKey, the total of keys and an error. The keys' secrets are never returned.
+func (s *service) ListAPIKeys(ctx context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error) {
+	if _, err := s.store.NamespaceGet(ctx, tenant); err != nil {
+		return nil, 0, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	return s.store.APIKeyList(ctx, tenant, pagination)
+}
+
+// UpdateAPIKey updates the name or the role of an API key.
+//
+// The role of who is updating the key must be able to act over the key's current role and over the new one.
+func (s *service) UpdateAPIKey(ctx context.Context, tenant, role string, req *request.APIKeyUpdate) (*models.APIKey, error) {
+	key, err := s.store.APIKeyGet(ctx, tenant, req.ID)
+	if err != nil {
+		return nil, NewErrAPIKeyNotFound(req.ID, err)
+	}
+
+	if !guard.CheckRole(role, key.Role) || (req.Role != "" && !guard.CheckRole(role, req.Role)) {
+		return nil, guard.ErrForbidden
+	}
+
+	if req.Name != "" && req.Name != key.Name {
+		if other, _ := s.store.APIKeyGetByName(ctx, tenant, req.Name); other != nil {
+			return nil, NewErrAPIKeyDuplicated(req.Name, nil)
+		}
+	}
+
+	if err := s.store.APIKeyUpdate(ctx, tenant, req.ID, &models.APIKeyUpdate{Name: req.Name, Role: req.Role}); err != nil {
+		return nil, err
+	}
+
+	before := map[string]interface{}{"name": key.Name, "role": key.Role}
+
+	if req.Name != "" {
+		key.Name = req.Name
+	}
+
+	if req.Role != "" {
+		key.Role = req.Role
+	}
+
+	s.audit(ctx, tenant, models.AuditAPIKeyUpdate, models.AuditTarget{Type: models.AuditTargetAPIKey, ID: key.ID}, before, map[string]interface{}{"name": key.Name, "role": key.Role})
+
+	return key, nil
+}
+
+// DeleteAPIKey deletes an API key from a namespace.
+//
+// The role of who is deleting the key must be able to act over the key's role.
+func (s *service) DeleteAPIKey(ctx context.Context, tenant, role, id string) error {
+	key, err := s.store.APIKeyGet(ctx, tenant, id)
+	if err != nil {
+		return NewErrAPIKeyNotFound(id, err)
+	}
+
+	if !guard.CheckRole(role, key.Role) {
+		return guard.ErrForbidden
+	}
+
+	if err := s.store.APIKeyDelete(ctx, tenant, id); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditAPIKeyDelete, models.AuditTarget{Type: models.AuditTargetAPIKey, ID: key.ID}, map[string]interface{}{"name": key.Name, "role": key.Role}, nil)
+
+	return nil
+}
+
+// AuthAPIKey authenticates a request through an API key's secret.
+//
+// When the key is valid and not expired, its last used time is updated and the models.APIKey is returned, so the
+// caller can act with the key's tenant and role.
+func (s *service) AuthAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	apiKey, err := s.store.APIKeyGetByDigest(ctx, digestAPIKey(key))
+	if err != nil {
+		return nil, NewErrAuthUnathorized(err)
+	}
+
+	if apiKey.Expired(clock.Now()) {
+		return nil, NewErrAuthUnathorized(NewErrAPIKeyExpired(nil))
+	}
+
+	if err := s.store.APIKeySetLastUsed(ctx, apiKey.ID); err != nil {
+		logrus.WithError(err).WithField("id", apiKey.ID).Warn("Failed to set the API key's last used time")
+	}
+
+	return apiKey, nil
+}
+
+// digestAPIKey returns the hexadecimal SHA256 digest of an API key's secret.
+func digestAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
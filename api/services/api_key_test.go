@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateAPIKey(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{Name: "group1", Owner: "hash1", TenantID: "a736a52b-5777-4f92-b0b8-e359bf484713"}
+	past := now.Add(-time.Hour)
+
+	Err := errors.New("error")
+
+	cases := []struct {
+		name          string
+		role          string
+		req           *request.APIKeyCreate
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "CreateAPIKey fails when the namespace is not found",
+			role: guard.RoleOwner,
+			req:  &request.APIKeyCreate{Name: "ci", RoleBody: request.RoleBody{Role: guard.RoleOperator}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotFound(namespace.TenantID, Err),
+		},
+		{
+			name: "CreateAPIKey fails when the creator's role cannot act over the key's role",
+			role: guard.RoleAdministrator,
+			req:  &request.APIKeyCreate{Name: "ci", RoleBody: request.RoleBody{Role: guard.RoleAdministrator}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+			},
+			expected: guard.ErrForbidden,
+		},
+		{
+			name: "CreateAPIKey fails when the expiration time has passed",
+			role: guard.RoleOwner,
+			req:  &request.APIKeyCreate{Name: "ci", RoleBody: request.RoleBody{Role: guard.RoleOperator}, ExpiresAt: &past},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: NewErrAPIKeyInvalid(map[string]interface{}{"expires_at": &past}, nil),
+		},
+		{
+			name: "CreateAPIKey fails when the name is duplicated",
+			role: guard.RoleOwner,
+			req:  &request.APIKeyCreate{Name: "ci", RoleBody: request.RoleBody{Role: guard.RoleOperator}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+				mock.On("APIKeyGetByName", ctx, namespace.TenantID, "ci").Return(&models.APIKey{Name: "ci"}, nil).Once()
+			},
+			expected: NewErrAPIKeyDuplicated("ci", nil),
+		},
+		{
+			name: "CreateAPIKey succeeds",
+			role: guard.RoleOwner,
+			req:  &request.APIKeyCreate{Name: "ci", RoleBody: request.RoleBody{Role: guard.RoleOperator}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+				mock.On("APIKeyGetByName", ctx, namespace.TenantID, "ci").Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("APIKeyCreate", ctx, testifymock.AnythingOfType("*models.APIKey")).Return(nil).Once()
+				mock.On("AuditCreate", ctx, testifymock.AnythingOfType("*models.AuditLog")).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			res, err := s.CreateAPIKey(ctx, namespace.TenantID, "hash1", tc.role, tc.req)
+			assert.Equal(t, tc.expected, err)
+			if err == nil {
+				assert.Equal(t, digestAPIKey(res.Key), res.Digest)
+				assert.Equal(t, tc.req.Role, res.Role)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteAPIKey(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	key := &models.APIKey{ID: "id", TenantID: "tenant", Name: "ci", Role: guard.RoleAdministrator}
+
+	Err := errors.New("error")
+
+	cases := []struct {
+		name          string
+		role          string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "DeleteAPIKey fails when the key is not found",
+			role: guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("APIKeyGet", ctx, key.TenantID, key.ID).Return(nil, Err).Once()
+			},
+			expected: NewErrAPIKeyNotFound(key.ID, Err),
+		},
+		{
+			name: "DeleteAPIKey fails when the role cannot act over the key's role",
+			role: guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("APIKeyGet", ctx, key.TenantID, key.ID).Return(key, nil).Once()
+			},
+			expected: guard.ErrForbidden,
+		},
+		{
+			name: "DeleteAPIKey succeeds",
+			role: guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("APIKeyGet", ctx, key.TenantID, key.ID).Return(key, nil).Once()
+				mock.On("APIKeyDelete", ctx, key.TenantID, key.ID).Return(nil).Once()
+				mock.On("AuditCreate", ctx, testifymock.AnythingOfType("*models.AuditLog")).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			err := s.DeleteAPIKey(ctx, key.TenantID, tc.role, key.ID)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestAuthAPIKey(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	past := now.Add(-time.Hour)
+	key := &models.APIKey{ID: "id", TenantID: "tenant", Name: "ci", Role: guard.RoleOperator}
+	expired := &models.APIKey{ID: "id", TenantID: "tenant", Name: "ci", Role: guard.RoleOperator, ExpiresAt: &past}
+
+	type Expected struct {
+		key *models.APIKey
+		err error
+	}
+
+	cases := []struct {
+		name          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name: "AuthAPIKey fails when the key is not found",
+			requiredMocks: func() {
+				mock.On("APIKeyGetByDigest", ctx, digestAPIKey("secret")).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrAuthUnathorized(store.ErrNoDocuments)},
+		},
+		{
+			name: "AuthAPIKey fails when the key is expired",
+			requiredMocks: func() {
+				mock.On("APIKeyGetByDigest", ctx, digestAPIKey("secret")).Return(expired, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{nil, NewErrAuthUnathorized(NewErrAPIKeyExpired(nil))},
+		},
+		{
+			name: "AuthAPIKey succeeds",
+			requiredMocks: func() {
+				mock.On("APIKeyGetByDigest", ctx, digestAPIKey("secret")).Return(key, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("APIKeySetLastUsed", ctx, key.ID).Return(nil).Once()
+			},
+			expected: Expected{key, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			returned, err := s.AuthAPIKey(ctx, "secret")
+			assert.Equal(t, tc.expected, Expected{returned, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+type AuditService interface {
+	ListAuditLogs(ctx context.Context, tenant string, pagination paginator.Query, filter models.AuditFilter) ([]models.AuditLog, int, error)
+}
+
+// ListAuditLogs lists the audit trail of a namespace.
+//
+// It receives a context, used to "control" the request flow, the tenant ID from models.Namespace, a pagination query
+// and a models.AuditFilter to narrow the entries returned.
+//
+// ListAuditLogs returns a slice of models.AuditLog, the total of entries and an error. When error is not nil, the
+// slice of models.AuditLog is nil and total is zero.
+func (s *service) ListAuditLogs(ctx context.Context, tenant string, pagination paginator.Query, filter models.AuditFilter) ([]models.AuditLog, int, error) {
+	if _, err := s.store.NamespaceGet(ctx, tenant); err != nil {
+		return nil, 0, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	return s.store.AuditList(ctx, tenant, pagination, filter)
+}
+
+// audit records an entry in the namespace's audit trail.
+//
+// The actor and its IP address are got from the request's context. As the audited action was already performed when
+// audit is called, a failure to record the entry is logged instead of returned.
+func (s *service) audit(ctx context.Context, tenant, action string, target models.AuditTarget, before, after map[string]interface{}) {
+	entry := &models.AuditLog{
+		TenantID: tenant,
+		Actor: models.AuditActor{
+			Role: gateway.RoleFromContext(ctx),
+		},
+		Action:    action,
+		Target:    target,
+		Before:    before,
+		After:     after,
+		IPAddress: gateway.IPFromContext(ctx),
+	}
+
+	if id := gateway.IDFromContext(ctx); id != nil {
+		entry.Actor.ID = id.ID
+	} else if key := gateway.APIKeyFromContext(ctx); key != "" {
+		entry.Actor.ID = key
+	}
+
+	if username := gateway.UsernameFromContext(ctx); username != nil {
+		entry.Actor.Username = username.ID
+	}
+
+	if err := s.store.AuditCreate(ctx, entry); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"tenant": tenant,
+			"action": action,
+			"target": target.ID,
+		}).Error("Failed to record the audit log")
+	}
+}
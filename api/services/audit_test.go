@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListAuditLogs(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{Name: "group1", Owner: "hash1", TenantID: "a736a52b-5777-4f92-b0b8-e359bf484713"}
+	query := paginator.Query{Page: 1, PerPage: 10}
+	filter := models.AuditFilter{Action: models.AuditDeviceDelete}
+	audits := []models.AuditLog{
+		{
+			TenantID: namespace.TenantID,
+			Actor:    models.AuditActor{ID: "hash1", Username: "user1", Role: "owner"},
+			Action:   models.AuditDeviceDelete,
+			Target:   models.AuditTarget{Type: models.AuditTargetDevice, ID: "uid"},
+		},
+	}
+
+	Err := errors.New("error")
+
+	type Expected struct {
+		audits []models.AuditLog
+		count  int
+		err    error
+	}
+
+	cases := []struct {
+		name          string
+		tenantID      string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name:     "ListAuditLogs fails when the namespace is not found",
+			tenantID: namespace.TenantID,
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(nil, Err).Once()
+			},
+			expected: Expected{
+				audits: nil,
+				count:  0,
+				err:    NewErrNamespaceNotFound(namespace.TenantID, Err),
+			},
+		},
+		{
+			name:     "ListAuditLogs fails when the store audit list fails",
+			tenantID: namespace.TenantID,
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+				mock.On("AuditList", ctx, namespace.TenantID, query, filter).Return(nil, 0, Err).Once()
+			},
+			expected: Expected{
+				audits: nil,
+				count:  0,
+				err:    Err,
+			},
+		},
+		{
+			name:     "ListAuditLogs succeeds",
+			tenantID: namespace.TenantID,
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+				mock.On("AuditList", ctx, namespace.TenantID, query, filter).Return(audits, len(audits), nil).Once()
+			},
+			expected: Expected{
+				audits: audits,
+				count:  len(audits),
+				err:    nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			returnedAudits, count, err := s.ListAuditLogs(ctx, tc.tenantID, query, filter)
+			assert.Equal(t, tc.expected, Expected{returnedAudits, count, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
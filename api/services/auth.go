@@ -15,7 +15,9 @@ import (
 
 	"github.com/cnf/structhash"
 	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
@@ -64,18 +66,24 @@ func (s *service) AuthDevice(ctx context.Context, req request.DeviceAuth, remote
 	}
 
 	type Device struct {
-		Name      string
-		Namespace string
+		Name              string
+		Namespace         string
+		AgentUpdate       *models.AgentUpdate
+		ReverseForwarding *models.ReverseForwarding
+		AgentForwarding   bool
 	}
 
 	var value *Device
 
 	if err := s.cache.Get(ctx, strings.Join([]string{"auth_device", key}, "/"), &value); err == nil && value != nil {
 		return &models.DeviceAuthResponse{
-			UID:       key,
-			Token:     tokenStr,
-			Name:      value.Name,
-			Namespace: value.Namespace,
+			UID:               key,
+			Token:             tokenStr,
+			Name:              value.Name,
+			Namespace:         value.Namespace,
+			AgentUpdate:       value.AgentUpdate,
+			ReverseForwarding: value.ReverseForwarding,
+			AgentForwarding:   value.AgentForwarding,
 		}, nil
 	}
 	var info *models.DeviceInfo
@@ -96,6 +104,7 @@ func (s *service) AuthDevice(ctx context.Context, req request.DeviceAuth, remote
 		TenantID:   req.TenantID,
 		LastSeen:   clock.Now(),
 		RemoteAddr: remoteAddr,
+		Attributes: req.Attributes,
 	}
 
 	// The order here is critical as we don't want to register devices if the tenant id is invalid
@@ -106,6 +115,20 @@ func (s *service) AuthDevice(ctx context.Context, req request.DeviceAuth, remote
 
 	hostname := strings.ToLower(req.Hostname)
 
+	// The device is upserted, so it is checked before whether it is a new one, and whether it was already online, as
+	// only coming online is an event.
+	var created, online bool
+	if s.emitting() {
+		_, err := s.store.DeviceGetByUID(ctx, models.UID(device.UID), device.TenantID)
+		created = err == store.ErrNoDocuments
+
+		if !created {
+			if previous, err := s.store.DeviceGet(ctx, models.UID(device.UID)); err == nil {
+				online = previous.Online
+			}
+		}
+	}
+
 	if err := s.store.DeviceCreate(ctx, device, hostname); err != nil {
 		return nil, NewErrDeviceCreate(device, err)
 	}
@@ -124,15 +147,33 @@ func (s *service) AuthDevice(ctx context.Context, req request.DeviceAuth, remote
 	if err != nil {
 		return nil, NewErrDeviceNotFound(models.UID(device.UID), err)
 	}
-	if err := s.cache.Set(ctx, strings.Join([]string{"auth_device", key}, "/"), &Device{Name: dev.Name, Namespace: namespace.Name}, time.Second*30); err != nil {
+
+	if req.HostKeyFingerprint != "" {
+		if err := s.pinDeviceHostKey(ctx, dev, req.HostKeyFingerprint); err != nil {
+			return nil, NewErrDeviceNotFound(models.UID(device.UID), err)
+		}
+	}
+
+	if created {
+		s.emit(ctx, dev.TenantID, webhook.EventDeviceCreated, dev)
+	}
+
+	if !online {
+		s.emit(ctx, dev.TenantID, webhook.EventDeviceOnline, dev)
+	}
+
+	if err := s.cache.Set(ctx, strings.Join([]string{"auth_device", key}, "/"), &Device{Name: dev.Name, Namespace: namespace.Name, AgentUpdate: agentUpdate(namespace), ReverseForwarding: reverseForwarding(namespace), AgentForwarding: agentForwarding(namespace)}, time.Second*30); err != nil {
 		return nil, err
 	}
 
 	return &models.DeviceAuthResponse{
-		UID:       key,
-		Token:     tokenStr,
-		Name:      dev.Name,
-		Namespace: namespace.Name,
+		UID:               key,
+		Token:             tokenStr,
+		Name:              dev.Name,
+		Namespace:         namespace.Name,
+		AgentUpdate:       agentUpdate(namespace),
+		ReverseForwarding: reverseForwarding(namespace),
+		AgentForwarding:   agentForwarding(namespace),
 	}, nil
 }
 
@@ -151,9 +192,25 @@ func (s *service) AuthUser(ctx context.Context, req request.UserAuth) (*models.U
 
 	namespace, _ := s.store.NamespaceGetFirst(ctx, user.ID)
 
+	password := sha256.Sum256([]byte(req.Password))
+	if user.Password != hex.EncodeToString(password[:]) {
+		return nil, NewErrAuthUnathorized(nil)
+	}
+
+	if user.MFA.Enabled {
+		return s.authUserMFAPending(user)
+	}
+
+	return s.authUserToken(ctx, user, namespace)
+}
+
+// authUserToken issues the user's token to a namespace, what is usually the first namespace the user is member of.
+//
+// When the namespace requires MFA and the user has not enabled it, the token is issued without a namespace.
+func (s *service) authUserToken(ctx context.Context, user *models.User, namespace *models.Namespace) (*models.UserAuthResponse, error) {
 	var role string
 	var tenant string
-	if namespace != nil {
+	if namespace != nil && !(mfaRequired(namespace) && !user.MFA.Enabled) {
 		tenant = namespace.TenantID
 
 		for _, member := range namespace.Members {
@@ -165,47 +222,42 @@ func (s *service) AuthUser(ctx context.Context, req request.UserAuth) (*models.U
 		}
 	}
 
-	password := sha256.Sum256([]byte(req.Password))
-	if user.Password == hex.EncodeToString(password[:]) {
-		token := jwt.NewWithClaims(jwt.SigningMethodRS256, models.UserAuthClaims{
-			Username: user.Username,
-			Admin:    true,
-			Tenant:   tenant,
-			Role:     role,
-			ID:       user.ID,
-			AuthClaims: models.AuthClaims{
-				Claims: "user",
-			},
-			RegisteredClaims: jwt.RegisteredClaims{
-				ExpiresAt: jwt.NewNumericDate(clock.Now().Add(time.Hour * 72)),
-			},
-		})
-
-		tokenStr, err := token.SignedString(s.privKey)
-		if err != nil {
-			return nil, NewErrTokenSigned(err)
-		}
-
-		user.LastLogin = clock.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, models.UserAuthClaims{
+		Username: user.Username,
+		Admin:    true,
+		Tenant:   tenant,
+		Role:     role,
+		ID:       user.ID,
+		AuthClaims: models.AuthClaims{
+			Claims: "user",
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(clock.Now().Add(time.Hour * 72)),
+		},
+	})
 
-		if err := s.store.UserUpdateData(ctx, user.ID, *user); err != nil {
-			return nil, NewErrUserUpdate(user, err)
-		}
+	tokenStr, err := token.SignedString(s.privKey)
+	if err != nil {
+		return nil, NewErrTokenSigned(err)
+	}
 
-		s.AuthCacheToken(ctx, tenant, user.ID, tokenStr) // nolint: errcheck
+	user.LastLogin = clock.Now()
 
-		return &models.UserAuthResponse{
-			Token:  tokenStr,
-			Name:   user.Name,
-			ID:     user.ID,
-			User:   user.Username,
-			Tenant: tenant,
-			Role:   role,
-			Email:  user.Email,
-		}, nil
+	if err := s.store.UserUpdateData(ctx, user.ID, *user); err != nil {
+		return nil, NewErrUserUpdate(user, err)
 	}
 
-	return nil, NewErrAuthUnathorized(nil)
+	s.AuthCacheToken(ctx, tenant, user.ID, tokenStr) // nolint: errcheck
+
+	return &models.UserAuthResponse{
+		Token:  tokenStr,
+		Name:   user.Name,
+		ID:     user.ID,
+		User:   user.Username,
+		Tenant: tenant,
+		Role:   role,
+		Email:  user.Email,
+	}, nil
 }
 
 func (s *service) AuthGetToken(ctx context.Context, id string) (*models.UserAuthResponse, error) {
@@ -307,6 +359,10 @@ func (s *service) AuthSwapToken(ctx context.Context, id, tenant string) (*models
 		}
 	}
 
+	if role != "" && mfaRequired(namespace) && !user.MFA.Enabled {
+		return nil, NewErrNamespaceMFARequired(nil)
+	}
+
 	for _, member := range namespace.Members {
 		if user.ID == member.ID {
 			token := jwt.NewWithClaims(jwt.SigningMethodRS256, models.UserAuthClaims{
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+const (
+	// AuthFailureWindow is how long a failed SSH authentication is counted after the last one of the same key.
+	AuthFailureWindow = 15 * time.Minute
+	// AuthBanDuration is how long the first ban of a key lasts. It doubles for each ban of the key in the last
+	// AuthBanMaxDuration, up to it.
+	AuthBanDuration = 15 * time.Minute
+	// AuthBanMaxDuration is the longest a key is banned.
+	AuthBanMaxDuration = 24 * time.Hour
+	// AuthDelayMax is the longest, in seconds, an attempt is delayed.
+	AuthDelayMax = 8
+)
+
+// AuthLimits are the number of failed SSH authentications, in the AuthFailureWindow, that ban each kind of key. The
+// attempts start to be delayed when the failures reach half of them.
+var AuthLimits = map[string]int{
+	models.AuthLimitIP:       20,
+	models.AuthLimitDevice:   100,
+	models.AuthLimitUsername: 10,
+}
+
+// AuthLimitService contains the service's functions to protect the SSH gateway's authentications from brute-force
+// attacks, delaying and banning the source IPs, devices and usernames with too many failed attempts.
+type AuthLimitService interface {
+	EvaluateAuthAttempt(ctx context.Context, attempt request.AuthAttempt) (*models.AuthEvaluation, error)
+	RecordAuthFailure(ctx context.Context, failure request.AuthFailure) error
+	ListAuthBans(ctx context.Context, pagination paginator.Query) ([]models.AuthBan, int, error)
+	LiftAuthBan(ctx context.Context, id string) error
+}
+
+// EvaluateAuthAttempt checks if a SSH authentication attempt is blocked by a ban of any of its keys or, when it is not,
+// how long it must be delayed.
+func (s *service) EvaluateAuthAttempt(ctx context.Context, attempt request.AuthAttempt) (*models.AuthEvaluation, error) {
+	keys := authLimitKeys(attempt)
+
+	ban, err := s.store.AuthBanGetActive(ctx, keys)
+	switch {
+	case err == nil:
+		return &models.AuthEvaluation{Banned: true, ExpiresAt: ban.ExpiresAt}, nil
+	case err != store.ErrNoDocuments:
+		return nil, err
+	}
+
+	evaluation := new(models.AuthEvaluation)
+	for kind, value := range keys {
+		failures, err := s.authFailures(ctx, kind, value)
+		if err != nil {
+			return nil, err
+		}
+
+		if delay := authDelay(failures, AuthLimits[kind]); delay > evaluation.Delay {
+			evaluation.Delay = delay
+		}
+	}
+
+	return evaluation, nil
+}
+
+// RecordAuthFailure counts a failed SSH authentication to each of its keys, banning the ones reaching their limits,
+// and records it as a session of the target device, when the device is known.
+func (s *service) RecordAuthFailure(ctx context.Context, failure request.AuthFailure) error {
+	for kind, value := range authLimitKeys(failure.AuthAttempt) {
+		failures, err := s.cache.Incr(ctx, authFailuresKey(kind, value), AuthFailureWindow)
+		if err != nil {
+			return err
+		}
+
+		if failures < AuthLimits[kind] {
+			continue
+		}
+
+		if err := s.banAuthKey(ctx, kind, value, failures); err != nil {
+			return err
+		}
+	}
+
+	if failure.SessionUID != "" {
+		if err := s.store.SessionSetFailureReason(ctx, models.UID(failure.SessionUID), failure.Reason); err != nil {
+			return NewErrSessionNotFound(models.UID(failure.SessionUID), err)
+		}
+
+		return nil
+	}
+
+	// An attempt to a device that was not found has no namespace to be listed in.
+	if failure.DeviceUID == "" {
+		return nil
+	}
+
+	position, _ := s.locator.GetPosition(net.ParseIP(failure.IPAddress))
+
+	session, err := s.store.SessionCreate(ctx, models.Session{
+		UID:       uuid.Generate(),
+		DeviceUID: models.UID(failure.DeviceUID),
+		Username:  failure.Username,
+		IPAddress: failure.IPAddress,
+		Type:      "unknown",
+		Position: models.SessionPosition{
+			Longitude: position.Longitude,
+			Latitude:  position.Latitude,
+		},
+		FailureReason: failure.Reason,
+	})
+	if err != nil {
+		return NewErrDeviceNotFound(models.UID(failure.DeviceUID), err)
+	}
+
+	return s.store.SessionDeleteActives(ctx, models.UID(session.UID))
+}
+
+// ListAuthBans lists the unexpired authentication bans.
+func (s *service) ListAuthBans(ctx context.Context, pagination paginator.Query) ([]models.AuthBan, int, error) {
+	return s.store.AuthBanList(ctx, pagination)
+}
+
+// LiftAuthBan removes an authentication ban, also forgetting the failed attempts of its key.
+//
+// It returns NewErrAuthBanNotFound when the ban does not exist.
+func (s *service) LiftAuthBan(ctx context.Context, id string) error {
+	ban, err := s.store.AuthBanDelete(ctx, id)
+	if err != nil {
+		return NewErrAuthBanNotFound(id, err)
+	}
+
+	return s.cache.Delete(ctx, authFailuresKey(ban.Kind, ban.Value))
+}
+
+// banAuthKey bans a key for AuthBanDuration, doubled for each of its recent bans, restarting the count of its failures.
+func (s *service) banAuthKey(ctx context.Context, kind, value string, failures int) error {
+	bans, err := s.cache.Incr(ctx, authBansKey(kind, value), AuthBanMaxDuration)
+	if err != nil {
+		return err
+	}
+
+	// The ban being created was already counted.
+	bans--
+
+	duration := AuthBanMaxDuration
+	if bans < 7 && AuthBanDuration<<bans < AuthBanMaxDuration {
+		duration = AuthBanDuration << bans
+	}
+
+	now := clock.Now()
+	if err := s.store.AuthBanCreate(ctx, &models.AuthBan{
+		Kind:      kind,
+		Value:     value,
+		Failures:  failures,
+		CreatedAt: now,
+		ExpiresAt: now.Add(duration),
+	}); err != nil {
+		return err
+	}
+
+	return s.cache.Delete(ctx, authFailuresKey(kind, value))
+}
+
+// authFailures returns the number of recent failed attempts of a key.
+func (s *service) authFailures(ctx context.Context, kind, value string) (int, error) {
+	var failures string
+	if err := s.cache.Get(ctx, authFailuresKey(kind, value), &failures); err != nil {
+		return 0, err
+	}
+
+	if failures == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(failures)
+}
+
+// authLimitKeys returns the keys, by kind, an attempt is limited by. The loopback addresses are not limited, as they
+// are the web terminal's connections, and the target's keys are only known when it has one.
+func authLimitKeys(attempt request.AuthAttempt) map[string]string {
+	keys := make(map[string]string)
+
+	if ip := net.ParseIP(attempt.IPAddress); ip != nil && !ip.IsLoopback() {
+		keys[models.AuthLimitIP] = ip.String()
+	}
+
+	if attempt.Device != "" {
+		device := strings.ToLower(attempt.Device)
+
+		keys[models.AuthLimitDevice] = device
+		if attempt.Username != "" {
+			keys[models.AuthLimitUsername] = attempt.Username + "@" + device
+		}
+	}
+
+	return keys
+}
+
+// authDelay returns how many seconds an attempt is delayed after failures of a key limited to limit. It doubles for
+// each failure after half of the limit, up to AuthDelayMax.
+func authDelay(failures, limit int) int {
+	over := failures - limit/2
+	if over < 0 {
+		return 0
+	}
+
+	if over > 3 {
+		return AuthDelayMax
+	}
+
+	return 1 << over
+}
+
+func authFailuresKey(kind, value string) string {
+	return "auth_failures/" + kind + "/" + value
+}
+
+func authBansKey(kind, value string) string {
+	return "auth_bans/" + kind + "/" + value
+}
@@ -0,0 +1,301 @@
+package services
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/geoip"
+	mocksGeoIp "github.com/shellhub-io/shellhub/pkg/geoip/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEvaluateAuthAttempt(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	attempt := request.AuthAttempt{IPAddress: "10.0.0.1", Device: "Namespace.Device", Username: "root"}
+	keys := map[string]string{
+		models.AuthLimitIP:       "10.0.0.1",
+		models.AuthLimitDevice:   "namespace.device",
+		models.AuthLimitUsername: "root@namespace.device",
+	}
+
+	expires := time.Now().Add(time.Hour)
+
+	type Expected struct {
+		evaluation *models.AuthEvaluation
+		err        error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the store function to get the active ban fails",
+			requiredMocks: func() {
+				storeMock.On("AuthBanGetActive", ctx, keys).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, Err},
+		},
+		{
+			description: "succeeds to evaluate a banned attempt",
+			requiredMocks: func() {
+				storeMock.On("AuthBanGetActive", ctx, keys).Return(&models.AuthBan{ExpiresAt: expires}, nil).Once()
+			},
+			expected: Expected{&models.AuthEvaluation{Banned: true, ExpiresAt: expires}, nil},
+		},
+		{
+			description: "succeeds to evaluate an attempt without recent failures",
+			requiredMocks: func() {
+				storeMock.On("AuthBanGetActive", ctx, keys).Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{&models.AuthEvaluation{}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			evaluation, err := s.EvaluateAuthAttempt(ctx, attempt)
+			assert.Equal(t, tc.expected, Expected{evaluation, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestRecordAuthFailure(t *testing.T) {
+	storeMock := &mocks.Store{}
+	locator := &mocksGeoIp.Locator{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, locator)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	attempt := request.AuthAttempt{IPAddress: "10.0.0.1", Device: "namespace.device", Username: "root"}
+
+	cases := []struct {
+		description   string
+		failure       request.AuthFailure
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "succeeds without a session when the device was not found",
+			failure:     request.AuthFailure{AuthAttempt: attempt, Reason: models.AuthFailureDeviceNotFound},
+			requiredMocks: func() {
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the session is not found",
+			failure:     request.AuthFailure{AuthAttempt: attempt, SessionUID: "session", Reason: models.AuthFailureDeviceRejected},
+			requiredMocks: func() {
+				storeMock.On("SessionSetFailureReason", ctx, models.UID("session"), models.AuthFailureDeviceRejected).Return(Err).Once()
+			},
+			expected: NewErrSessionNotFound("session", Err),
+		},
+		{
+			description: "succeeds to set the failure reason of the session",
+			failure:     request.AuthFailure{AuthAttempt: attempt, SessionUID: "session", Reason: models.AuthFailureDeviceRejected},
+			requiredMocks: func() {
+				storeMock.On("SessionSetFailureReason", ctx, models.UID("session"), models.AuthFailureDeviceRejected).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the session of the device cannot be created",
+			failure:     request.AuthFailure{AuthAttempt: attempt, DeviceUID: "device", Reason: models.AuthFailurePublicKeyRejected},
+			requiredMocks: func() {
+				locator.On("GetPosition", net.ParseIP("10.0.0.1")).Return(geoip.Position{}, nil).Once()
+				storeMock.On("SessionCreate", ctx, mock.AnythingOfType("models.Session")).Return(nil, Err).Once()
+			},
+			expected: NewErrDeviceNotFound("device", Err),
+		},
+		{
+			description: "succeeds to record a closed session of the device",
+			failure:     request.AuthFailure{AuthAttempt: attempt, DeviceUID: "device", Reason: models.AuthFailurePublicKeyRejected},
+			requiredMocks: func() {
+				locator.On("GetPosition", net.ParseIP("10.0.0.1")).Return(geoip.Position{}, nil).Once()
+				storeMock.On("SessionCreate", ctx, mock.MatchedBy(func(session models.Session) bool {
+					return session.DeviceUID == "device" &&
+						session.Username == "root" &&
+						session.FailureReason == models.AuthFailurePublicKeyRejected
+				})).Return(&models.Session{UID: "session"}, nil).Once()
+				storeMock.On("SessionDeleteActives", ctx, models.UID("session")).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.RecordAuthFailure(ctx, tc.failure)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	locator.AssertExpectations(t)
+}
+
+func TestRecordAuthFailureBan(t *testing.T) {
+	storeMock := &mocks.Store{}
+	cacheMock := &mocks.Cache{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, cacheMock, clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	now := time.Now()
+
+	failure := request.AuthFailure{AuthAttempt: request.AuthAttempt{IPAddress: "10.0.0.1"}, Reason: models.AuthFailurePublicKeyRejected}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the failure cannot be counted",
+			requiredMocks: func() {
+				cacheMock.On("Incr", ctx, "auth_failures/ip/10.0.0.1", AuthFailureWindow).Return(0, Err).Once()
+			},
+			expected: Err,
+		},
+		{
+			description: "succeeds to count the failure under the limit",
+			requiredMocks: func() {
+				cacheMock.On("Incr", ctx, "auth_failures/ip/10.0.0.1", AuthFailureWindow).Return(AuthLimits[models.AuthLimitIP]-1, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds to ban the key reaching the limit, for longer on each ban",
+			requiredMocks: func() {
+				cacheMock.On("Incr", ctx, "auth_failures/ip/10.0.0.1", AuthFailureWindow).Return(AuthLimits[models.AuthLimitIP], nil).Once()
+				cacheMock.On("Incr", ctx, "auth_bans/ip/10.0.0.1", AuthBanMaxDuration).Return(2, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("AuthBanCreate", ctx, mock.MatchedBy(func(ban *models.AuthBan) bool {
+					return ban.Kind == models.AuthLimitIP &&
+						ban.Value == "10.0.0.1" &&
+						ban.Failures == AuthLimits[models.AuthLimitIP] &&
+						ban.ExpiresAt.Equal(now.Add(2*AuthBanDuration))
+				})).Return(nil).Once()
+				cacheMock.On("Delete", ctx, "auth_failures/ip/10.0.0.1").Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.RecordAuthFailure(ctx, failure)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	cacheMock.AssertExpectations(t)
+}
+
+func TestLiftAuthBan(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		id            string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the ban is not found",
+			id:          "invalid",
+			requiredMocks: func() {
+				storeMock.On("AuthBanDelete", ctx, "invalid").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrAuthBanNotFound("invalid", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds to lift the ban",
+			id:          "id",
+			requiredMocks: func() {
+				storeMock.On("AuthBanDelete", ctx, "id").Return(&models.AuthBan{Kind: models.AuthLimitIP, Value: "10.0.0.1"}, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.LiftAuthBan(ctx, tc.id)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAuthLimitKeys(t *testing.T) {
+	cases := []struct {
+		description string
+		attempt     request.AuthAttempt
+		expected    map[string]string
+	}{
+		{
+			description: "does not limit the loopback addresses",
+			attempt:     request.AuthAttempt{IPAddress: "127.0.0.1", Device: "namespace.device", Username: "root"},
+			expected: map[string]string{
+				models.AuthLimitDevice:   "namespace.device",
+				models.AuthLimitUsername: "root@namespace.device",
+			},
+		},
+		{
+			description: "does not limit the target without a device",
+			attempt:     request.AuthAttempt{IPAddress: "10.0.0.1", Username: "root"},
+			expected: map[string]string{
+				models.AuthLimitIP: "10.0.0.1",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, authLimitKeys(tc.attempt))
+		})
+	}
+}
+
+func TestAuthDelay(t *testing.T) {
+	assert.Equal(t, 0, authDelay(4, 10))
+	assert.Equal(t, 1, authDelay(5, 10))
+	assert.Equal(t, 4, authDelay(7, 10))
+	assert.Equal(t, AuthDelayMax, authDelay(9, 10))
+}
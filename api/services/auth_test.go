@@ -58,7 +58,10 @@ func TestAuthDevice(t *testing.T) {
 	}
 
 	clockMock.On("Now").Return(now).Twice()
-	namespace := &models.Namespace{Name: "group1", Owner: "hash1", TenantID: "tenant"}
+	namespace := &models.Namespace{Name: "group1", Owner: "hash1", TenantID: "tenant", Settings: &models.NamespaceSettings{
+		ReverseForwarding: &models.ReverseForwarding{Enabled: true, Rules: []models.ReverseForwardingRule{{Action: models.ReverseForwardingAllow, FromPort: 8080, ToPort: 8080}}},
+		AgentForwarding:   true,
+	}}
 
 	mock.On("DeviceCreate", ctx, *device, "").
 		Return(nil).Once()
@@ -82,6 +85,8 @@ func TestAuthDevice(t *testing.T) {
 	assert.Equal(t, device.UID, authRes.UID)
 	assert.Equal(t, device.Name, authRes.Name)
 	assert.Equal(t, namespace.Name, authRes.Namespace)
+	assert.Equal(t, namespace.Settings.ReverseForwarding, authRes.ReverseForwarding)
+	assert.True(t, authRes.AgentForwarding)
 	assert.NotEmpty(t, authRes.Token)
 	assert.Equal(t, device.RemoteAddr, "0.0.0.0")
 
@@ -10,6 +10,7 @@ import (
 	"github.com/shellhub-io/shellhub/api/store"
 	req "github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/validator"
@@ -92,7 +93,14 @@ func (s *service) DeleteDevice(ctx context.Context, uid models.UID, tenant strin
 		return err
 	}
 
-	return s.store.DeviceDelete(ctx, uid)
+	if err := s.store.DeviceDelete(ctx, uid); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditDeviceDelete, models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID}, map[string]interface{}{"name": device.Name, "status": device.Status}, nil)
+	s.emit(ctx, tenant, webhook.EventDeviceRemoved, device)
+
+	return nil
 }
 
 func (s *service) RenameDevice(ctx context.Context, uid models.UID, name, tenant string) error {
@@ -152,13 +160,37 @@ func (s *service) LookupDevice(ctx context.Context, namespace, name string) (*mo
 	return device, nil
 }
 
+// UpdateDeviceStatus sets whether a device is online. Only a change of the device's status is emitted as an event.
 func (s *service) UpdateDeviceStatus(ctx context.Context, uid models.UID, online bool) error {
+	// The device's status is checked before it is set, so its status is known to change.
+	changed := false
+	if s.emitting() {
+		if device, err := s.store.DeviceGet(ctx, uid); err == nil {
+			changed = device.Online != online
+		}
+	}
+
 	err := s.store.DeviceSetOnline(ctx, uid, online)
 	if err == store.ErrNoDocuments {
 		return NewErrDeviceNotFound(uid, err)
 	}
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		if device, err := s.store.DeviceGet(ctx, uid); err == nil {
+			event := webhook.EventDeviceOffline
+			if online {
+				event = webhook.EventDeviceOnline
+			}
+
+			s.emit(ctx, device.TenantID, event, device)
+		}
+	}
+
+	return nil
 }
 
 func (s *service) UpdatePendingStatus(ctx context.Context, uid models.UID, status, tenant string) error {
@@ -187,7 +219,7 @@ func (s *service) UpdatePendingStatus(ctx context.Context, uid models.UID, statu
 	}
 
 	if status != StatusAccepted {
-		return s.store.DeviceUpdateStatus(ctx, uid, status)
+		return s.updateDeviceStatus(ctx, device, status)
 	}
 
 	// NOTICE: The logic below is only executed when the new status is "accepted".
@@ -245,7 +277,27 @@ func (s *service) UpdatePendingStatus(ctx context.Context, uid models.UID, statu
 		}
 	}
 
-	return s.store.DeviceUpdateStatus(ctx, uid, status)
+	return s.updateDeviceStatus(ctx, device, status)
+}
+
+// updateDeviceStatus sets the device's status and records the change in the namespace's audit trail.
+func (s *service) updateDeviceStatus(ctx context.Context, device *models.Device, status string) error {
+	if err := s.store.DeviceUpdateStatus(ctx, models.UID(device.UID), status); err != nil {
+		return err
+	}
+
+	s.audit(ctx, device.TenantID, models.AuditDeviceUpdateStatus, models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID}, map[string]interface{}{"status": device.Status}, map[string]interface{}{"status": status})
+
+	switch status {
+	case StatusAccepted:
+		device.Status = status
+		s.emit(ctx, device.TenantID, webhook.EventDeviceAccepted, device)
+	case "rejected":
+		device.Status = status
+		s.emit(ctx, device.TenantID, webhook.EventDeviceRejected, device)
+	}
+
+	return nil
 }
 
 // SetDevicePosition sets the position to a device from its IP.
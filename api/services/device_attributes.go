@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DeviceAttributes contains the service's function to manage device attributes.
+type DeviceAttributes interface {
+	UpdateDeviceAttributes(ctx context.Context, tenant string, uid models.UID, attributes map[string]string) error
+	GetDeviceAttributeKeys(ctx context.Context, tenant string) ([]string, int, error)
+}
+
+// UpdateDeviceAttributes replaces the attributes of a device. UID is the device's UID and attributes are the new ones.
+//
+// If the device does not exist in the namespace, a NewErrDeviceNotFound error will be returned.
+func (s *service) UpdateDeviceAttributes(ctx context.Context, tenant string, uid models.UID, attributes map[string]string) error {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	if err := s.store.DeviceUpdateAttributes(ctx, uid, attributes); err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditDeviceUpdateAttributes, models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID}, map[string]interface{}{"attributes": device.Attributes}, map[string]interface{}{"attributes": attributes})
+
+	return nil
+}
+
+// GetDeviceAttributeKeys returns the attribute keys used by the devices of a namespace.
+//
+// If the namespace does not exist, a NewErrNamespaceNotFound error will be returned.
+func (s *service) GetDeviceAttributeKeys(ctx context.Context, tenant string) ([]string, int, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil || namespace == nil {
+		return nil, 0, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	return s.store.DeviceAttributeKeys(ctx, namespace.TenantID)
+}
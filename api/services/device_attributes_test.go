@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUpdateDeviceAttributes(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	device := &models.Device{UID: "uid", TenantID: "tenant", Attributes: map[string]string{"site": "porto"}}
+	attributes := map[string]string{"site": "lisbon", "rack": "r12"}
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found in the namespace",
+			uid:         "invalid",
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("invalid"), "tenant").
+					Return(nil, Err).Once()
+			},
+			expected: NewErrDeviceNotFound("invalid", Err),
+		},
+		{
+			description: "fails when the store function to update the attributes fails",
+			uid:         models.UID(device.UID),
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID(device.UID), "tenant").
+					Return(device, nil).Once()
+				storeMock.On("DeviceUpdateAttributes", ctx, models.UID(device.UID), attributes).
+					Return(Err).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID(device.UID), Err),
+		},
+		{
+			description: "succeeds to update the attributes",
+			uid:         models.UID(device.UID),
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID(device.UID), "tenant").
+					Return(device, nil).Once()
+				storeMock.On("DeviceUpdateAttributes", ctx, models.UID(device.UID), attributes).
+					Return(nil).Once()
+				storeMock.On("AuditCreate", ctx, mock.MatchedBy(func(entry *models.AuditLog) bool {
+					return entry.Action == models.AuditDeviceUpdateAttributes &&
+						assert.ObjectsAreEqual(device.Attributes, entry.Before["attributes"]) &&
+						assert.ObjectsAreEqual(attributes, entry.After["attributes"])
+				})).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.UpdateDeviceAttributes(ctx, "tenant", tc.uid, attributes)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestGetDeviceAttributeKeys(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	namespace := &models.Namespace{Name: "namespace", TenantID: "tenant"}
+
+	type Expected struct {
+		keys  []string
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace is not found",
+			tenant:      "invalid",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "invalid").Return(nil, Err).Once()
+			},
+			expected: Expected{nil, 0, NewErrNamespaceNotFound("invalid", Err)},
+		},
+		{
+			description: "succeeds to get the attribute keys",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("DeviceAttributeKeys", ctx, "tenant").Return([]string{"rack", "site"}, 2, nil).Once()
+			},
+			expected: Expected{[]string{"rack", "site"}, 2, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			keys, count, err := s.GetDeviceAttributeKeys(ctx, tc.tenant)
+			assert.Equal(t, tc.expected, Expected{keys, count, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
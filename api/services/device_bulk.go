@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DeviceBulkMax is the maximum number of devices a bulk operation can select.
+const DeviceBulkMax = 1000
+
+// DeviceBulkService contains the service's function to apply an action to several devices at once.
+type DeviceBulkService interface {
+	BulkDevices(ctx context.Context, tenant string, uids []models.UID, filter []models.Filter, action, tag string, publicURL bool) ([]models.DeviceBulkResult, error)
+}
+
+// BulkDevices applies an action to the namespace's devices selected either by uids or, when uids is empty, by filter.
+//
+// The action is applied to each device through the same service function used by the single device endpoints, so the
+// namespace's limits are evaluated device by device. A failure does not stop the operation, it is reported in the
+// device's result instead.
+//
+// It returns NewErrDeviceBulkActionInvalid when the action is unknown and NewErrDeviceBulkLimit when more than
+// DeviceBulkMax devices are selected.
+func (s *service) BulkDevices(ctx context.Context, tenant string, uids []models.UID, filter []models.Filter, action, tag string, publicURL bool) ([]models.DeviceBulkResult, error) {
+	validateAction := map[string]bool{
+		models.DeviceBulkAccept:    true,
+		models.DeviceBulkReject:    true,
+		models.DeviceBulkRemove:    true,
+		models.DeviceBulkAddTag:    true,
+		models.DeviceBulkRemoveTag: true,
+		models.DeviceBulkPublicURL: true,
+	}
+
+	if _, ok := validateAction[action]; !ok {
+		return nil, NewErrDeviceBulkActionInvalid(action, nil)
+	}
+
+	if len(uids) == 0 {
+		devices, count, err := s.store.DeviceList(ctx, paginator.Query{Page: 1, PerPage: DeviceBulkMax}, filter, "", "", "", false)
+		if err != nil {
+			return nil, err
+		}
+
+		if count > DeviceBulkMax {
+			return nil, NewErrDeviceBulkLimit(DeviceBulkMax, nil)
+		}
+
+		for _, device := range devices {
+			if device.TenantID == tenant {
+				uids = append(uids, models.UID(device.UID))
+			}
+		}
+	}
+
+	if len(uids) > DeviceBulkMax {
+		return nil, NewErrDeviceBulkLimit(DeviceBulkMax, nil)
+	}
+
+	results := make([]models.DeviceBulkResult, 0, len(uids))
+	for _, uid := range uids {
+		result := models.DeviceBulkResult{UID: string(uid), Status: models.DeviceBulkSucceeded}
+
+		if err := s.bulkDevice(ctx, tenant, uid, action, tag, publicURL); err != nil {
+			result.Status = models.DeviceBulkFailed
+			result.Error = bulkError(err)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// bulkDevice applies a bulk operation's action to a single device of the namespace.
+func (s *service) bulkDevice(ctx context.Context, tenant string, uid models.UID, action, tag string, publicURL bool) error {
+	// The tag's service functions do not check the device's namespace.
+	if _, err := s.store.DeviceGetByUID(ctx, uid, tenant); err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	switch action {
+	case models.DeviceBulkAccept:
+		return s.UpdatePendingStatus(ctx, uid, StatusAccepted, tenant)
+	case models.DeviceBulkReject:
+		return s.UpdatePendingStatus(ctx, uid, "rejected", tenant)
+	case models.DeviceBulkRemove:
+		return s.DeleteDevice(ctx, uid, tenant)
+	case models.DeviceBulkAddTag:
+		return s.CreateDeviceTag(ctx, uid, tag)
+	case models.DeviceBulkRemoveTag:
+		return s.RemoveDeviceTag(ctx, uid, tag)
+	case models.DeviceBulkPublicURL:
+		return s.UpdateDevice(ctx, tenant, uid, nil, &publicURL)
+	default:
+		return NewErrDeviceBulkActionInvalid(action, nil)
+	}
+}
+
+// bulkError returns the message reported for a device which action has failed. Only the outermost message of the
+// service's errors is reported, keeping the store's details out of the report.
+func bulkError(err error) string {
+	if e, ok := err.(errors.Error); ok {
+		return e.Message
+	}
+
+	return err.Error()
+}
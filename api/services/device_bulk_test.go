@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestBulkDevices(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	namespace := &models.Namespace{Name: "namespace", TenantID: "tenant"}
+	filter := []models.Filter{{Type: "property", Params: &models.PropertyParams{Name: "status", Operator: "eq", Value: "pending"}}}
+	query := paginator.Query{Page: 1, PerPage: DeviceBulkMax}
+
+	type Expected struct {
+		results []models.DeviceBulkResult
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		uids          []models.UID
+		filter        []models.Filter
+		action        string
+		tag           string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the action is invalid",
+			uids:          []models.UID{"uid"},
+			action:        "rename",
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrDeviceBulkActionInvalid("rename", nil)},
+		},
+		{
+			description: "fails when the filter selects too many devices",
+			filter:      filter,
+			action:      models.DeviceBulkAccept,
+			requiredMocks: func() {
+				storeMock.On("DeviceList", ctx, query, filter, "", "", "", false).
+					Return([]models.Device{}, DeviceBulkMax+1, nil).Once()
+			},
+			expected: Expected{nil, NewErrDeviceBulkLimit(DeviceBulkMax, nil)},
+		},
+		{
+			description: "reports the result of each device selected by uid",
+			uids:        []models.UID{"uid1", "uid2", "uid3"},
+			action:      models.DeviceBulkAddTag,
+			tag:         "tag",
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid1"), "tenant").
+					Return(&models.Device{UID: "uid1", TenantID: "tenant"}, nil).Once()
+				storeMock.On("DeviceGet", ctx, models.UID("uid1")).
+					Return(&models.Device{UID: "uid1", TenantID: "tenant"}, nil).Once()
+				storeMock.On("DeviceCreateTag", ctx, models.UID("uid1"), "tag").
+					Return(nil).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid2"), "tenant").
+					Return(nil, Err).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid3"), "tenant").
+					Return(&models.Device{UID: "uid3", TenantID: "tenant", Tags: []string{"tag"}}, nil).Once()
+				storeMock.On("DeviceGet", ctx, models.UID("uid3")).
+					Return(&models.Device{UID: "uid3", TenantID: "tenant", Tags: []string{"tag"}}, nil).Once()
+			},
+			expected: Expected{
+				[]models.DeviceBulkResult{
+					{UID: "uid1", Status: models.DeviceBulkSucceeded},
+					{UID: "uid2", Status: models.DeviceBulkFailed, Error: ErrDeviceNotFound.Error()},
+					{UID: "uid3", Status: models.DeviceBulkFailed, Error: ErrDuplicateTagName.Error()},
+				},
+				nil,
+			},
+		},
+		{
+			description: "applies the action to the namespace's devices selected by the filter",
+			filter:      filter,
+			action:      models.DeviceBulkReject,
+			requiredMocks: func() {
+				devices := []models.Device{
+					{UID: "uid1", TenantID: "tenant", Status: "pending"},
+					{UID: "uid2", TenantID: "other", Status: "pending"},
+				}
+
+				storeMock.On("DeviceList", ctx, query, filter, "", "", "", false).
+					Return(devices, 2, nil).Once()
+				storeMock.On("DeviceGetByUID", ctx, models.UID("uid1"), "tenant").
+					Return(&devices[0], nil).Twice()
+				storeMock.On("NamespaceGet", ctx, "tenant").
+					Return(namespace, nil).Once()
+				storeMock.On("DeviceUpdateStatus", ctx, models.UID("uid1"), "rejected").
+					Return(nil).Once()
+				storeMock.On("AuditCreate", ctx, mock.Anything).
+					Return(nil).Once()
+			},
+			expected: Expected{
+				[]models.DeviceBulkResult{
+					{UID: "uid1", Status: models.DeviceBulkSucceeded},
+				},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			results, err := s.BulkDevices(ctx, "tenant", tc.uids, tc.filter, tc.action, tc.tag, false)
+			assert.Equal(t, tc.expected, Expected{results, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
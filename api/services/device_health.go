@@ -0,0 +1,52 @@
+package services
+
+import (
+	"context"
+	"math"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DeviceHealthService contains the service's functions to record the health reported by the devices' agents.
+type DeviceHealthService interface {
+	ReportDeviceHealth(ctx context.Context, uid models.UID, health *models.DeviceHealth) error
+}
+
+// ReportDeviceHealth records a health snapshot reported by a device's agent as the device's latest one. The usage
+// percentages are computed from the reported sizes, instead of trusted from the agent.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned.
+func (s *service) ReportDeviceHealth(ctx context.Context, uid models.UID, health *models.DeviceHealth) error {
+	health.Memory.Usage = 0
+	if health.Memory.Total > 0 && health.Memory.Available <= health.Memory.Total {
+		health.Memory.Usage = percentage(health.Memory.Total-health.Memory.Available, health.Memory.Total)
+	}
+
+	health.DiskUsage = 0
+	for i := range health.Disks {
+		disk := &health.Disks[i]
+
+		disk.Usage = 0
+		if disk.Total > 0 && disk.Used <= disk.Total {
+			disk.Usage = percentage(disk.Used, disk.Total)
+		}
+
+		if disk.Usage > health.DiskUsage {
+			health.DiskUsage = disk.Usage
+		}
+	}
+
+	health.ReportedAt = clock.Now()
+
+	if err := s.store.DeviceSetHealth(ctx, uid, health); err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	return nil
+}
+
+// percentage returns part as a percentage of total, rounded to two decimal places.
+func percentage(part, total uint64) float64 {
+	return math.Round(float64(part)/float64(total)*10000) / 100
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportDeviceHealth(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	report := func() *models.DeviceHealth {
+		return &models.DeviceHealth{
+			Uptime: 3600,
+			Memory: models.DeviceMemory{Total: 1000, Available: 250, Usage: 1},
+			Disks: []models.DeviceDisk{
+				{Mount: "/", Total: 1000, Used: 950},
+				{Mount: "/data", Total: 3000, Used: 1000, Usage: 100},
+			},
+			DiskUsage: 1,
+		}
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("DeviceSetHealth", ctx, models.UID("uid"), &models.DeviceHealth{
+					Uptime: 3600,
+					Memory: models.DeviceMemory{Total: 1000, Available: 250, Usage: 75},
+					Disks: []models.DeviceDisk{
+						{Mount: "/", Total: 1000, Used: 950, Usage: 95},
+						{Mount: "/data", Total: 3000, Used: 1000, Usage: 33.33},
+					},
+					DiskUsage:  95,
+					ReportedAt: now,
+				}).Return(Err).Once()
+			},
+			expected: NewErrDeviceNotFound("uid", Err),
+		},
+		{
+			description: "succeeds to record the health with its usages computed",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("DeviceSetHealth", ctx, models.UID("uid"), &models.DeviceHealth{
+					Uptime: 3600,
+					Memory: models.DeviceMemory{Total: 1000, Available: 250, Usage: 75},
+					Disks: []models.DeviceDisk{
+						{Mount: "/", Total: 1000, Used: 950, Usage: 95},
+						{Mount: "/data", Total: 3000, Used: 1000, Usage: 33.33},
+					},
+					DiskUsage:  95,
+					ReportedAt: now,
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.ReportDeviceHealth(ctx, "uid", report())
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+)
+
+// DeviceHostKey contains the service's functions to manage the SSH host keys pinned to the devices.
+type DeviceHostKey interface {
+	ResetDeviceHostKey(ctx context.Context, tenant string, uid models.UID) error
+}
+
+// ResetDeviceHostKey unpins the SSH host key of a device, after it was legitimately re-provisioned, so the next one
+// reported by its agent is pinned instead.
+//
+// If the device does not exist in the namespace, a NewErrDeviceNotFound error will be returned.
+func (s *service) ResetDeviceHostKey(ctx context.Context, tenant string, uid models.UID) error {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	if err := s.store.DeviceSetHostKeyFingerprint(ctx, uid, ""); err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditDeviceResetHostKey, models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID}, map[string]interface{}{"host_key_fingerprint": device.HostKeyFingerprint}, nil)
+
+	return nil
+}
+
+// pinDeviceHostKey pins the SSH host key reported by the agent of a device without one, trusting it on its first use. A
+// pinned key is kept until it is reset, so a different one is only logged, as the gateway refuses its connections.
+func (s *service) pinDeviceHostKey(ctx context.Context, device *models.Device, fingerprint string) error {
+	switch device.HostKeyFingerprint {
+	case fingerprint:
+		return nil
+	case "":
+		return s.store.DeviceSetHostKeyFingerprint(ctx, models.UID(device.UID), fingerprint)
+	default:
+		logrus.WithFields(logrus.Fields{
+			"device":   device.UID,
+			"pinned":   device.HostKeyFingerprint,
+			"reported": fingerprint,
+		}).Warn("device reported a host key different from the pinned one")
+
+		return nil
+	}
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestResetDeviceHostKey(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	device := &models.Device{UID: "uid", TenantID: "tenant", HostKeyFingerprint: "SHA256:fingerprint"}
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found in the namespace",
+			uid:         "invalid",
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID("invalid"), "tenant").
+					Return(nil, Err).Once()
+			},
+			expected: NewErrDeviceNotFound("invalid", Err),
+		},
+		{
+			description: "fails when the store function to unpin the host key fails",
+			uid:         models.UID(device.UID),
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID(device.UID), "tenant").
+					Return(device, nil).Once()
+				storeMock.On("DeviceSetHostKeyFingerprint", ctx, models.UID(device.UID), "").
+					Return(Err).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID(device.UID), Err),
+		},
+		{
+			description: "succeeds to reset the host key",
+			uid:         models.UID(device.UID),
+			requiredMocks: func() {
+				storeMock.On("DeviceGetByUID", ctx, models.UID(device.UID), "tenant").
+					Return(device, nil).Once()
+				storeMock.On("DeviceSetHostKeyFingerprint", ctx, models.UID(device.UID), "").
+					Return(nil).Once()
+				storeMock.On("AuditCreate", ctx, mock.MatchedBy(func(entry *models.AuditLog) bool {
+					return entry.Action == models.AuditDeviceResetHostKey &&
+						entry.Before["host_key_fingerprint"] == device.HostKeyFingerprint
+				})).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.ResetDeviceHostKey(ctx, "tenant", tc.uid)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestPinDeviceHostKey(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		device        *models.Device
+		requiredMocks func()
+	}{
+		{
+			description: "pins the first host key reported by the device",
+			device:      &models.Device{UID: "uid"},
+			requiredMocks: func() {
+				storeMock.On("DeviceSetHostKeyFingerprint", ctx, models.UID("uid"), "SHA256:reported").
+					Return(nil).Once()
+			},
+		},
+		{
+			description: "keeps the host key pinned to the device",
+			device:      &models.Device{UID: "uid", HostKeyFingerprint: "SHA256:pinned"},
+			requiredMocks: func() {
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			assert.NoError(t, s.pinDeviceHostKey(ctx, tc.device, "SHA256:reported"))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DeviceInventory contains the service's function to list the devices of an inventory.
+type DeviceInventory interface {
+	ListInventoryDevices(ctx context.Context, tenant string) ([]models.Device, error)
+}
+
+// ListInventoryDevices returns every accepted device of a namespace, sorted by name, to be rendered as an inventory.
+//
+// If the namespace does not exist, a NewErrNamespaceNotFound error will be returned.
+func (s *service) ListInventoryDevices(ctx context.Context, tenant string) ([]models.Device, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenant)
+	if err != nil || namespace == nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	filter := []models.Filter{
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: namespace.TenantID},
+		},
+	}
+
+	devices, _, err := s.store.DeviceList(ctx, paginator.Query{Page: -1, PerPage: -1}, filter, StatusAccepted, "name", "asc", false)
+	if err != nil {
+		return nil, err
+	}
+
+	return devices, nil
+}
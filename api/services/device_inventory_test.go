@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListInventoryDevices(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	namespace := &models.Namespace{Name: "namespace", TenantID: "tenant"}
+	filter := []models.Filter{{Type: "property", Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: "tenant"}}}
+	query := paginator.Query{Page: -1, PerPage: -1}
+	devices := []models.Device{
+		{UID: "uid1", Name: "router", TenantID: "tenant", Status: "accepted"},
+		{UID: "uid2", Name: "sensor", TenantID: "tenant", Status: "accepted"},
+	}
+
+	type Expected struct {
+		devices []models.Device
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace is not found",
+			tenant:      "invalid",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "invalid").Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotFound("invalid", Err)},
+		},
+		{
+			description: "fails when the store function to list the devices fails",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("DeviceList", ctx, query, filter, "accepted", "name", "asc", false).
+					Return(nil, 0, Err).Once()
+			},
+			expected: Expected{nil, Err},
+		},
+		{
+			description: "succeeds to list the namespace's accepted devices",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("DeviceList", ctx, query, filter, "accepted", "name", "asc", false).
+					Return(devices, len(devices), nil).Once()
+			},
+			expected: Expected{devices, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			devices, err := s.ListInventoryDevices(ctx, tc.tenant)
+			assert.Equal(t, tc.expected, Expected{devices, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -7,7 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hibiken/asynq"
 	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	servicemocks "github.com/shellhub-io/shellhub/api/services/mocks"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mocks"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
@@ -19,6 +21,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/validator"
 	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
 )
 
 func TestListDevices(t *testing.T) {
@@ -309,6 +312,12 @@ func TestDeleteDevice(t *testing.T) {
 				envMock.On("Get", "SHELLHUB_CLOUD").Return("false").Once()
 				mock.On("DeviceDelete", ctx, models.UID(device.UID)).
 					Return(nil).Once()
+				mock.On("AuditCreate", ctx, &models.AuditLog{
+					TenantID: namespace.TenantID,
+					Action:   models.AuditDeviceDelete,
+					Target:   models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID},
+					Before:   map[string]interface{}{"name": device.Name, "status": device.Status},
+				}).Return(nil).Once()
 			},
 			id:       user.ID,
 			expected: nil,
@@ -367,6 +376,12 @@ func TestDeleteDevice(t *testing.T) {
 				}).Return(200, nil).Once()
 				mock.On("DeviceDelete", ctx, models.UID(device.UID)).
 					Return(nil).Once()
+				mock.On("AuditCreate", ctx, &models.AuditLog{
+					TenantID: namespace.TenantID,
+					Action:   models.AuditDeviceDelete,
+					Target:   models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID},
+					Before:   map[string]interface{}{"name": device.Name, "status": device.Status},
+				}).Return(nil).Once()
 			},
 			id:       user.ID,
 			expected: nil,
@@ -629,6 +644,61 @@ func TestUpdateDeviceStatus(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestUpdateDeviceStatusEvent(t *testing.T) {
+	mock := &mocks.Store{}
+	tasks := &servicemocks.Enqueuer{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil, WithEnqueuer(tasks))
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		name          string
+		online        bool
+		requiredMocks func()
+	}{
+		{
+			name:   "UpdateDeviceStatus emits the event when the device comes online",
+			online: true,
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				mock.On("DeviceSetOnline", ctx, models.UID("uid"), true).Return(nil).Once()
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: "tenant", Online: true}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				tasks.On("EnqueueContext", ctx, testifymock.AnythingOfType("*asynq.Task"), asynq.Queue(WebhookQueue)).Return(&asynq.TaskInfo{}, nil).Once()
+			},
+		},
+		{
+			name:   "UpdateDeviceStatus does not emit the event when the device was already online",
+			online: true,
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: "tenant", Online: true}, nil).Once()
+				mock.On("DeviceSetOnline", ctx, models.UID("uid"), true).Return(nil).Once()
+			},
+		},
+		{
+			name:   "UpdateDeviceStatus emits the event when the device goes offline",
+			online: false,
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: "tenant", Online: true}, nil).Once()
+				mock.On("DeviceSetOnline", ctx, models.UID("uid"), false).Return(nil).Once()
+				mock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				tasks.On("EnqueueContext", ctx, testifymock.AnythingOfType("*asynq.Task"), asynq.Queue(WebhookQueue)).Return(&asynq.TaskInfo{}, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			assert.NoError(t, s.UpdateDeviceStatus(ctx, "uid", tc.online))
+		})
+	}
+
+	mock.AssertExpectations(t)
+	tasks.AssertExpectations(t)
+}
+
 func TestUpdatePendingStatus(t *testing.T) {
 	mock := &mocks.Store{}
 	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
@@ -830,6 +900,13 @@ func TestUpdatePendingStatus(t *testing.T) {
 					Return(nil).Once()
 				mock.On("DeviceUpdateStatus", ctx, models.UID(device.UID), "accepted").
 					Return(nil).Once()
+				mock.On("AuditCreate", ctx, &models.AuditLog{
+					TenantID: device.TenantID,
+					Action:   models.AuditDeviceUpdateStatus,
+					Target:   models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID},
+					Before:   map[string]interface{}{"status": device.Status},
+					After:    map[string]interface{}{"status": "accepted"},
+				}).Return(nil).Once()
 			},
 			expected: nil,
 		},
@@ -861,6 +938,13 @@ func TestUpdatePendingStatus(t *testing.T) {
 				}).Return(200, nil).Once()
 				mock.On("DeviceUpdateStatus", ctx, models.UID(device.UID), "accepted").
 					Return(nil).Once()
+				mock.On("AuditCreate", ctx, &models.AuditLog{
+					TenantID: device.TenantID,
+					Action:   models.AuditDeviceUpdateStatus,
+					Target:   models.AuditTarget{Type: models.AuditTargetDevice, ID: device.UID},
+					Before:   map[string]interface{}{"status": device.Status},
+					After:    map[string]interface{}{"status": "accepted"},
+				}).Return(nil).Once()
 			},
 			expected: nil,
 		},
@@ -106,6 +106,8 @@ var (
 	ErrTokenSigned               = errors.New("token signed", ErrLayer, ErrCodeInvalid)
 	ErrTypeAssertion             = errors.New("type assertion failed", ErrLayer, ErrCodeInvalid)
 	ErrSessionNotFound           = errors.New("session not found", ErrLayer, ErrCodeNotFound)
+	ErrSessionRecordNotFound     = errors.New("session record not found", ErrLayer, ErrCodeNotFound)
+	ErrSessionNotActive          = errors.New("session not active", ErrLayer, ErrCodeInvalid)
 	ErrAuthInvalid               = errors.New("auth invalid", ErrLayer, ErrCodeInvalid)
 	ErrAuthUnathorized           = errors.New("auth unauthorized", ErrLayer, ErrCodeUnauthorized)
 	ErrNamespaceLimitReached     = errors.New("namespace limit reached", ErrLayer, ErrCodeLimit)
@@ -114,6 +116,28 @@ var (
 	ErrDeviceRemovedFull         = errors.New("device removed full", ErrLayer, ErrCodePayment)
 	ErrDeviceRemovedDelete       = errors.New("device removed delete", ErrLayer, ErrCodeStore)
 	ErrDeviceRemovedGet          = errors.New("device removed get", ErrLayer, ErrCodeNotFound)
+	ErrUserMFAEnabled            = errors.New("user mfa already enabled", ErrLayer, ErrCodeInvalid)
+	ErrUserMFADisabled           = errors.New("user mfa not enabled", ErrLayer, ErrCodeInvalid)
+	ErrUserMFACodeInvalid        = errors.New("user mfa code invalid", ErrLayer, ErrCodeUnauthorized)
+	ErrUserMFALocked             = errors.New("user mfa locked", ErrLayer, ErrCodeLimit)
+	ErrNamespaceMFARequired      = errors.New("namespace requires mfa", ErrLayer, ErrCodeForbidden)
+	ErrAPIKeyNotFound            = errors.New("api key not found", ErrLayer, ErrCodeNotFound)
+	ErrAPIKeyDuplicated          = errors.New("api key duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrAPIKeyInvalid             = errors.New("api key invalid", ErrLayer, ErrCodeInvalid)
+	ErrAPIKeyExpired             = errors.New("api key expired", ErrLayer, ErrCodeUnauthorized)
+	ErrWebhookNotFound           = errors.New("webhook not found", ErrLayer, ErrCodeNotFound)
+	ErrWebhookInvalid            = errors.New("webhook invalid", ErrLayer, ErrCodeInvalid)
+	ErrWebhookDeliveryNotFound   = errors.New("webhook delivery not found", ErrLayer, ErrCodeNotFound)
+	ErrWebhookAddressForbidden   = errors.New("webhook address forbidden", ErrLayer, ErrCodeInvalid)
+	ErrDeviceBulkLimit           = errors.New("device bulk limit reached", ErrLayer, ErrCodeLimit)
+	ErrDeviceBulkActionInvalid   = errors.New("device bulk action invalid", ErrLayer, ErrCodeInvalid)
+	ErrUserCANotFound            = errors.New("user certificate authority not found", ErrLayer, ErrCodeNotFound)
+	ErrUserCADuplicated          = errors.New("user certificate authority duplicated", ErrLayer, ErrCodeDuplicated)
+	ErrUserCAInvalid             = errors.New("user certificate authority invalid", ErrLayer, ErrCodeInvalid)
+	ErrUserCertificateInvalid    = errors.New("user certificate invalid", ErrLayer, ErrCodeForbidden)
+	ErrAuthBanNotFound           = errors.New("authentication ban not found", ErrLayer, ErrCodeNotFound)
+	ErrAccessRequestNotFound     = errors.New("access request not found", ErrLayer, ErrCodeNotFound)
+	ErrAccessRequestNotPending   = errors.New("access request not pending", ErrLayer, ErrCodeInvalid)
 )
 
 // NewErrNotFound returns an error with the ErrDataNotFound and wrap an error.
@@ -234,6 +258,27 @@ func NewErrPublicKeyTagsEmpty(next error) error {
 	return NewErrNotFound(ErrPublicKeyNoTags, "", next)
 }
 
+// NewErrUserCANotFound returns an error to be used when the user certificate authority is not found.
+func NewErrUserCANotFound(fingerprint string, next error) error {
+	return NewErrNotFound(ErrUserCANotFound, fingerprint, next)
+}
+
+// NewErrUserCADuplicated returns an error to be used when the user certificate authority is already trusted by the
+// namespace.
+func NewErrUserCADuplicated(fingerprint string, next error) error {
+	return NewErrDuplicated(ErrUserCADuplicated, []string{fingerprint}, next)
+}
+
+// NewErrUserCAInvalid returns an error to be used when the user certificate authority's data is not a public key.
+func NewErrUserCAInvalid(next error) error {
+	return NewErrInvalid(ErrUserCAInvalid, nil, next)
+}
+
+// NewErrUserCertificateInvalid returns an error to be used when a user certificate does not allow the login.
+func NewErrUserCertificateInvalid(next error) error {
+	return NewErrForbidden(ErrUserCertificateInvalid, next)
+}
+
 // NewErrPublicKeyDataInvalid returns an error when the public key data is invalid.
 func NewErrPublicKeyDataInvalid(value []byte, next error) error {
 	// FIXME: literal assignment.
@@ -265,6 +310,16 @@ func NewErrSessionNotFound(id models.UID, next error) error {
 	return NewErrNotFound(ErrSessionNotFound, string(id), next)
 }
 
+// NewErrSessionRecordNotFound returns an error when the session has no recorded frames.
+func NewErrSessionRecordNotFound(id models.UID, next error) error {
+	return NewErrNotFound(ErrSessionRecordNotFound, string(id), next)
+}
+
+// NewErrSessionNotActive returns an error when the session has already finished.
+func NewErrSessionNotActive(id models.UID, next error) error {
+	return NewErrInvalid(ErrSessionNotActive, map[string]interface{}{"uid": id}, next)
+}
+
 // NewErrNamespaceList return an error to be used when cannot list namespaces.
 func NewErrNamespaceList(next error) error {
 	return NewErrInvalid(ErrNamespaceList, nil, next)
@@ -397,3 +452,89 @@ func NewErrDeviceRemovedDelete(next error) error {
 func NewErrDeviceRemovedGet(next error) error {
 	return NewErrInvalid(ErrDeviceRemovedGet, nil, next)
 }
+
+// NewErrAPIKeyNotFound returns an error to be used when the API key is not found.
+func NewErrAPIKeyNotFound(id string, next error) error {
+	return NewErrNotFound(ErrAPIKeyNotFound, id, next)
+}
+
+// NewErrAPIKeyDuplicated returns an error to be used when the API key's name already exist in the namespace.
+func NewErrAPIKeyDuplicated(name string, next error) error {
+	return NewErrDuplicated(ErrAPIKeyDuplicated, []string{name}, next)
+}
+
+// NewErrAPIKeyInvalid returns an error to be used when the API key data is invalid.
+func NewErrAPIKeyInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrAPIKeyInvalid, data, next)
+}
+
+// NewErrAPIKeyExpired returns an error to be used when the API key has passed its expiration time.
+func NewErrAPIKeyExpired(next error) error {
+	return NewErrUnathorized(ErrAPIKeyExpired, next)
+}
+
+// NewErrWebhookNotFound returns an error to be used when the webhook is not found.
+func NewErrWebhookNotFound(id string, next error) error {
+	return NewErrNotFound(ErrWebhookNotFound, id, next)
+}
+
+// NewErrWebhookInvalid returns an error to be used when the webhook data is invalid.
+func NewErrWebhookInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrWebhookInvalid, data, next)
+}
+
+// NewErrWebhookDeliveryNotFound returns an error to be used when the webhook's delivery is not found.
+func NewErrWebhookDeliveryNotFound(id string, next error) error {
+	return NewErrNotFound(ErrWebhookDeliveryNotFound, id, next)
+}
+
+// NewErrUserMFAEnabled returns an error to be used when the user's MFA is already enabled.
+func NewErrUserMFAEnabled(next error) error {
+	return NewErrInvalid(ErrUserMFAEnabled, nil, next)
+}
+
+// NewErrUserMFADisabled returns an error to be used when the user's MFA is not enabled or its secret was not generated.
+func NewErrUserMFADisabled(next error) error {
+	return NewErrInvalid(ErrUserMFADisabled, nil, next)
+}
+
+// NewErrUserMFACodeInvalid returns an error to be used when a TOTP or recovery code doesn't match the user's ones.
+func NewErrUserMFACodeInvalid(next error) error {
+	return NewErrUnathorized(ErrUserMFACodeInvalid, next)
+}
+
+// NewErrUserMFALocked returns an error to be used when the user's MFA is locked by too many failed codes.
+func NewErrUserMFALocked(limit int, next error) error {
+	return NewErrLimit(ErrUserMFALocked, limit, next)
+}
+
+// NewErrNamespaceMFARequired returns an error to be used when a user without MFA tries to access a namespace that
+// requires it.
+func NewErrNamespaceMFARequired(next error) error {
+	return NewErrForbidden(ErrNamespaceMFARequired, next)
+}
+
+// NewErrDeviceBulkLimit returns an error to be used when a bulk operation selects more devices than allowed.
+func NewErrDeviceBulkLimit(limit int, next error) error {
+	return NewErrLimit(ErrDeviceBulkLimit, limit, next)
+}
+
+// NewErrDeviceBulkActionInvalid returns an error to be used when a bulk operation's action is invalid.
+func NewErrDeviceBulkActionInvalid(action string, next error) error {
+	return NewErrInvalid(ErrDeviceBulkActionInvalid, map[string]interface{}{"action": action}, next)
+}
+
+// NewErrAuthBanNotFound returns an error to be used when the authentication ban is not found.
+func NewErrAuthBanNotFound(id string, next error) error {
+	return NewErrNotFound(ErrAuthBanNotFound, id, next)
+}
+
+// NewErrAccessRequestNotFound returns an error to be used when the access request is not found.
+func NewErrAccessRequestNotFound(id string, next error) error {
+	return NewErrNotFound(ErrAccessRequestNotFound, id, next)
+}
+
+// NewErrAccessRequestNotPending returns an error to be used when the access request was already decided or expired.
+func NewErrAccessRequestNotPending(id string, next error) error {
+	return NewErrInvalid(ErrAccessRequestNotPending, map[string]interface{}{"id": id}, next)
+}
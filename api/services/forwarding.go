@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// ReverseForwardingService contains the service's functions to enforce the namespace's remote port forwarding policy.
+type ReverseForwardingService interface {
+	EvaluateReverseForwarding(ctx context.Context, tenantID string, port uint32) (bool, error)
+}
+
+// EvaluateReverseForwarding checks if the namespace's policy allows binding port on its devices through a remote port
+// forwarding.
+//
+// If the namespace does not exist, a NewErrNamespaceNotFound error will be returned.
+func (s *service) EvaluateReverseForwarding(ctx context.Context, tenantID string, port uint32) (bool, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenantID)
+	if err != nil || namespace == nil {
+		return false, NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	return reverseForwarding(namespace).Allows(port), nil
+}
+
+// reverseForwarding returns the remote port forwarding policy of a namespace, nil when it was never set.
+func reverseForwarding(namespace *models.Namespace) *models.ReverseForwarding {
+	if namespace.Settings == nil {
+		return nil
+	}
+
+	return namespace.Settings.ReverseForwarding
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateReverseForwarding(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	namespace := func(forwarding *models.ReverseForwarding) *models.Namespace {
+		return &models.Namespace{
+			Name:     "namespace",
+			TenantID: "tenant",
+			Settings: &models.NamespaceSettings{ReverseForwarding: forwarding},
+		}
+	}
+
+	rules := []models.ReverseForwardingRule{
+		{Action: models.ReverseForwardingDeny, FromPort: 8080, ToPort: 8080},
+		{Action: models.ReverseForwardingAllow, FromPort: 8000, ToPort: 8999},
+	}
+
+	type Expected struct {
+		allowed bool
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		port          uint32
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace is not found",
+			tenant:      "invalid",
+			port:        8000,
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "invalid").Return(nil, Err).Once()
+			},
+			expected: Expected{false, NewErrNamespaceNotFound("invalid", Err)},
+		},
+		{
+			description: "denies when the policy was never set",
+			tenant:      "tenant",
+			port:        8000,
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace(nil), nil).Once()
+			},
+			expected: Expected{false, nil},
+		},
+		{
+			description: "denies when the forwarding is disabled",
+			tenant:      "tenant",
+			port:        8000,
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").
+					Return(namespace(&models.ReverseForwarding{Enabled: false, Rules: rules}), nil).Once()
+			},
+			expected: Expected{false, nil},
+		},
+		{
+			description: "denies when the first matching rule denies the port",
+			tenant:      "tenant",
+			port:        8080,
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").
+					Return(namespace(&models.ReverseForwarding{Enabled: true, Rules: rules}), nil).Once()
+			},
+			expected: Expected{false, nil},
+		},
+		{
+			description: "denies when no rule matches the port",
+			tenant:      "tenant",
+			port:        22,
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").
+					Return(namespace(&models.ReverseForwarding{Enabled: true, Rules: rules}), nil).Once()
+			},
+			expected: Expected{false, nil},
+		},
+		{
+			description: "denies the port chosen by the device",
+			tenant:      "tenant",
+			port:        0,
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").
+					Return(namespace(&models.ReverseForwarding{Enabled: true, Rules: rules}), nil).Once()
+			},
+			expected: Expected{false, nil},
+		},
+		{
+			description: "allows when the first matching rule allows the port",
+			tenant:      "tenant",
+			port:        8081,
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").
+					Return(namespace(&models.ReverseForwarding{Enabled: true, Rules: rules}), nil).Once()
+			},
+			expected: Expected{true, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			allowed, err := s.EvaluateReverseForwarding(ctx, tc.tenant, tc.port)
+			assert.Equal(t, tc.expected, Expected{allowed, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
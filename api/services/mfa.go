@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/api/response"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/totp"
+)
+
+const (
+	// mfaIssuer is the issuer shown by authenticator apps.
+	mfaIssuer = "ShellHub"
+	// mfaRecoveryCodes is the number of recovery codes generated with the MFA secret.
+	mfaRecoveryCodes = 8
+	// mfaRecoveryCodeSize is the number of random bytes of a recovery code.
+	mfaRecoveryCodeSize = 5
+	// mfaPendingTimeout is how long the "mfa pending" token is valid.
+	mfaPendingTimeout = 5 * time.Minute
+	// mfaMaxFailures is the number of consecutive failed codes what locks the user's MFA.
+	mfaMaxFailures = 5
+	// mfaLockTimeout is how long the user's MFA stays locked after the last failed code.
+	mfaLockTimeout = 15 * time.Minute
+)
+
+type MFAService interface {
+	GenerateMFA(ctx context.Context, id string) (*response.UserMFAGenerate, error)
+	EnableMFA(ctx context.Context, id, code string) error
+	DisableMFA(ctx context.Context, id, code string) error
+	AuthMFA(ctx context.Context, req request.UserMFAAuth) (*models.UserAuthResponse, error)
+}
+
+// GenerateMFA generates a new TOTP secret and recovery codes to a user.
+//
+// The secret is only required on login after the user verifies its first code through EnableMFA, so generating it
+// again, before enabling, replaces the previous one.
+//
+// GenerateMFA returns the secret, its otpauth URI, to be shown as a QR code, and the recovery codes in plain text, what
+// are never returned again.
+func (s *service) GenerateMFA(ctx context.Context, id string) (*response.UserMFAGenerate, error) {
+	user, _, err := s.store.UserGetByID(ctx, id, false)
+	if err != nil {
+		return nil, NewErrUserNotFound(id, err)
+	}
+
+	if user.MFA.Enabled {
+		return nil, NewErrUserMFAEnabled(nil)
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, mfaRecoveryCodes)
+	digests := make([]string, mfaRecoveryCodes)
+	for i := range codes {
+		code := make([]byte, mfaRecoveryCodeSize)
+		if _, err := rand.Read(code); err != nil {
+			return nil, err
+		}
+
+		codes[i] = hex.EncodeToString(code)
+		digests[i] = digestRecoveryCode(codes[i])
+	}
+
+	if err := s.store.UserUpdateMFA(ctx, user.ID, models.UserMFA{Enabled: false, Secret: secret, RecoveryCodes: digests}); err != nil {
+		return nil, err
+	}
+
+	return &response.UserMFAGenerate{
+		Secret:        secret,
+		URI:           totp.URI(mfaIssuer, user.Username, secret),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// EnableMFA enables the user's MFA when the code matches the secret generated by GenerateMFA.
+func (s *service) EnableMFA(ctx context.Context, id, code string) error {
+	user, _, err := s.store.UserGetByID(ctx, id, false)
+	if err != nil {
+		return NewErrUserNotFound(id, err)
+	}
+
+	if user.MFA.Enabled {
+		return NewErrUserMFAEnabled(nil)
+	}
+
+	if user.MFA.Secret == "" {
+		return NewErrUserMFADisabled(nil)
+	}
+
+	if !totp.Validate(code, user.MFA.Secret, clock.Now()) {
+		return NewErrUserMFACodeInvalid(nil)
+	}
+
+	user.MFA.Enabled = true
+
+	return s.store.UserUpdateMFA(ctx, user.ID, user.MFA)
+}
+
+// DisableMFA disables the user's MFA, removing its secret and recovery codes. The code can be a TOTP code or one of
+// the recovery codes.
+func (s *service) DisableMFA(ctx context.Context, id, code string) error {
+	user, _, err := s.store.UserGetByID(ctx, id, false)
+	if err != nil {
+		return NewErrUserNotFound(id, err)
+	}
+
+	if !user.MFA.Enabled {
+		return NewErrUserMFADisabled(nil)
+	}
+
+	if _, err := s.checkMFA(ctx, user, code); err != nil {
+		return err
+	}
+
+	return s.store.UserUpdateMFA(ctx, user.ID, models.UserMFA{})
+}
+
+// AuthMFA is the second step of the login of a user with MFA enabled. It exchanges the "mfa pending" token, returned
+// by AuthUser, and a TOTP or recovery code by the user's token. A recovery code can be used only once.
+//
+// After mfaMaxFailures consecutive failed codes, the user's MFA is locked, rejecting any code, until mfaLockTimeout
+// passes since the last one.
+func (s *service) AuthMFA(ctx context.Context, req request.UserMFAAuth) (*models.UserAuthResponse, error) {
+	claims := new(models.UserMFAClaims)
+	if _, err := jwt.ParseWithClaims(req.Token, claims, func(*jwt.Token) (interface{}, error) {
+		return s.pubKey, nil
+	}); err != nil || claims.Claims != "mfa" {
+		return nil, NewErrAuthUnathorized(err)
+	}
+
+	user, _, err := s.store.UserGetByID(ctx, claims.ID, false)
+	if err != nil {
+		return nil, NewErrUserNotFound(claims.ID, err)
+	}
+
+	if !user.MFA.Enabled {
+		return nil, NewErrUserMFADisabled(nil)
+	}
+
+	recovery, err := s.checkMFA(ctx, user, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if recovery >= 0 || user.MFA.Failures > 0 {
+		if recovery >= 0 {
+			user.MFA.RecoveryCodes = append(user.MFA.RecoveryCodes[:recovery], user.MFA.RecoveryCodes[recovery+1:]...)
+		}
+
+		user.MFA.Failures = 0
+		user.MFA.FailedAt = time.Time{}
+
+		if err := s.store.UserUpdateMFA(ctx, user.ID, user.MFA); err != nil {
+			return nil, err
+		}
+	}
+
+	namespace, _ := s.store.NamespaceGetFirst(ctx, user.ID)
+
+	return s.authUserToken(ctx, user, namespace)
+}
+
+// authUserMFAPending issues the short-lived token returned by the first step of the login of a user with MFA enabled.
+func (s *service) authUserMFAPending(user *models.User) (*models.UserAuthResponse, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, models.UserMFAClaims{
+		ID: user.ID,
+		AuthClaims: models.AuthClaims{
+			Claims: "mfa",
+		},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(clock.Now().Add(mfaPendingTimeout)),
+		},
+	})
+
+	tokenStr, err := token.SignedString(s.privKey)
+	if err != nil {
+		return nil, NewErrTokenSigned(err)
+	}
+
+	return &models.UserAuthResponse{
+		Token: tokenStr,
+		ID:    user.ID,
+		User:  user.Username,
+		MFA:   true,
+	}, nil
+}
+
+// checkMFA validates a code of the user, like validateMFA, counting it when it fails. It fails without validating the
+// code when the user's MFA is locked, what happens when the failures reach mfaMaxFailures.
+func (s *service) checkMFA(ctx context.Context, user *models.User, code string) (int, error) {
+	if mfaLocked(&user.MFA) {
+		return -1, NewErrUserMFALocked(mfaMaxFailures, nil)
+	}
+
+	recovery, ok := validateMFA(user, code)
+	if ok {
+		return recovery, nil
+	}
+
+	mfa, err := s.store.UserRecordMFAFailure(ctx, user.ID, clock.Now())
+	if err != nil {
+		return -1, err
+	}
+
+	if mfaLocked(mfa) {
+		return -1, NewErrUserMFALocked(mfaMaxFailures, nil)
+	}
+
+	return -1, NewErrUserMFACodeInvalid(nil)
+}
+
+// mfaLocked checks if the MFA has reached mfaMaxFailures failed codes, the last one less than mfaLockTimeout ago.
+func mfaLocked(mfa *models.UserMFA) bool {
+	return mfa.Failures >= mfaMaxFailures && clock.Now().Before(mfa.FailedAt.Add(mfaLockTimeout))
+}
+
+// validateMFA checks if a code is the user's current TOTP code or one of its recovery codes. When it is a recovery
+// code, its index in the user's recovery codes is returned; otherwise, the index is -1.
+func validateMFA(user *models.User, code string) (int, bool) {
+	if totp.Validate(code, user.MFA.Secret, clock.Now()) {
+		return -1, true
+	}
+
+	digest := digestRecoveryCode(code)
+	for i, recovery := range user.MFA.RecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(recovery), []byte(digest)) == 1 {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
+
+// digestRecoveryCode returns the hexadecimal SHA256 digest of a recovery code.
+func digestRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// mfaRequired checks if a namespace requires its members to have MFA enabled.
+func mfaRequired(namespace *models.Namespace) bool {
+	return namespace.Settings != nil && namespace.Settings.MFARequired
+}
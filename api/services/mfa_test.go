@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/totp"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestEnableMFA(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+
+	code, err := totp.Generate(secret, now)
+	assert.NoError(t, err)
+
+	Err := errors.New("error")
+
+	cases := []struct {
+		name          string
+		code          string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "EnableMFA fails when the user is not found",
+			code: code,
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "id", false).Return(nil, 0, Err).Once()
+			},
+			expected: NewErrUserNotFound("id", Err),
+		},
+		{
+			name: "EnableMFA fails when MFA is already enabled",
+			code: code,
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id", MFA: models.UserMFA{Enabled: true, Secret: secret}}, 0, nil).Once()
+			},
+			expected: NewErrUserMFAEnabled(nil),
+		},
+		{
+			name: "EnableMFA fails when the secret was not generated",
+			code: code,
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id"}, 0, nil).Once()
+			},
+			expected: NewErrUserMFADisabled(nil),
+		},
+		{
+			name: "EnableMFA fails when the code is invalid",
+			code: "000000",
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id", MFA: models.UserMFA{Secret: secret}}, 0, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: NewErrUserMFACodeInvalid(nil),
+		},
+		{
+			name: "EnableMFA succeeds",
+			code: code,
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "id", false).Return(&models.User{ID: "id", MFA: models.UserMFA{Secret: secret}}, 0, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("UserUpdateMFA", ctx, "id", models.UserMFA{Enabled: true, Secret: secret}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			err := s.EnableMFA(ctx, "id", tc.code)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestAuthMFA(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	secret, err := totp.GenerateSecret()
+	assert.NoError(t, err)
+
+	recovery := sha256.Sum256([]byte("recovery"))
+
+	user := &models.User{
+		ID:        "id",
+		Confirmed: true,
+		UserData:  models.UserData{Username: "user"},
+		MFA:       models.UserMFA{Enabled: true, Secret: secret, RecoveryCodes: []string{hex.EncodeToString(recovery[:])}},
+	}
+
+	clockMock.On("Now").Return(now).Once()
+	pending, err := s.authUserMFAPending(user)
+	assert.NoError(t, err)
+	assert.True(t, pending.MFA)
+
+	_, errToken := jwt.ParseWithClaims("invalid", new(models.UserMFAClaims), func(*jwt.Token) (interface{}, error) {
+		return publicKey, nil
+	})
+
+	cases := []struct {
+		name          string
+		req           request.UserMFAAuth
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "AuthMFA fails when the token is invalid",
+			req:  request.UserMFAAuth{Token: "invalid", Code: "recovery"},
+			requiredMocks: func() {
+			},
+			expected: NewErrAuthUnathorized(errToken),
+		},
+		{
+			name: "AuthMFA fails when the code is invalid",
+			req:  request.UserMFAAuth{Token: pending.Token, Code: "invalid"},
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "id", false).Return(user, 0, nil).Once()
+				clockMock.On("Now").Return(now).Twice()
+				mock.On("UserRecordMFAFailure", ctx, "id", now).Return(&models.UserMFA{Failures: 1, FailedAt: now}, nil).Once()
+			},
+			expected: NewErrUserMFACodeInvalid(nil),
+		},
+		{
+			name: "AuthMFA fails when the code is invalid and it locks the MFA",
+			req:  request.UserMFAAuth{Token: pending.Token, Code: "invalid"},
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "id", false).Return(user, 0, nil).Once()
+				clockMock.On("Now").Return(now).Times(3)
+				mock.On("UserRecordMFAFailure", ctx, "id", now).Return(&models.UserMFA{Failures: mfaMaxFailures, FailedAt: now}, nil).Once()
+			},
+			expected: NewErrUserMFALocked(mfaMaxFailures, nil),
+		},
+		{
+			name: "AuthMFA fails when the MFA is locked, even with a valid code",
+			req:  request.UserMFAAuth{Token: pending.Token, Code: "recovery"},
+			requiredMocks: func() {
+				locked := *user
+				locked.MFA.Failures = mfaMaxFailures
+				locked.MFA.FailedAt = now
+
+				mock.On("UserGetByID", ctx, "id", false).Return(&locked, 0, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: NewErrUserMFALocked(mfaMaxFailures, nil),
+		},
+		{
+			name: "AuthMFA succeeds with a recovery code, what is consumed",
+			req:  request.UserMFAAuth{Token: pending.Token, Code: "recovery"},
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "id", false).Return(user, 0, nil).Once()
+				clockMock.On("Now").Return(now).Times(3)
+				mock.On("UserUpdateMFA", ctx, "id", models.UserMFA{Enabled: true, Secret: secret, RecoveryCodes: []string{}}).Return(nil).Once()
+				mock.On("NamespaceGetFirst", ctx, "id").Return(nil, store.ErrNoDocuments).Once()
+				mock.On("UserUpdateData", ctx, "id", testifymock.AnythingOfType("models.User")).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			res, err := s.AuthMFA(ctx, tc.req)
+			assert.Equal(t, tc.expected, err)
+			if err == nil {
+				assert.False(t, res.MFA)
+				assert.NotEmpty(t, res.Token)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,64 @@
+// Code generated by mockery v2.20.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	asynq "github.com/hibiken/asynq"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Enqueuer is an autogenerated mock type for the Enqueuer type
+type Enqueuer struct {
+	mock.Mock
+}
+
+// EnqueueContext provides a mock function with given fields: ctx, task, opts
+func (_m *Enqueuer) EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, task)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *asynq.TaskInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *asynq.Task, ...asynq.Option) (*asynq.TaskInfo, error)); ok {
+		return rf(ctx, task, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *asynq.Task, ...asynq.Option) *asynq.TaskInfo); ok {
+		r0 = rf(ctx, task, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*asynq.TaskInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *asynq.Task, ...asynq.Option) error); ok {
+		r1 = rf(ctx, task, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewEnqueuer interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewEnqueuer creates a new instance of Enqueuer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewEnqueuer(t mockConstructorTestingTNewEnqueuer) *Enqueuer {
+	mock := &Enqueuer{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -15,6 +15,10 @@ import (
 	response "github.com/shellhub-io/shellhub/pkg/api/response"
 
 	rsa "crypto/rsa"
+
+	time "time"
+
+	webhook "github.com/shellhub-io/shellhub/pkg/api/webhook"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -62,6 +66,46 @@ func (_m *Service) AddPublicKeyTag(ctx context.Context, tenant string, fingerpri
 	return r0
 }
 
+// ApproveAccessRequest provides a mock function with given fields: ctx, tenant, id, username, duration
+func (_m *Service) ApproveAccessRequest(ctx context.Context, tenant string, id string, username string, duration time.Duration) error {
+	ret := _m.Called(ctx, tenant, id, username, duration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, time.Duration) error); ok {
+		r0 = rf(ctx, tenant, id, username, duration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthAPIKey provides a mock function with given fields: ctx, key
+func (_m *Service) AuthAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.APIKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.APIKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AuthCacheToken provides a mock function with given fields: ctx, tenant, id, token
 func (_m *Service) AuthCacheToken(ctx context.Context, tenant string, id string, token string) error {
 	ret := _m.Called(ctx, tenant, id, token)
@@ -76,6 +120,32 @@ func (_m *Service) AuthCacheToken(ctx context.Context, tenant string, id string,
 	return r0
 }
 
+// AuthCertificate provides a mock function with given fields: ctx, uid, data, username, address
+func (_m *Service) AuthCertificate(ctx context.Context, uid models.UID, data []byte, username string, address string) (*models.CertificateEvaluation, error) {
+	ret := _m.Called(ctx, uid, data, username, address)
+
+	var r0 *models.CertificateEvaluation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, []byte, string, string) (*models.CertificateEvaluation, error)); ok {
+		return rf(ctx, uid, data, username, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, []byte, string, string) *models.CertificateEvaluation); ok {
+		r0 = rf(ctx, uid, data, username, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CertificateEvaluation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, []byte, string, string) error); ok {
+		r1 = rf(ctx, uid, data, username, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AuthDevice provides a mock function with given fields: ctx, req, remoteAddr
 func (_m *Service) AuthDevice(ctx context.Context, req request.DeviceAuth, remoteAddr string) (*models.DeviceAuthResponse, error) {
 	ret := _m.Called(ctx, req, remoteAddr)
@@ -152,6 +222,32 @@ func (_m *Service) AuthIsCacheToken(ctx context.Context, tenant string, id strin
 	return r0, r1
 }
 
+// AuthMFA provides a mock function with given fields: ctx, req
+func (_m *Service) AuthMFA(ctx context.Context, req request.UserMFAAuth) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *models.UserAuthResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.UserMFAAuth) (*models.UserAuthResponse, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.UserMFAAuth) *models.UserAuthResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAuthResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.UserMFAAuth) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AuthPublicKey provides a mock function with given fields: ctx, req
 func (_m *Service) AuthPublicKey(ctx context.Context, req request.PublicKeyAuth) (*models.PublicKeyAuthResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -270,6 +366,84 @@ func (_m *Service) AuthUserInfo(ctx context.Context, username string, tenant str
 	return r0, r1
 }
 
+// BulkDevices provides a mock function with given fields: ctx, tenant, uids, filter, action, tag, publicURL
+func (_m *Service) BulkDevices(ctx context.Context, tenant string, uids []models.UID, filter []models.Filter, action string, tag string, publicURL bool) ([]models.DeviceBulkResult, error) {
+	ret := _m.Called(ctx, tenant, uids, filter, action, tag, publicURL)
+
+	var r0 []models.DeviceBulkResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []models.UID, []models.Filter, string, string, bool) ([]models.DeviceBulkResult, error)); ok {
+		return rf(ctx, tenant, uids, filter, action, tag, publicURL)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []models.UID, []models.Filter, string, string, bool) []models.DeviceBulkResult); ok {
+		r0 = rf(ctx, tenant, uids, filter, action, tag, publicURL)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceBulkResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []models.UID, []models.Filter, string, string, bool) error); ok {
+		r1 = rf(ctx, tenant, uids, filter, action, tag, publicURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAPIKey provides a mock function with given fields: ctx, tenant, creator, role, req
+func (_m *Service) CreateAPIKey(ctx context.Context, tenant string, creator string, role string, req *request.APIKeyCreate) (*response.APIKeyCreate, error) {
+	ret := _m.Called(ctx, tenant, creator, role, req)
+
+	var r0 *response.APIKeyCreate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *request.APIKeyCreate) (*response.APIKeyCreate, error)); ok {
+		return rf(ctx, tenant, creator, role, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, *request.APIKeyCreate) *response.APIKeyCreate); ok {
+		r0 = rf(ctx, tenant, creator, role, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.APIKeyCreate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, *request.APIKeyCreate) error); ok {
+		r1 = rf(ctx, tenant, creator, role, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAccessRequest provides a mock function with given fields: ctx, req
+func (_m *Service) CreateAccessRequest(ctx context.Context, req request.AccessRequestCreate) (*models.AccessRequest, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.AccessRequestCreate) (*models.AccessRequest, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.AccessRequestCreate) *models.AccessRequest); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.AccessRequestCreate) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateDeviceTag provides a mock function with given fields: ctx, uid, tag
 func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, tag string) error {
 	ret := _m.Called(ctx, uid, tag)
@@ -388,6 +562,58 @@ func (_m *Service) CreateSession(ctx context.Context, session request.SessionCre
 	return r0, r1
 }
 
+// CreateUserCA provides a mock function with given fields: ctx, tenant, name, data
+func (_m *Service) CreateUserCA(ctx context.Context, tenant string, name string, data []byte) (*models.UserCA, error) {
+	ret := _m.Called(ctx, tenant, name, data)
+
+	var r0 *models.UserCA
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) (*models.UserCA, error)); ok {
+		return rf(ctx, tenant, name, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) *models.UserCA); ok {
+		r0 = rf(ctx, tenant, name, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserCA)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []byte) error); ok {
+		r1 = rf(ctx, tenant, name, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWebhook provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateWebhook(ctx context.Context, tenant string, req *request.WebhookCreate) (*response.WebhookCreate, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	var r0 *response.WebhookCreate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *request.WebhookCreate) (*response.WebhookCreate, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *request.WebhookCreate) *response.WebhookCreate); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.WebhookCreate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *request.WebhookCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeactivateSession provides a mock function with given fields: ctx, uid
 func (_m *Service) DeactivateSession(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -402,6 +628,20 @@ func (_m *Service) DeactivateSession(ctx context.Context, uid models.UID) error
 	return r0
 }
 
+// DeleteAPIKey provides a mock function with given fields: ctx, tenant, role, id
+func (_m *Service) DeleteAPIKey(ctx context.Context, tenant string, role string, id string) error {
+	ret := _m.Called(ctx, tenant, role, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, tenant, role, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteDevice provides a mock function with given fields: ctx, uid, tenant
 func (_m *Service) DeleteDevice(ctx context.Context, uid models.UID, tenant string) error {
 	ret := _m.Called(ctx, uid, tenant)
@@ -444,6 +684,20 @@ func (_m *Service) DeletePublicKey(ctx context.Context, fingerprint string, tena
 	return r0
 }
 
+// DeleteSessionRecord provides a mock function with given fields: ctx, uid
+func (_m *Service) DeleteSessionRecord(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteTag provides a mock function with given fields: ctx, tenant, tag
 func (_m *Service) DeleteTag(ctx context.Context, tenant string, tag string) error {
 	ret := _m.Called(ctx, tenant, tag)
@@ -458,6 +712,62 @@ func (_m *Service) DeleteTag(ctx context.Context, tenant string, tag string) err
 	return r0
 }
 
+// DeleteUserCA provides a mock function with given fields: ctx, tenant, fingerprint
+func (_m *Service) DeleteUserCA(ctx context.Context, tenant string, fingerprint string) error {
+	ret := _m.Called(ctx, tenant, fingerprint)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, fingerprint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteWebhook provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteWebhook(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeliverWebhook provides a mock function with given fields: ctx, id, last
+func (_m *Service) DeliverWebhook(ctx context.Context, id string, last bool) error {
+	ret := _m.Called(ctx, id, last)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, id, last)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DenyAccessRequest provides a mock function with given fields: ctx, tenant, id, username
+func (_m *Service) DenyAccessRequest(ctx context.Context, tenant string, id string, username string) error {
+	ret := _m.Called(ctx, tenant, id, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, tenant, id, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceHeartbeat provides a mock function with given fields: ctx, uid
 func (_m *Service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -472,6 +782,34 @@ func (_m *Service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
 	return r0
 }
 
+// DisableMFA provides a mock function with given fields: ctx, id, code
+func (_m *Service) DisableMFA(ctx context.Context, id string, code string) error {
+	ret := _m.Called(ctx, id, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DispatchWebhookEvent provides a mock function with given fields: ctx, event
+func (_m *Service) DispatchWebhookEvent(ctx context.Context, event *webhook.Event) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *webhook.Event) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EditNamespace provides a mock function with given fields: ctx, tenantID, name
 func (_m *Service) EditNamespace(ctx context.Context, tenantID string, name string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, tenantID, name)
@@ -498,6 +836,34 @@ func (_m *Service) EditNamespace(ctx context.Context, tenantID string, name stri
 	return r0, r1
 }
 
+// EditNamespaceMFA provides a mock function with given fields: ctx, required, tenantID, userID
+func (_m *Service) EditNamespaceMFA(ctx context.Context, required bool, tenantID string, userID string) error {
+	ret := _m.Called(ctx, required, tenantID, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, string, string) error); ok {
+		r0 = rf(ctx, required, tenantID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EditNamespaceSettings provides a mock function with given fields: ctx, tenantID, settings
+func (_m *Service) EditNamespaceSettings(ctx context.Context, tenantID string, settings *models.NamespaceSettingsUpdate) error {
+	ret := _m.Called(ctx, tenantID, settings)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.NamespaceSettingsUpdate) error); ok {
+		r0 = rf(ctx, tenantID, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EditNamespaceUser provides a mock function with given fields: ctx, tenantID, userID, memberID, memberNewRole
 func (_m *Service) EditNamespaceUser(ctx context.Context, tenantID string, userID string, memberID string, memberNewRole string) error {
 	ret := _m.Called(ctx, tenantID, userID, memberID, memberNewRole)
@@ -526,19 +892,109 @@ func (_m *Service) EditSessionRecordStatus(ctx context.Context, sessionRecord bo
 	return r0
 }
 
-// EvaluateKeyFilter provides a mock function with given fields: ctx, key, dev
-func (_m *Service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey, dev models.Device) (bool, error) {
-	ret := _m.Called(ctx, key, dev)
+// EnableMFA provides a mock function with given fields: ctx, id, code
+func (_m *Service) EnableMFA(ctx context.Context, id string, code string) error {
+	ret := _m.Called(ctx, id, code)
 
-	var r0 bool
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey, models.Device) (bool, error)); ok {
-		return rf(ctx, key, dev)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey, models.Device) bool); ok {
-		r0 = rf(ctx, key, dev)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, code)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvaluateAgentForwarding provides a mock function with given fields: ctx, tenantID
+func (_m *Service) EvaluateAgentForwarding(ctx context.Context, tenantID string) (bool, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvaluateAuthAttempt provides a mock function with given fields: ctx, attempt
+func (_m *Service) EvaluateAuthAttempt(ctx context.Context, attempt request.AuthAttempt) (*models.AuthEvaluation, error) {
+	ret := _m.Called(ctx, attempt)
+
+	var r0 *models.AuthEvaluation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.AuthAttempt) (*models.AuthEvaluation, error)); ok {
+		return rf(ctx, attempt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, request.AuthAttempt) *models.AuthEvaluation); ok {
+		r0 = rf(ctx, attempt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthEvaluation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, request.AuthAttempt) error); ok {
+		r1 = rf(ctx, attempt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvaluateCertificate provides a mock function with given fields: ctx, data, device, username, address
+func (_m *Service) EvaluateCertificate(ctx context.Context, data []byte, device models.Device, username string, address string) (*models.CertificateEvaluation, error) {
+	ret := _m.Called(ctx, data, device, username, address)
+
+	var r0 *models.CertificateEvaluation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, models.Device, string, string) (*models.CertificateEvaluation, error)); ok {
+		return rf(ctx, data, device, username, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, models.Device, string, string) *models.CertificateEvaluation); ok {
+		r0 = rf(ctx, data, device, username, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CertificateEvaluation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, models.Device, string, string) error); ok {
+		r1 = rf(ctx, data, device, username, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvaluateKeyFilter provides a mock function with given fields: ctx, key, dev
+func (_m *Service) EvaluateKeyFilter(ctx context.Context, key *models.PublicKey, dev models.Device) (bool, error) {
+	ret := _m.Called(ctx, key, dev)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey, models.Device) (bool, error)); ok {
+		return rf(ctx, key, dev)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PublicKey, models.Device) bool); ok {
+		r0 = rf(ctx, key, dev)
+	} else {
+		r0 = ret.Get(0).(bool)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *models.PublicKey, models.Device) error); ok {
@@ -574,6 +1030,82 @@ func (_m *Service) EvaluateKeyUsername(ctx context.Context, key *models.PublicKe
 	return r0, r1
 }
 
+// EvaluateReverseForwarding provides a mock function with given fields: ctx, tenantID, port
+func (_m *Service) EvaluateReverseForwarding(ctx context.Context, tenantID string, port uint32) (bool, error) {
+	ret := _m.Called(ctx, tenantID, port)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint32) (bool, error)); ok {
+		return rf(ctx, tenantID, port)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint32) bool); ok {
+		r0 = rf(ctx, tenantID, port)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint32) error); ok {
+		r1 = rf(ctx, tenantID, port)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateMFA provides a mock function with given fields: ctx, id
+func (_m *Service) GenerateMFA(ctx context.Context, id string) (*response.UserMFAGenerate, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *response.UserMFAGenerate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*response.UserMFAGenerate, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *response.UserMFAGenerate); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*response.UserMFAGenerate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccessRequest provides a mock function with given fields: ctx, id
+func (_m *Service) GetAccessRequest(ctx context.Context, id string) (*models.AccessRequest, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.AccessRequest, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.AccessRequest); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevice provides a mock function with given fields: ctx, uid
 func (_m *Service) GetDevice(ctx context.Context, uid models.UID) (*models.Device, error) {
 	ret := _m.Called(ctx, uid)
@@ -600,6 +1132,39 @@ func (_m *Service) GetDevice(ctx context.Context, uid models.UID) (*models.Devic
 	return r0, r1
 }
 
+// GetDeviceAttributeKeys provides a mock function with given fields: ctx, tenant
+func (_m *Service) GetDeviceAttributeKeys(ctx context.Context, tenant string) ([]string, int, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 []string
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, int, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, tenant)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetNamespace provides a mock function with given fields: ctx, tenantID
 func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, tenantID)
@@ -702,6 +1267,58 @@ func (_m *Service) GetSessionRecord(ctx context.Context, tenantID string) (bool,
 	return r0, r1
 }
 
+// GetSessionRecordFrames provides a mock function with given fields: ctx, uid
+func (_m *Service) GetSessionRecordFrames(ctx context.Context, uid models.UID) ([]models.RecordedSession, error) {
+	ret := _m.Called(ctx, uid)
+
+	var r0 []models.RecordedSession
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) ([]models.RecordedSession, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) []models.RecordedSession); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.RecordedSession)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionTimeouts provides a mock function with given fields: ctx, tenantID
+func (_m *Service) GetSessionTimeouts(ctx context.Context, tenantID string) (*models.SessionTimeouts, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	var r0 *models.SessionTimeouts
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.SessionTimeouts, error)); ok {
+		return rf(ctx, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.SessionTimeouts); ok {
+		r0 = rf(ctx, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SessionTimeouts)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStats provides a mock function with given fields: ctx
 func (_m *Service) GetStats(ctx context.Context) (*models.Stats, error) {
 	ret := _m.Called(ctx)
@@ -775,6 +1392,176 @@ func (_m *Service) KeepAliveSession(ctx context.Context, uid models.UID) error {
 	return r0
 }
 
+// KeyRegistered provides a mock function with given fields: ctx, fingerprint
+func (_m *Service) KeyRegistered(ctx context.Context, fingerprint string) (bool, error) {
+	ret := _m.Called(ctx, fingerprint)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, fingerprint)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LiftAuthBan provides a mock function with given fields: ctx, id
+func (_m *Service) LiftAuthBan(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListAPIKeys provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListAPIKeys(ctx context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.APIKey
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.APIKey, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.APIKey); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListAccessRequests provides a mock function with given fields: ctx, tenant, status, pagination
+func (_m *Service) ListAccessRequests(ctx context.Context, tenant string, status string, pagination paginator.Query) ([]models.AccessRequest, int, error) {
+	ret := _m.Called(ctx, tenant, status, pagination)
+
+	var r0 []models.AccessRequest
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) ([]models.AccessRequest, int, error)); ok {
+		return rf(ctx, tenant, status, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) []models.AccessRequest); ok {
+		r0 = rf(ctx, tenant, status, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, status, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, status, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListAuditLogs provides a mock function with given fields: ctx, tenant, pagination, filter
+func (_m *Service) ListAuditLogs(ctx context.Context, tenant string, pagination paginator.Query, filter models.AuditFilter) ([]models.AuditLog, int, error) {
+	ret := _m.Called(ctx, tenant, pagination, filter)
+
+	var r0 []models.AuditLog
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query, models.AuditFilter) ([]models.AuditLog, int, error)); ok {
+		return rf(ctx, tenant, pagination, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query, models.AuditFilter) []models.AuditLog); ok {
+		r0 = rf(ctx, tenant, pagination, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query, models.AuditFilter) int); ok {
+		r1 = rf(ctx, tenant, pagination, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query, models.AuditFilter) error); ok {
+		r2 = rf(ctx, tenant, pagination, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListAuthBans provides a mock function with given fields: ctx, pagination
+func (_m *Service) ListAuthBans(ctx context.Context, pagination paginator.Query) ([]models.AuthBan, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.AuthBan
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.AuthBan, int, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.AuthBan); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuthBan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListDevices provides a mock function with given fields: ctx, tenant, pagination, filter, status, sort, order
 func (_m *Service) ListDevices(ctx context.Context, tenant string, pagination paginator.Query, filter []models.Filter, status string, sort string, order string) ([]models.Device, int, error) {
 	ret := _m.Called(ctx, tenant, pagination, filter, status, sort, order)
@@ -808,6 +1595,32 @@ func (_m *Service) ListDevices(ctx context.Context, tenant string, pagination pa
 	return r0, r1, r2
 }
 
+// ListInventoryDevices provides a mock function with given fields: ctx, tenant
+func (_m *Service) ListInventoryDevices(ctx context.Context, tenant string) ([]models.Device, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 []models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Device, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Device); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListNamespaces provides a mock function with given fields: ctx, pagination, filter, export
 func (_m *Service) ListNamespaces(ctx context.Context, pagination paginator.Query, filter []models.Filter, export bool) ([]models.Namespace, int, error) {
 	ret := _m.Called(ctx, pagination, filter, export)
@@ -907,6 +1720,105 @@ func (_m *Service) ListSessions(ctx context.Context, pagination paginator.Query)
 	return r0, r1, r2
 }
 
+// ListUserCAs provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListUserCAs(ctx context.Context, tenant string, pagination paginator.Query) ([]models.UserCA, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.UserCA
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.UserCA, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.UserCA); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserCA)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListWebhookDeliveries provides a mock function with given fields: ctx, tenant, id, pagination
+func (_m *Service) ListWebhookDeliveries(ctx context.Context, tenant string, id string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	ret := _m.Called(ctx, tenant, id, pagination)
+
+	var r0 []models.WebhookDelivery
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) ([]models.WebhookDelivery, int, error)); ok {
+		return rf(ctx, tenant, id, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) []models.WebhookDelivery); ok {
+		r0 = rf(ctx, tenant, id, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, id, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, id, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListWebhooks provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListWebhooks(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.Webhook
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.Webhook, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.Webhook); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // LookupDevice provides a mock function with given fields: ctx, namespace, name
 func (_m *Service) LookupDevice(ctx context.Context, namespace string, name string) (*models.Device, error) {
 	ret := _m.Called(ctx, namespace, name)
@@ -949,6 +1861,74 @@ func (_m *Service) PublicKey() *rsa.PublicKey {
 	return r0
 }
 
+// RecordAuthFailure provides a mock function with given fields: ctx, failure
+func (_m *Service) RecordAuthFailure(ctx context.Context, failure request.AuthFailure) error {
+	ret := _m.Called(ctx, failure)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, request.AuthFailure) error); ok {
+		r0 = rf(ctx, failure)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordSession provides a mock function with given fields: ctx, uid, message, width, height
+func (_m *Service) RecordSession(ctx context.Context, uid models.UID, message string, width int, height int) error {
+	ret := _m.Called(ctx, uid, message, width, height)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, int, int) error); ok {
+		r0 = rf(ctx, uid, message, width, height)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RecordSessionActivity provides a mock function with given fields: ctx, uid, activity
+func (_m *Service) RecordSessionActivity(ctx context.Context, uid models.UID, activity *models.SessionActivity) error {
+	ret := _m.Called(ctx, uid, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.SessionActivity) error); ok {
+		r0 = rf(ctx, uid, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RedeliverWebhook provides a mock function with given fields: ctx, tenant, id, delivery
+func (_m *Service) RedeliverWebhook(ctx context.Context, tenant string, id string, delivery string) (*models.WebhookDelivery, error) {
+	ret := _m.Called(ctx, tenant, id, delivery)
+
+	var r0 *models.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*models.WebhookDelivery, error)); ok {
+		return rf(ctx, tenant, id, delivery)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.WebhookDelivery); ok {
+		r0 = rf(ctx, tenant, id, delivery)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, tenant, id, delivery)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RemoveDeviceTag provides a mock function with given fields: ctx, uid, tag
 func (_m *Service) RemoveDeviceTag(ctx context.Context, uid models.UID, tag string) error {
 	ret := _m.Called(ctx, uid, tag)
@@ -1031,6 +2011,34 @@ func (_m *Service) RenameTag(ctx context.Context, tenant string, oldTag string,
 	return r0
 }
 
+// ReportDeviceHealth provides a mock function with given fields: ctx, uid, health
+func (_m *Service) ReportDeviceHealth(ctx context.Context, uid models.UID, health *models.DeviceHealth) error {
+	ret := _m.Called(ctx, uid, health)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.DeviceHealth) error); ok {
+		r0 = rf(ctx, uid, health)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetDeviceHostKey provides a mock function with given fields: ctx, tenant, uid
+func (_m *Service) ResetDeviceHostKey(ctx context.Context, tenant string, uid models.UID) error {
+	ret := _m.Called(ctx, tenant, uid)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) error); ok {
+		r0 = rf(ctx, tenant, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetDevicePosition provides a mock function with given fields: ctx, uid, ip
 func (_m *Service) SetDevicePosition(ctx context.Context, uid models.UID, ip string) error {
 	ret := _m.Called(ctx, uid, ip)
@@ -1059,6 +2067,20 @@ func (_m *Service) SetSessionAuthenticated(ctx context.Context, uid models.UID,
 	return r0
 }
 
+// SetSessionClosureReason provides a mock function with given fields: ctx, uid, reason
+func (_m *Service) SetSessionClosureReason(ctx context.Context, uid models.UID, reason string) error {
+	ret := _m.Called(ctx, uid, reason)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Setup provides a mock function with given fields: ctx, req
 func (_m *Service) Setup(ctx context.Context, req request.Setup) error {
 	ret := _m.Called(ctx, req)
@@ -1073,6 +2095,58 @@ func (_m *Service) Setup(ctx context.Context, req request.Setup) error {
 	return r0
 }
 
+// ShadowSession provides a mock function with given fields: ctx, tenant, uid, username, writable
+func (_m *Service) ShadowSession(ctx context.Context, tenant string, uid models.UID, username string, writable bool) (*models.SessionShadow, error) {
+	ret := _m.Called(ctx, tenant, uid, username, writable)
+
+	var r0 *models.SessionShadow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string, bool) (*models.SessionShadow, error)); ok {
+		return rf(ctx, tenant, uid, username, writable)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, string, bool) *models.SessionShadow); ok {
+		r0 = rf(ctx, tenant, uid, username, writable)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SessionShadow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID, string, bool) error); ok {
+		r1 = rf(ctx, tenant, uid, username, writable)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateAPIKey provides a mock function with given fields: ctx, tenant, role, req
+func (_m *Service) UpdateAPIKey(ctx context.Context, tenant string, role string, req *request.APIKeyUpdate) (*models.APIKey, error) {
+	ret := _m.Called(ctx, tenant, role, req)
+
+	var r0 *models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *request.APIKeyUpdate) (*models.APIKey, error)); ok {
+		return rf(ctx, tenant, role, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *request.APIKeyUpdate) *models.APIKey); ok {
+		r0 = rf(ctx, tenant, role, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *request.APIKeyUpdate) error); ok {
+		r1 = rf(ctx, tenant, role, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateDataUser provides a mock function with given fields: ctx, id, userData
 func (_m *Service) UpdateDataUser(ctx context.Context, id string, userData request.UserDataUpdate) ([]string, error) {
 	ret := _m.Called(ctx, id, userData)
@@ -1113,6 +2187,20 @@ func (_m *Service) UpdateDevice(ctx context.Context, tenant string, uid models.U
 	return r0
 }
 
+// UpdateDeviceAttributes provides a mock function with given fields: ctx, tenant, uid, attributes
+func (_m *Service) UpdateDeviceAttributes(ctx context.Context, tenant string, uid models.UID, attributes map[string]string) error {
+	ret := _m.Called(ctx, tenant, uid, attributes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, map[string]string) error); ok {
+		r0 = rf(ctx, tenant, uid, attributes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateDeviceStatus provides a mock function with given fields: ctx, uid, online
 func (_m *Service) UpdateDeviceStatus(ctx context.Context, uid models.UID, online bool) error {
 	ret := _m.Called(ctx, uid, online)
@@ -1209,6 +2297,32 @@ func (_m *Service) UpdatePublicKeyTags(ctx context.Context, tenant string, finge
 	return r0
 }
 
+// UpdateWebhook provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) UpdateWebhook(ctx context.Context, tenant string, req *request.WebhookUpdate) (*models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	var r0 *models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *request.WebhookUpdate) (*models.Webhook, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *request.WebhookUpdate) *models.Webhook); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *request.WebhookUpdate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewService interface {
 	mock.TestingT
 	Cleanup(func())
@@ -26,6 +26,7 @@ type NamespaceService interface {
 	EditNamespaceUser(ctx context.Context, tenantID, userID, memberID, memberNewRole string) error
 	EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error
 	GetSessionRecord(ctx context.Context, tenantID string) (bool, error)
+	EditNamespaceMFA(ctx context.Context, required bool, tenantID, userID string) error
 }
 
 // ListNamespaces lists selected namespaces from a user.
@@ -244,7 +245,14 @@ func (s *service) AddNamespaceUser(ctx context.Context, memberUsername, memberRo
 		return nil, guard.ErrForbidden
 	}
 
-	return s.store.NamespaceAddMember(ctx, tenantID, passive.ID, memberRole)
+	added, err := s.store.NamespaceAddMember(ctx, tenantID, passive.ID, memberRole)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenantID, models.AuditNamespaceAddMember, models.AuditTarget{Type: models.AuditTargetMember, ID: passive.ID}, nil, map[string]interface{}{"role": memberRole})
+
+	return added, nil
 }
 
 // RemoveNamespaceUser removes member from a namespace.
@@ -300,6 +308,8 @@ func (s *service) RemoveNamespaceUser(ctx context.Context, tenantID, memberID, u
 
 	s.AuthUncacheToken(ctx, namespace.TenantID, member.ID) // nolint: errcheck
 
+	s.audit(ctx, tenantID, models.AuditNamespaceRemoveMember, models.AuditTarget{Type: models.AuditTargetMember, ID: member.ID}, map[string]interface{}{"role": passive.Role}, nil)
+
 	return removed, nil
 }
 
@@ -356,6 +366,8 @@ func (s *service) EditNamespaceUser(ctx context.Context, tenantID, userID, membe
 
 	s.AuthUncacheToken(ctx, namespace.TenantID, member.ID) // nolint: errcheck
 
+	s.audit(ctx, tenantID, models.AuditNamespaceEditMember, models.AuditTarget{Type: models.AuditTargetMember, ID: member.ID}, map[string]interface{}{"role": passive.Role}, map[string]interface{}{"role": memberNewRole})
+
 	return nil
 }
 
@@ -364,7 +376,54 @@ func (s *service) EditNamespaceUser(ctx context.Context, tenantID, userID, membe
 // It receives a context, used to "control" the request flow, a boolean to define if the sessions will be recorded and
 // the tenant ID from models.Namespace.
 func (s *service) EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error {
-	return s.store.NamespaceSetSessionRecord(ctx, sessionRecord, tenantID)
+	previous, err := s.store.NamespaceGetSessionRecord(ctx, tenantID)
+	if err != nil {
+		return NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	if err := s.store.NamespaceSetSessionRecord(ctx, sessionRecord, tenantID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenantID, models.AuditNamespaceSessionRecord, models.AuditTarget{Type: models.AuditTargetNamespace, ID: tenantID}, map[string]interface{}{"session_record": previous}, map[string]interface{}{"session_record": sessionRecord})
+
+	return nil
+}
+
+// EditNamespaceMFA defines if the namespace requires its members to have MFA enabled.
+//
+// It receives a context, used to "control" the request flow, a boolean to define if MFA is required, the tenant ID
+// from models.Namespace and the user ID from models.User who is editing it. To avoid locking itself out of the
+// namespace, the user must have MFA enabled to require it. The members' tokens are uncached, so the requirement is
+// checked again on their next requests.
+func (s *service) EditNamespaceMFA(ctx context.Context, required bool, tenantID, userID string) error {
+	namespace, err := s.store.NamespaceGet(ctx, tenantID)
+	if err != nil {
+		return NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	if required {
+		user, _, err := s.store.UserGetByID(ctx, userID, false)
+		if err != nil {
+			return NewErrUserNotFound(userID, err)
+		}
+
+		if !user.MFA.Enabled {
+			return NewErrUserMFADisabled(nil)
+		}
+	}
+
+	if err := s.store.NamespaceSetMFARequired(ctx, required, tenantID); err != nil {
+		return err
+	}
+
+	for _, member := range namespace.Members {
+		s.AuthUncacheToken(ctx, namespace.TenantID, member.ID) // nolint: errcheck
+	}
+
+	s.audit(ctx, tenantID, models.AuditNamespaceMFARequired, models.AuditTarget{Type: models.AuditTargetNamespace, ID: tenantID}, map[string]interface{}{"mfa_required": mfaRequired(namespace)}, map[string]interface{}{"mfa_required": required})
+
+	return nil
 }
 
 // GetSessionRecord gets the session record data.
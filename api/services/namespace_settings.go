@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// NamespaceSettingsService contains the service's functions to manage the namespace's settings enforced on the SSH
+// sessions and on the agents.
+type NamespaceSettingsService interface {
+	EditNamespaceSettings(ctx context.Context, tenantID string, settings *models.NamespaceSettingsUpdate) error
+}
+
+// EditNamespaceSettings replaces the settings of a namespace that are set in settings, auditing each of them.
+//
+// If the namespace does not exist, a NewErrNamespaceNotFound error will be returned.
+func (s *service) EditNamespaceSettings(ctx context.Context, tenantID string, settings *models.NamespaceSettingsUpdate) error {
+	namespace, err := s.store.NamespaceGet(ctx, tenantID)
+	if err != nil || namespace == nil {
+		return NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	if err := s.store.NamespaceUpdateSettings(ctx, tenantID, settings); err != nil {
+		return NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	target := models.AuditTarget{Type: models.AuditTargetNamespace, ID: tenantID}
+
+	if settings.ReverseForwarding != nil {
+		s.audit(ctx, tenantID, models.AuditNamespaceForwarding, target, map[string]interface{}{"reverse_forwarding": reverseForwarding(namespace)}, map[string]interface{}{"reverse_forwarding": settings.ReverseForwarding})
+	}
+
+	if settings.AgentForwarding != nil {
+		s.audit(ctx, tenantID, models.AuditNamespaceAgentForward, target, map[string]interface{}{"agent_forwarding": agentForwarding(namespace)}, map[string]interface{}{"agent_forwarding": *settings.AgentForwarding})
+	}
+
+	if settings.SessionTimeouts != nil {
+		s.audit(ctx, tenantID, models.AuditNamespaceTimeouts, target, map[string]interface{}{"session_timeouts": sessionTimeouts(namespace)}, map[string]interface{}{"session_timeouts": settings.SessionTimeouts})
+	}
+
+	if settings.ProtectedTags != nil {
+		s.audit(ctx, tenantID, models.AuditNamespaceProtectedTags, target, map[string]interface{}{"protected_tags": protectedTags(namespace)}, map[string]interface{}{"protected_tags": *settings.ProtectedTags})
+	}
+
+	if settings.AgentUpdate != nil {
+		s.audit(ctx, tenantID, models.AuditNamespaceAgentUpdate, target, map[string]interface{}{"agent_update": agentUpdate(namespace)}, map[string]interface{}{"agent_update": settings.AgentUpdate})
+	}
+
+	return nil
+}
+
+// agentUpdate returns the policy for the automatic updates of a namespace's agents, or nil when it has none.
+func agentUpdate(namespace *models.Namespace) *models.AgentUpdate {
+	if namespace.Settings == nil {
+		return nil
+	}
+
+	return namespace.Settings.AgentUpdate
+}
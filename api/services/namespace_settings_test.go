@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEditNamespaceSettings(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	namespace := &models.Namespace{Name: "namespace", TenantID: "tenant"}
+
+	enabled := true
+	settings := &models.NamespaceSettingsUpdate{
+		AgentForwarding: &enabled,
+		AgentUpdate:     &models.AgentUpdate{Version: "v0.13.0"},
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the namespace is not found",
+			tenant:      "invalid",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "invalid").Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("invalid", Err),
+		},
+		{
+			description: "fails when the store function to update the settings fails",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("NamespaceUpdateSettings", ctx, "tenant", settings).Return(Err).Once()
+			},
+			expected: NewErrNamespaceNotFound("tenant", Err),
+		},
+		{
+			description: "succeeds to update the settings, auditing each of them",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				storeMock.On("NamespaceUpdateSettings", ctx, "tenant", settings).Return(nil).Once()
+				storeMock.On("AuditCreate", ctx, mock.MatchedBy(func(entry *models.AuditLog) bool {
+					return entry.Action == models.AuditNamespaceAgentForward &&
+						entry.Before["agent_forwarding"] == false &&
+						entry.After["agent_forwarding"] == true
+				})).Return(nil).Once()
+				storeMock.On("AuditCreate", ctx, mock.MatchedBy(func(entry *models.AuditLog) bool {
+					return entry.Action == models.AuditNamespaceAgentUpdate &&
+						entry.Before["agent_update"] == (*models.AgentUpdate)(nil) &&
+						entry.After["agent_update"] == settings.AgentUpdate
+				})).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := s.EditNamespaceSettings(ctx, tc.tenant, settings)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
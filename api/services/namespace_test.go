@@ -888,6 +888,12 @@ func TestAddNamespaceUser(t *testing.T) {
 				mock.On("UserGetByUsername", ctx, user2.Username).Return(user2, nil).Once()
 
 				mock.On("NamespaceAddMember", ctx, namespace.TenantID, user2.ID, guard.RoleObserver).Return(namespaceTwoMembers, nil).Once()
+				mock.On("AuditCreate", ctx, &models.AuditLog{
+					TenantID: namespace.TenantID,
+					Action:   models.AuditNamespaceAddMember,
+					Target:   models.AuditTarget{Type: models.AuditTargetMember, ID: user2.ID},
+					After:    map[string]interface{}{"role": guard.RoleObserver},
+				}).Return(nil).Once()
 			},
 			Expected: Expected{
 				namespace: namespaceTwoMembers,
@@ -1029,6 +1035,12 @@ func TestRemoveNamespaceUser(t *testing.T) {
 				mock.On("UserGetByID", ctx, user2.ID, false).Return(user2, 0, nil).Once()
 
 				mock.On("NamespaceRemoveMember", ctx, namespaceTwoMembers.TenantID, user2.ID).Return(namespace, nil).Once()
+				mock.On("AuditCreate", ctx, &models.AuditLog{
+					TenantID: namespaceTwoMembers.TenantID,
+					Action:   models.AuditNamespaceRemoveMember,
+					Target:   models.AuditTarget{Type: models.AuditTargetMember, ID: user2.ID},
+					Before:   map[string]interface{}{"role": guard.RoleObserver},
+				}).Return(nil).Once()
 			},
 			TenantID: namespaceTwoMembers.TenantID,
 			MemberID: user2.ID,
@@ -1199,6 +1211,13 @@ func TestEditNamespaceUser(t *testing.T) {
 				mock.On("UserGetByID", ctx, activeMember.ID, false).Return(activeMember, 0, nil).Once()
 
 				mock.On("NamespaceEditMember", ctx, namespaceActivePassive.TenantID, passiveMember.ID, guard.RoleOperator).Return(nil).Once()
+				mock.On("AuditCreate", ctx, &models.AuditLog{
+					TenantID: namespaceActivePassive.TenantID,
+					Action:   models.AuditNamespaceEditMember,
+					Target:   models.AuditTarget{Type: models.AuditTargetMember, ID: passiveMember.ID},
+					Before:   map[string]interface{}{"role": guard.RoleObserver},
+					After:    map[string]interface{}{"role": guard.RoleOperator},
+				}).Return(nil).Once()
 			},
 			Expected: nil,
 		},
@@ -1310,11 +1329,22 @@ func TestEditSessionRecord(t *testing.T) {
 		ownerID, tenantID string
 		expected          error
 	}{
+		{
+			name:    "EditSessionRecord fails when namespace get session record fails",
+			ownerID: namespace.Owner,
+			requiredMocks: func() {
+				mock.On("NamespaceGetSessionRecord", ctx, namespace.TenantID).Return(false, Err).Once()
+			},
+			tenantID:      namespace.TenantID,
+			sessionRecord: true,
+			expected:      NewErrNamespaceNotFound(namespace.TenantID, Err),
+		},
 		{
 			name:    "EditSessionRecord fails when namespace set session record fails",
 			ownerID: namespace.Owner,
 			requiredMocks: func() {
 				status := true
+				mock.On("NamespaceGetSessionRecord", ctx, namespace.TenantID).Return(false, nil).Once()
 				mock.On("NamespaceSetSessionRecord", ctx, status, namespace.TenantID).Return(Err).Once()
 			},
 			tenantID:      namespace.TenantID,
@@ -1326,7 +1356,15 @@ func TestEditSessionRecord(t *testing.T) {
 			ownerID: namespace.Owner,
 			requiredMocks: func() {
 				status := true
+				mock.On("NamespaceGetSessionRecord", ctx, namespace.TenantID).Return(false, nil).Once()
 				mock.On("NamespaceSetSessionRecord", ctx, status, namespace.TenantID).Return(nil).Once()
+				mock.On("AuditCreate", ctx, &models.AuditLog{
+					TenantID: namespace.TenantID,
+					Action:   models.AuditNamespaceSessionRecord,
+					Target:   models.AuditTarget{Type: models.AuditTargetNamespace, ID: namespace.TenantID},
+					Before:   map[string]interface{}{"session_record": false},
+					After:    map[string]interface{}{"session_record": status},
+				}).Return(nil).Once()
 			},
 			tenantID:      namespace.TenantID,
 			sessionRecord: true,
@@ -1,8 +1,10 @@
 package services
 
 import (
+	"context"
 	"crypto/rsa"
 
+	"github.com/hibiken/asynq"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/geoip"
@@ -21,23 +23,56 @@ type service struct {
 	cache   cache.Cache
 	client  interface{}
 	locator geoip.Locator
+	tasks   Enqueuer
+}
+
+// Enqueuer enqueues the tasks processed by the API's workers, like the webhook's deliveries.
+type Enqueuer interface {
+	EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
+// Option configures an optional feature of the service.
+type Option func(*service)
+
+// WithEnqueuer enables the features processed by the API's workers, enqueuing their tasks through e.
+func WithEnqueuer(e Enqueuer) Option {
+	return func(s *service) {
+		s.tasks = e
+	}
 }
 
 type Service interface {
 	TagsService
 	DeviceService
 	DeviceTags
+	DeviceBulkService
+	DeviceAttributes
+	DeviceHostKey
+	DeviceHealthService
+	DeviceInventory
 	UserService
 	SSHKeysService
 	SSHKeysTagsService
+	UserCAService
 	SessionService
 	NamespaceService
+	ReverseForwardingService
+	AgentForwardingService
+	SessionTimeoutsService
+	SessionShadowService
+	AuthLimitService
+	AccessRequestService
+	NamespaceSettingsService
 	AuthService
 	StatsService
 	SetupService
+	AuditService
+	APIKeyService
+	MFAService
+	WebhookService
 }
 
-func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, cache cache.Cache, c interface{}, l geoip.Locator) *APIService {
+func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, cache cache.Cache, c interface{}, l geoip.Locator, opts ...Option) *APIService {
 	if privKey == nil || pubKey == nil {
 		var err error
 		privKey, pubKey, err = LoadKeys()
@@ -46,5 +81,10 @@ func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKe
 		}
 	}
 
-	return &APIService{service: &service{store, privKey, pubKey, cache, c, l}}
+	s := &service{store, privKey, pubKey, cache, c, l, nil}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return &APIService{service: s}
 }
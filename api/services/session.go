@@ -7,6 +7,8 @@ import (
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
@@ -17,6 +19,11 @@ type SessionService interface {
 	DeactivateSession(ctx context.Context, uid models.UID) error
 	KeepAliveSession(ctx context.Context, uid models.UID) error
 	SetSessionAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error
+	RecordSession(ctx context.Context, uid models.UID, message string, width, height int) error
+	GetSessionRecordFrames(ctx context.Context, uid models.UID) ([]models.RecordedSession, error)
+	DeleteSessionRecord(ctx context.Context, uid models.UID) error
+	RecordSessionActivity(ctx context.Context, uid models.UID, activity *models.SessionActivity) error
+	SetSessionClosureReason(ctx context.Context, uid models.UID, reason string) error
 }
 
 func (s *service) ListSessions(ctx context.Context, pagination paginator.Query) ([]models.Session, int, error) {
@@ -30,7 +37,16 @@ func (s *service) GetSession(ctx context.Context, uid models.UID) (*models.Sessi
 func (s *service) CreateSession(ctx context.Context, session request.SessionCreate) (*models.Session, error) {
 	position, _ := s.locator.GetPosition(net.ParseIP(session.IPAddress))
 
-	return s.store.SessionCreate(ctx, models.Session{
+	// The session to a device with a protected tag records the decision of the request that approved it.
+	var access *models.AccessRequest
+	if session.AccessRequest != "" {
+		var err error
+		if access, err = s.store.AccessRequestGet(ctx, session.AccessRequest); err != nil {
+			return nil, NewErrAccessRequestNotFound(session.AccessRequest, err)
+		}
+	}
+
+	created, err := s.store.SessionCreate(ctx, models.Session{
 		UID:       session.UID,
 		DeviceUID: models.UID(session.DeviceUID),
 		Username:  session.Username,
@@ -41,7 +57,15 @@ func (s *service) CreateSession(ctx context.Context, session request.SessionCrea
 			Longitude: position.Longitude,
 			Latitude:  position.Latitude,
 		},
+		AccessRequest: access,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.emit(ctx, created.TenantID, webhook.EventSessionStarted, created)
+
+	return created, nil
 }
 
 func (s *service) DeactivateSession(ctx context.Context, uid models.UID) error {
@@ -50,7 +74,13 @@ func (s *service) DeactivateSession(ctx context.Context, uid models.UID) error {
 		return NewErrSessionNotFound(uid, err)
 	}
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	s.emitSession(ctx, webhook.EventSessionClosed, uid)
+
+	return nil
 }
 
 func (s *service) KeepAliveSession(ctx context.Context, uid models.UID) error {
@@ -58,5 +88,112 @@ func (s *service) KeepAliveSession(ctx context.Context, uid models.UID) error {
 }
 
 func (s *service) SetSessionAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error {
-	return s.store.SessionSetAuthenticated(ctx, uid, authenticated)
+	if err := s.store.SessionSetAuthenticated(ctx, uid, authenticated); err != nil {
+		return err
+	}
+
+	if authenticated {
+		s.emitSession(ctx, webhook.EventSessionAuthenticated, uid)
+	}
+
+	return nil
+}
+
+// emitSession emits a session's event with the session's current state.
+func (s *service) emitSession(ctx context.Context, event string, uid models.UID) {
+	if !s.emitting() {
+		return
+	}
+
+	session, err := s.store.SessionGet(ctx, uid)
+	if err != nil {
+		return
+	}
+
+	s.emit(ctx, session.TenantID, event, session)
+}
+
+// RecordSession records a frame of a session's output.
+//
+// The frame is discarded when the session's namespace has the session record disabled.
+func (s *service) RecordSession(ctx context.Context, uid models.UID, message string, width, height int) error {
+	session, err := s.store.SessionGet(ctx, uid)
+	if err != nil {
+		return NewErrSessionNotFound(uid, err)
+	}
+
+	if record, err := s.store.NamespaceGetSessionRecord(ctx, session.TenantID); err != nil || !record {
+		return err
+	}
+
+	return s.store.SessionCreateRecordFrame(ctx, uid, &models.RecordedSession{
+		UID:      uid,
+		Message:  message,
+		TenantID: session.TenantID,
+		Time:     clock.Now(),
+		Width:    width,
+		Height:   height,
+	})
+}
+
+// GetSessionRecordFrames gets the recorded frames of a session, ordered by time.
+//
+// It returns an error when the session is not found or when it has no recorded frames.
+func (s *service) GetSessionRecordFrames(ctx context.Context, uid models.UID) ([]models.RecordedSession, error) {
+	if _, err := s.store.SessionGet(ctx, uid); err != nil {
+		return nil, NewErrSessionNotFound(uid, err)
+	}
+
+	frames, count, err := s.store.SessionGetRecordFrame(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return nil, NewErrSessionRecordNotFound(uid, nil)
+	}
+
+	return frames, nil
+}
+
+// DeleteSessionRecord deletes the recorded frames of a session.
+func (s *service) DeleteSessionRecord(ctx context.Context, uid models.UID) error {
+	if _, err := s.store.SessionGet(ctx, uid); err != nil {
+		return NewErrSessionNotFound(uid, err)
+	}
+
+	return s.store.SessionDeleteRecordFrame(ctx, uid)
+}
+
+// RecordSessionActivity records what was done in a non-interactive session, as the command executed, the head of its
+// streams, its exit code and the file operations of a SFTP session.
+//
+// The activity is discarded when the session's namespace has the session record disabled. It returns
+// NewErrSessionNotFound when the session does not exist.
+func (s *service) RecordSessionActivity(ctx context.Context, uid models.UID, activity *models.SessionActivity) error {
+	session, err := s.store.SessionGet(ctx, uid)
+	if err != nil {
+		return NewErrSessionNotFound(uid, err)
+	}
+
+	if record, err := s.store.NamespaceGetSessionRecord(ctx, session.TenantID); err != nil || !record {
+		return err
+	}
+
+	if err := s.store.SessionSetActivity(ctx, uid, activity); err != nil {
+		return NewErrSessionNotFound(uid, err)
+	}
+
+	return nil
+}
+
+// SetSessionClosureReason records why the SSH server closed a session, as when it reached a namespace's timeout.
+//
+// It returns NewErrSessionNotFound when the session does not exist.
+func (s *service) SetSessionClosureReason(ctx context.Context, uid models.UID, reason string) error {
+	if err := s.store.SessionSetClosureReason(ctx, uid, reason); err != nil {
+		return NewErrSessionNotFound(uid, err)
+	}
+
+	return nil
 }
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+// SessionShadowTTL is how long a session shadow can be redeemed by the SSH server after its creation.
+const SessionShadowTTL = time.Second * 30
+
+// SessionShadowService contains the service's function to let a namespace's member join an active session.
+type SessionShadowService interface {
+	ShadowSession(ctx context.Context, tenant string, uid models.UID, username string, writable bool) (*models.SessionShadow, error)
+}
+
+// ShadowSession grants username the access to join an active session of the namespace, watching it or, when writable,
+// also typing on it. The returned token must be redeemed in the SSH server within SessionShadowTTL.
+//
+// It returns NewErrSessionNotFound when the session does not belong to the namespace and NewErrSessionNotActive when
+// the session has already finished.
+func (s *service) ShadowSession(ctx context.Context, tenant string, uid models.UID, username string, writable bool) (*models.SessionShadow, error) {
+	session, err := s.store.SessionGet(ctx, uid)
+	if err != nil || session.TenantID != tenant {
+		return nil, NewErrSessionNotFound(uid, err)
+	}
+
+	if !session.Active {
+		return nil, NewErrSessionNotActive(uid, nil)
+	}
+
+	shadow := &models.SessionShadow{
+		Token:    uuid.Generate(),
+		UID:      session.UID,
+		TenantID: tenant,
+		Username: username,
+		Writable: writable,
+	}
+
+	if err := s.cache.Set(ctx, models.SessionShadowCacheKey(shadow.Token), shadow, SessionShadowTTL); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditSessionShadow, models.AuditTarget{Type: models.AuditTargetSession, ID: session.UID}, nil, map[string]interface{}{"username": username, "writable": writable})
+
+	return shadow, nil
+}
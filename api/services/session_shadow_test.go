@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestShadowSession(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		shadow *models.SessionShadow
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the session is not found",
+			uid:         "invalid",
+			requiredMocks: func() {
+				storeMock.On("SessionGet", ctx, models.UID("invalid")).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrSessionNotFound("invalid", Err)},
+		},
+		{
+			description: "fails when the session belongs to another namespace",
+			uid:         "uid",
+			requiredMocks: func() {
+				storeMock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "other", Active: true}, nil).Once()
+			},
+			expected: Expected{nil, NewErrSessionNotFound("uid", nil)},
+		},
+		{
+			description: "fails when the session is not active",
+			uid:         "uid",
+			requiredMocks: func() {
+				storeMock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "tenant"}, nil).Once()
+			},
+			expected: Expected{nil, NewErrSessionNotActive("uid", nil)},
+		},
+		{
+			description: "succeeds to shadow the session",
+			uid:         "uid",
+			requiredMocks: func() {
+				storeMock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", TenantID: "tenant", Active: true}, nil).Once()
+				uuidMock.On("Generate").Return("token").Once()
+				storeMock.On("AuditCreate", ctx, mock.MatchedBy(func(entry *models.AuditLog) bool {
+					return entry.Action == models.AuditSessionShadow &&
+						entry.Target.ID == "uid" &&
+						entry.After["username"] == "john" &&
+						entry.After["writable"] == true
+				})).Return(nil).Once()
+			},
+			expected: Expected{
+				&models.SessionShadow{Token: "token", UID: "uid", TenantID: "tenant", Username: "john", Writable: true},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			shadow, err := s.ShadowSession(ctx, "tenant", tc.uid, "john", true)
+			assert.Equal(t, tc.expected, Expected{shadow, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
@@ -308,3 +308,273 @@ func TestSetSessionAuthenticated(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestGetSessionRecordFrames(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	session := &models.Session{UID: "uid"}
+	frames := []models.RecordedSession{
+		{UID: "uid", Message: "$ ", Width: 80, Height: 24},
+	}
+
+	Err := errors.New("error")
+
+	type Expected struct {
+		frames []models.RecordedSession
+		err    error
+	}
+
+	cases := []struct {
+		name          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name: "GetSessionRecordFrames fails when the session is not found",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrSessionNotFound("uid", Err)},
+		},
+		{
+			name: "GetSessionRecordFrames fails when the session has no frames",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("SessionGetRecordFrame", ctx, models.UID("uid")).Return([]models.RecordedSession{}, 0, nil).Once()
+			},
+			expected: Expected{nil, NewErrSessionRecordNotFound("uid", nil)},
+		},
+		{
+			name: "GetSessionRecordFrames succeeds",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("SessionGetRecordFrame", ctx, models.UID("uid")).Return(frames, len(frames), nil).Once()
+			},
+			expected: Expected{frames, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			returned, err := s.GetSessionRecordFrames(ctx, "uid")
+			assert.Equal(t, tc.expected, Expected{returned, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestRecordSession(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	session := &models.Session{UID: "uid", TenantID: "tenant"}
+	frame := &models.RecordedSession{UID: "uid", TenantID: "tenant", Message: "$ ", Time: now, Width: 80, Height: 24}
+
+	Err := errors.New("error")
+
+	cases := []struct {
+		name          string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "RecordSession fails when the session is not found",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(nil, Err).Once()
+			},
+			expected: NewErrSessionNotFound("uid", Err),
+		},
+		{
+			name: "RecordSession discards the frame when the namespace has the session record disabled",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("NamespaceGetSessionRecord", ctx, "tenant").Return(false, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			name: "RecordSession succeeds",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("NamespaceGetSessionRecord", ctx, "tenant").Return(true, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("SessionCreateRecordFrame", ctx, models.UID("uid"), frame).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			err := s.RecordSession(ctx, "uid", "$ ", 80, 24)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteSessionRecord(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error")
+
+	cases := []struct {
+		name          string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "DeleteSessionRecord fails when the session is not found",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(nil, Err).Once()
+			},
+			expected: NewErrSessionNotFound("uid", Err),
+		},
+		{
+			name: "DeleteSessionRecord succeeds",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(&models.Session{UID: "uid"}, nil).Once()
+				mock.On("SessionDeleteRecordFrame", ctx, models.UID("uid")).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			err := s.DeleteSessionRecord(ctx, "uid")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestRecordSessionActivity(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error")
+
+	session := &models.Session{UID: "uid", TenantID: "tenant"}
+
+	code := 0
+	activity := &models.SessionActivity{
+		Command:  "uptime",
+		Stdout:   models.SessionStream{Data: "up 3 days\n", Size: 10},
+		ExitCode: &code,
+	}
+
+	cases := []struct {
+		name          string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "RecordSessionActivity fails when the session is not found",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(nil, Err).Once()
+			},
+			expected: NewErrSessionNotFound("uid", Err),
+		},
+		{
+			name: "RecordSessionActivity fails when the session record status cannot be got",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("NamespaceGetSessionRecord", ctx, "tenant").Return(false, Err).Once()
+			},
+			expected: Err,
+		},
+		{
+			name: "RecordSessionActivity discards the activity when the session record is disabled",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("NamespaceGetSessionRecord", ctx, "tenant").Return(false, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			name: "RecordSessionActivity fails when the activity cannot be set",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("NamespaceGetSessionRecord", ctx, "tenant").Return(true, nil).Once()
+				mock.On("SessionSetActivity", ctx, models.UID("uid"), activity).Return(Err).Once()
+			},
+			expected: NewErrSessionNotFound("uid", Err),
+		},
+		{
+			name: "RecordSessionActivity succeeds",
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).Return(session, nil).Once()
+				mock.On("NamespaceGetSessionRecord", ctx, "tenant").Return(true, nil).Once()
+				mock.On("SessionSetActivity", ctx, models.UID("uid"), activity).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			err := s.RecordSessionActivity(ctx, "uid", activity)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestSetSessionClosureReason(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error")
+
+	cases := []struct {
+		name          string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "SetSessionClosureReason fails when the session is not found",
+			requiredMocks: func() {
+				mock.On("SessionSetClosureReason", ctx, models.UID("uid"), models.SessionClosureIdleTimeout).Return(Err).Once()
+			},
+			expected: NewErrSessionNotFound("uid", Err),
+		},
+		{
+			name: "SetSessionClosureReason succeeds",
+			requiredMocks: func() {
+				mock.On("SessionSetClosureReason", ctx, models.UID("uid"), models.SessionClosureIdleTimeout).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			err := s.SetSessionClosureReason(ctx, "uid", models.SessionClosureIdleTimeout)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// SessionTimeoutsService contains the service's functions to get the limits of how long the SSH sessions to the
+// namespace's devices can last.
+type SessionTimeoutsService interface {
+	GetSessionTimeouts(ctx context.Context, tenantID string) (*models.SessionTimeouts, error)
+}
+
+// GetSessionTimeouts returns the limits of the namespace's SSH sessions. They are disabled until set by the namespace.
+//
+// If the namespace does not exist, a NewErrNamespaceNotFound error will be returned.
+func (s *service) GetSessionTimeouts(ctx context.Context, tenantID string) (*models.SessionTimeouts, error) {
+	namespace, err := s.store.NamespaceGet(ctx, tenantID)
+	if err != nil || namespace == nil {
+		return nil, NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	return sessionTimeouts(namespace), nil
+}
+
+// sessionTimeouts returns the limits of a namespace's SSH sessions.
+func sessionTimeouts(namespace *models.Namespace) *models.SessionTimeouts {
+	if namespace.Settings == nil || namespace.Settings.SessionTimeouts == nil {
+		return &models.SessionTimeouts{}
+	}
+
+	return namespace.Settings.SessionTimeouts
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSessionTimeouts(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		timeouts *models.SessionTimeouts
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the namespace is not found",
+			tenant:      "invalid",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "invalid").Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrNamespaceNotFound("invalid", Err)},
+		},
+		{
+			description: "disables the timeouts when the namespace has no settings",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+			},
+			expected: Expected{&models.SessionTimeouts{}, nil},
+		},
+		{
+			description: "returns the namespace's timeouts",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{SessionTimeouts: &models.SessionTimeouts{IdleTimeout: 15}}}, nil).Once()
+			},
+			expected: Expected{&models.SessionTimeouts{IdleTimeout: 15}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			timeouts, err := s.GetSessionTimeouts(ctx, tc.tenant)
+			assert.Equal(t, tc.expected, Expected{timeouts, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
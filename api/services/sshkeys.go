@@ -22,6 +22,7 @@ type SSHKeysService interface {
 	EvaluateKeyUsername(ctx context.Context, key *models.PublicKey, username string) (bool, error)
 	ListPublicKeys(ctx context.Context, pagination paginator.Query) ([]models.PublicKey, int, error)
 	GetPublicKey(ctx context.Context, fingerprint, tenant string) (*models.PublicKey, error)
+	KeyRegistered(ctx context.Context, fingerprint string) (bool, error)
 	CreatePublicKey(ctx context.Context, req request.PublicKeyCreate, tenant string) (*response.PublicKeyCreate, error)
 	UpdatePublicKey(ctx context.Context, fingerprint, tenant string, key request.PublicKeyUpdate) (*models.PublicKey, error)
 	DeletePublicKey(ctx context.Context, fingerprint, tenant string) error
@@ -75,6 +76,17 @@ func (s *service) GetPublicKey(ctx context.Context, fingerprint, tenant string)
 	return s.store.PublicKeyGet(ctx, fingerprint, tenant)
 }
 
+// KeyRegistered checks if a public key, or a user certificate authority, with the fingerprint is registered in any
+// namespace.
+func (s *service) KeyRegistered(ctx context.Context, fingerprint string) (bool, error) {
+	registered, err := s.store.PublicKeyRegistered(ctx, fingerprint)
+	if err != nil || registered {
+		return registered, err
+	}
+
+	return s.store.UserCARegistered(ctx, fingerprint)
+}
+
 func (s *service) CreatePublicKey(ctx context.Context, req request.PublicKeyCreate, tenant string) (*response.PublicKeyCreate, error) {
 	// Checks if public key filter type is Tags.
 	// If it is, checks if there are, at least, one tag on the public key filter and if the all tags exist on database.
@@ -293,6 +293,73 @@ func TestGetPublicKeys(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestKeyRegistered(t *testing.T) {
+	mock := &mocks.Store{}
+
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	type Expected struct {
+		registered bool
+		err        error
+	}
+
+	cases := []struct {
+		description   string
+		fingerprint   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "Fails when the public keys cannot be checked",
+			fingerprint: "fingerprint",
+			requiredMocks: func() {
+				mock.On("PublicKeyRegistered", ctx, "fingerprint").Return(false, Err).Once()
+			},
+			expected: Expected{false, Err},
+		},
+		{
+			description: "Succeeds when a public key is registered",
+			fingerprint: "fingerprint",
+			requiredMocks: func() {
+				mock.On("PublicKeyRegistered", ctx, "fingerprint").Return(true, nil).Once()
+			},
+			expected: Expected{true, nil},
+		},
+		{
+			description: "Succeeds when a user certificate authority is registered",
+			fingerprint: "fingerprint",
+			requiredMocks: func() {
+				mock.On("PublicKeyRegistered", ctx, "fingerprint").Return(false, nil).Once()
+				mock.On("UserCARegistered", ctx, "fingerprint").Return(true, nil).Once()
+			},
+			expected: Expected{true, nil},
+		},
+		{
+			description: "Succeeds when nothing is registered",
+			fingerprint: "fingerprint",
+			requiredMocks: func() {
+				mock.On("PublicKeyRegistered", ctx, "fingerprint").Return(false, nil).Once()
+				mock.On("UserCARegistered", ctx, "fingerprint").Return(false, nil).Once()
+			},
+			expected: Expected{false, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+			registered, err := s.KeyRegistered(ctx, tc.fingerprint)
+			assert.Equal(t, tc.expected, Expected{registered, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
 func TestUpdatePublicKeys(t *testing.T) {
 	mock := &mocks.Store{}
 
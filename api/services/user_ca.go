@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"golang.org/x/crypto/ssh"
+)
+
+// Critical options of a user certificate enforced by ShellHub.
+const (
+	// CriticalOptionSourceAddress restricts the addresses, a comma separated list of addresses and CIDR blocks, the
+	// certificate can be used from.
+	CriticalOptionSourceAddress = "source-address"
+	// CriticalOptionForceCommand is the command executed instead of the one requested by the user.
+	CriticalOptionForceCommand = "force-command"
+)
+
+// UserCAService contains the service's functions to manage the namespace's trusted user certificate authorities and
+// to evaluate the certificates signed by them.
+type UserCAService interface {
+	ListUserCAs(ctx context.Context, tenant string, pagination paginator.Query) ([]models.UserCA, int, error)
+	CreateUserCA(ctx context.Context, tenant, name string, data []byte) (*models.UserCA, error)
+	DeleteUserCA(ctx context.Context, tenant, fingerprint string) error
+	EvaluateCertificate(ctx context.Context, data []byte, device models.Device, username, address string) (*models.CertificateEvaluation, error)
+	AuthCertificate(ctx context.Context, uid models.UID, data []byte, username, address string) (*models.CertificateEvaluation, error)
+}
+
+// ListUserCAs lists the user certificate authorities trusted by a namespace.
+func (s *service) ListUserCAs(ctx context.Context, tenant string, pagination paginator.Query) ([]models.UserCA, int, error) {
+	return s.store.UserCAList(ctx, tenant, pagination)
+}
+
+// CreateUserCA trusts a user certificate authority in a namespace. Data is the authority's public key in the
+// authorized keys format.
+//
+// It returns NewErrUserCAInvalid when data is not a public key and NewErrUserCADuplicated when the authority is
+// already trusted by the namespace.
+func (s *service) CreateUserCA(ctx context.Context, tenant, name string, data []byte) (*models.UserCA, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey(data) //nolint:dogsled
+	if err != nil {
+		return nil, NewErrUserCAInvalid(err)
+	}
+
+	if _, ok := key.(*ssh.Certificate); ok {
+		return nil, NewErrUserCAInvalid(nil)
+	}
+
+	fingerprint := ssh.FingerprintLegacyMD5(key)
+
+	if _, err := s.store.UserCAGet(ctx, tenant, fingerprint); err != store.ErrNoDocuments {
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, NewErrUserCADuplicated(fingerprint, nil)
+	}
+
+	ca := &models.UserCA{
+		Fingerprint: fingerprint,
+		TenantID:    tenant,
+		Name:        name,
+		Data:        ssh.MarshalAuthorizedKey(key),
+		CreatedAt:   clock.Now(),
+	}
+
+	if err := s.store.UserCACreate(ctx, ca); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditUserCACreate, models.AuditTarget{Type: models.AuditTargetUserCA, ID: ca.Fingerprint}, nil, map[string]interface{}{"name": ca.Name})
+
+	return ca, nil
+}
+
+// DeleteUserCA stops trusting a user certificate authority in a namespace. The certificates signed by it are no longer
+// accepted.
+func (s *service) DeleteUserCA(ctx context.Context, tenant, fingerprint string) error {
+	ca, err := s.store.UserCAGet(ctx, tenant, fingerprint)
+	if err != nil {
+		return NewErrUserCANotFound(fingerprint, err)
+	}
+
+	if err := s.store.UserCADelete(ctx, tenant, fingerprint); err != nil {
+		return NewErrUserCANotFound(fingerprint, err)
+	}
+
+	s.audit(ctx, tenant, models.AuditUserCADelete, models.AuditTarget{Type: models.AuditTargetUserCA, ID: ca.Fingerprint}, map[string]interface{}{"name": ca.Name}, nil)
+
+	return nil
+}
+
+// EvaluateCertificate checks if an OpenSSH user certificate allows username to log in a device from address. Data is
+// the certificate in the authorized keys format.
+//
+// The certificate must be signed by a user certificate authority trusted by the device's namespace, be in its validity
+// window and have username among its principals. Certificates without principals are refused, as they would be valid
+// for every username. Besides CriticalOptionSourceAddress, which is checked against address, the only critical option
+// supported is CriticalOptionForceCommand, returned to be enforced by the caller.
+//
+// It returns NewErrUserCANotFound when the authority is not trusted and NewErrUserCertificateInvalid when the
+// certificate does not allow the login.
+func (s *service) EvaluateCertificate(ctx context.Context, data []byte, device models.Device, username, address string) (*models.CertificateEvaluation, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey(data) //nolint:dogsled
+	if err != nil {
+		return nil, NewErrUserCertificateInvalid(err)
+	}
+
+	cert, ok := key.(*ssh.Certificate)
+	if !ok || cert.CertType != ssh.UserCert || len(cert.ValidPrincipals) == 0 {
+		return nil, NewErrUserCertificateInvalid(nil)
+	}
+
+	fingerprint := ssh.FingerprintLegacyMD5(cert.SignatureKey)
+	if _, err := s.store.UserCAGet(ctx, device.TenantID, fingerprint); err != nil {
+		return nil, NewErrUserCANotFound(fingerprint, err)
+	}
+
+	checker := &ssh.CertChecker{
+		SupportedCriticalOptions: []string{CriticalOptionSourceAddress, CriticalOptionForceCommand},
+		Clock:                    clock.Now,
+	}
+
+	if err := checker.CheckCert(username, cert); err != nil {
+		return nil, NewErrUserCertificateInvalid(err)
+	}
+
+	if addresses, ok := cert.CriticalOptions[CriticalOptionSourceAddress]; ok && !sourceAddressAllowed(addresses, address) {
+		return nil, NewErrUserCertificateInvalid(nil)
+	}
+
+	return &models.CertificateEvaluation{ForceCommand: cert.CriticalOptions[CriticalOptionForceCommand]}, nil
+}
+
+// AuthCertificate evaluates a user certificate, as EvaluateCertificate does, against the device whose UID is uid. It is
+// used by the device's agent to authenticate the connections reaching it straight, like the ones of a jump-host.
+func (s *service) AuthCertificate(ctx context.Context, uid models.UID, data []byte, username, address string) (*models.CertificateEvaluation, error) {
+	device, err := s.store.DeviceGet(ctx, uid)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	return s.EvaluateCertificate(ctx, data, *device, username, address)
+}
+
+// sourceAddressAllowed checks if address, with or without a port, is in addresses, a comma separated list of
+// addresses and CIDR blocks.
+func sourceAddressAllowed(addresses, address string) bool {
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		address = host
+	}
+
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	for _, allowed := range strings.Split(addresses, ",") {
+		if _, network, err := net.ParseCIDR(allowed); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+
+			continue
+		}
+
+		if allowedIP := net.ParseIP(allowed); allowedIP != nil && allowedIP.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
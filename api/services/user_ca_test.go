@@ -0,0 +1,325 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestCreateUserCA(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	caPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	caKey, err := ssh.NewPublicKey(caPublicKey)
+	assert.NoError(t, err)
+
+	data := ssh.MarshalAuthorizedKey(caKey)
+	fingerprint := ssh.FingerprintLegacyMD5(caKey)
+
+	type Expected struct {
+		ca  *models.UserCA
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		data          []byte
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the data is not a public key",
+			data:          []byte("invalid"),
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrUserCAInvalid(fmt.Errorf("ssh: no key found"))},
+		},
+		{
+			description: "fails when the authority is already trusted",
+			data:        data,
+			requiredMocks: func() {
+				storeMock.On("UserCAGet", ctx, "tenant", fingerprint).
+					Return(&models.UserCA{Fingerprint: fingerprint, TenantID: "tenant"}, nil).Once()
+			},
+			expected: Expected{nil, NewErrUserCADuplicated(fingerprint, nil)},
+		},
+		{
+			description: "fails when the store function to get the authority fails",
+			data:        data,
+			requiredMocks: func() {
+				storeMock.On("UserCAGet", ctx, "tenant", fingerprint).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, Err},
+		},
+		{
+			description: "succeeds to trust the authority",
+			data:        data,
+			requiredMocks: func() {
+				storeMock.On("UserCAGet", ctx, "tenant", fingerprint).Return(nil, store.ErrNoDocuments).Once()
+				clockMock.On("Now").Return(now).Once()
+				storeMock.On("UserCACreate", ctx, &models.UserCA{
+					Fingerprint: fingerprint,
+					TenantID:    "tenant",
+					Name:        "ca",
+					Data:        data,
+					CreatedAt:   now,
+				}).Return(nil).Once()
+				storeMock.On("AuditCreate", ctx, mock.MatchedBy(func(entry *models.AuditLog) bool {
+					return entry.Action == models.AuditUserCACreate && entry.Target.ID == fingerprint
+				})).Return(nil).Once()
+			},
+			expected: Expected{
+				&models.UserCA{Fingerprint: fingerprint, TenantID: "tenant", Name: "ca", Data: data, CreatedAt: now},
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			ca, err := s.CreateUserCA(ctx, "tenant", "ca", tc.data)
+			assert.Equal(t, tc.expected, Expected{ca, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestEvaluateCertificate(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	_, caPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	ca, err := ssh.NewSignerFromKey(caPrivateKey)
+	assert.NoError(t, err)
+
+	userPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	userKey, err := ssh.NewPublicKey(userPublicKey)
+	assert.NoError(t, err)
+
+	fingerprint := ssh.FingerprintLegacyMD5(ca.PublicKey())
+	device := models.Device{UID: "uid", TenantID: "tenant"}
+
+	certificate := func(principals []string, options map[string]string, validBefore time.Time) []byte {
+		cert := &ssh.Certificate{
+			Key:             userKey,
+			CertType:        ssh.UserCert,
+			KeyId:           "user",
+			ValidPrincipals: principals,
+			ValidAfter:      uint64(now.Add(-time.Hour).Unix()),
+			ValidBefore:     uint64(validBefore.Unix()),
+			Permissions:     ssh.Permissions{CriticalOptions: options},
+		}
+
+		assert.NoError(t, cert.SignCert(rand.Reader, ca))
+
+		return ssh.MarshalAuthorizedKey(cert)
+	}
+
+	type Expected struct {
+		evaluation *models.CertificateEvaluation
+		err        error
+	}
+
+	cases := []struct {
+		description   string
+		data          []byte
+		address       string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the key is not a certificate",
+			data:          ssh.MarshalAuthorizedKey(userKey),
+			address:       "192.168.1.10:40000",
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrUserCertificateInvalid(nil)},
+		},
+		{
+			description:   "fails when the certificate has no principals",
+			data:          certificate(nil, nil, now.Add(time.Hour)),
+			address:       "192.168.1.10:40000",
+			requiredMocks: func() {},
+			expected:      Expected{nil, NewErrUserCertificateInvalid(nil)},
+		},
+		{
+			description: "fails when the authority is not trusted by the namespace",
+			data:        certificate([]string{"root"}, nil, now.Add(time.Hour)),
+			address:     "192.168.1.10:40000",
+			requiredMocks: func() {
+				storeMock.On("UserCAGet", ctx, "tenant", fingerprint).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrUserCANotFound(fingerprint, Err)},
+		},
+		{
+			description: "fails when the username is not a principal",
+			data:        certificate([]string{"admin"}, nil, now.Add(time.Hour)),
+			address:     "192.168.1.10:40000",
+			requiredMocks: func() {
+				storeMock.On("UserCAGet", ctx, "tenant", fingerprint).Return(&models.UserCA{Fingerprint: fingerprint}, nil).Once()
+			},
+			expected: Expected{nil, NewErrUserCertificateInvalid(fmt.Errorf("ssh: principal %q not in the set of valid principals for given certificate: %q", "root", []string{"admin"}))},
+		},
+		{
+			description: "fails when the certificate has expired",
+			data:        certificate([]string{"root"}, nil, now.Add(-time.Minute)),
+			address:     "192.168.1.10:40000",
+			requiredMocks: func() {
+				storeMock.On("UserCAGet", ctx, "tenant", fingerprint).Return(&models.UserCA{Fingerprint: fingerprint}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{nil, NewErrUserCertificateInvalid(fmt.Errorf("ssh: cert has expired"))},
+		},
+		{
+			description: "fails when the certificate has an unsupported critical option",
+			data:        certificate([]string{"root"}, map[string]string{"verify-required": ""}, now.Add(time.Hour)),
+			address:     "192.168.1.10:40000",
+			requiredMocks: func() {
+				storeMock.On("UserCAGet", ctx, "tenant", fingerprint).Return(&models.UserCA{Fingerprint: fingerprint}, nil).Once()
+			},
+			expected: Expected{nil, NewErrUserCertificateInvalid(fmt.Errorf("ssh: unsupported critical option %q in certificate", "verify-required"))},
+		},
+		{
+			description: "fails when the address is not a source address",
+			data:        certificate([]string{"root"}, map[string]string{CriticalOptionSourceAddress: "10.0.0.0/8,172.16.0.1"}, now.Add(time.Hour)),
+			address:     "192.168.1.10:40000",
+			requiredMocks: func() {
+				storeMock.On("UserCAGet", ctx, "tenant", fingerprint).Return(&models.UserCA{Fingerprint: fingerprint}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{nil, NewErrUserCertificateInvalid(nil)},
+		},
+		{
+			description: "succeeds when the address is a source address",
+			data:        certificate([]string{"admin", "root"}, map[string]string{CriticalOptionSourceAddress: "10.0.0.0/8,192.168.1.10"}, now.Add(time.Hour)),
+			address:     "192.168.1.10:40000",
+			requiredMocks: func() {
+				storeMock.On("UserCAGet", ctx, "tenant", fingerprint).Return(&models.UserCA{Fingerprint: fingerprint}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{&models.CertificateEvaluation{}, nil},
+		},
+		{
+			description: "succeeds returning the forced command",
+			data:        certificate([]string{"root"}, map[string]string{CriticalOptionForceCommand: "uptime"}, now.Add(time.Hour)),
+			address:     "192.168.1.10:40000",
+			requiredMocks: func() {
+				storeMock.On("UserCAGet", ctx, "tenant", fingerprint).Return(&models.UserCA{Fingerprint: fingerprint}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{&models.CertificateEvaluation{ForceCommand: "uptime"}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			evaluation, err := s.EvaluateCertificate(ctx, tc.data, device, "root", tc.address)
+			assert.Equal(t, tc.expected, Expected{evaluation, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
+
+func TestAuthCertificate(t *testing.T) {
+	storeMock := &mocks.Store{}
+	s := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	Err := errors.New("error", "", 0)
+
+	_, caPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	ca, err := ssh.NewSignerFromKey(caPrivateKey)
+	assert.NoError(t, err)
+
+	cert := &ssh.Certificate{
+		Key:             ca.PublicKey(),
+		CertType:        ssh.UserCert,
+		ValidPrincipals: []string{"root"},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	assert.NoError(t, cert.SignCert(rand.Reader, ca))
+
+	data := ssh.MarshalAuthorizedKey(cert)
+	fingerprint := ssh.FingerprintLegacyMD5(ca.PublicKey())
+
+	type Expected struct {
+		evaluation *models.CertificateEvaluation
+		err        error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID("uid"), Err)},
+		},
+		{
+			description: "fails when the authority is not trusted by the device's namespace",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				storeMock.On("UserCAGet", ctx, "tenant", fingerprint).Return(nil, Err).Once()
+			},
+			expected: Expected{nil, NewErrUserCANotFound(fingerprint, Err)},
+		},
+		{
+			description: "succeeds when the certificate allows the user on the device",
+			requiredMocks: func() {
+				storeMock.On("DeviceGet", ctx, models.UID("uid")).Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				storeMock.On("UserCAGet", ctx, "tenant", fingerprint).Return(&models.UserCA{Fingerprint: fingerprint}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{&models.CertificateEvaluation{}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			evaluation, err := s.AuthCertificate(ctx, models.UID("uid"), data, "root", "192.168.1.10:40000")
+			assert.Equal(t, tc.expected, Expected{evaluation, err})
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,368 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/api/response"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// WebhookQueue is the queue of the webhook's tasks.
+	WebhookQueue = "webhooks"
+	// TaskWebhookDispatch is the task that creates an event's deliveries to the namespace's webhooks. Its payload is a
+	// JSON encoded webhook.Event.
+	TaskWebhookDispatch = "webhook:dispatch"
+	// TaskWebhookDeliver is the task that sends a delivery to its webhook. Its payload is the delivery's ID.
+	TaskWebhookDeliver = "webhook:deliver"
+	// WebhookMaxRetry is how many times a failed delivery is retried, with an exponential backoff, before it is marked
+	// as failed.
+	WebhookMaxRetry = 8
+	// webhookSecretSize is the number of random bytes used to generate a webhook's secret.
+	webhookSecretSize = 32
+)
+
+// webhookClient is the HTTP client used to send the deliveries.
+var webhookClient = newWebhookClient()
+
+// lookupWebhookHost resolves the host of a webhook's URL.
+var lookupWebhookHost = net.DefaultResolver.LookupIPAddr
+
+// newWebhookClient creates the HTTP client used to send the deliveries. As a webhook's host may resolve to another
+// address after the webhook is created, the address is checked again on each connection, after it is resolved, so
+// the forbidden ones cannot be reached. The environment's proxy is not used, since it would be the checked address.
+func newWebhookClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || forbiddenWebhookIP(ip) {
+				return ErrWebhookAddressForbidden
+			}
+
+			return nil
+		},
+	}).DialContext
+
+	return &http.Client{Timeout: 10 * time.Second, Transport: transport}
+}
+
+// forbiddenWebhookIP checks if a webhook cannot target ip, as the loopback, private and link-local addresses, which
+// would let a namespace reach the services in ShellHub's network.
+func forbiddenWebhookIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsUnspecified() || ip.IsMulticast()
+}
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, tenant string, req *request.WebhookCreate) (*response.WebhookCreate, error)
+	ListWebhooks(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error)
+	UpdateWebhook(ctx context.Context, tenant string, req *request.WebhookUpdate) (*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, tenant, id string) error
+	ListWebhookDeliveries(ctx context.Context, tenant, id string, pagination paginator.Query) ([]models.WebhookDelivery, int, error)
+	RedeliverWebhook(ctx context.Context, tenant, id, delivery string) (*models.WebhookDelivery, error)
+	DispatchWebhookEvent(ctx context.Context, event *webhook.Event) error
+	DeliverWebhook(ctx context.Context, id string, last bool) error
+}
+
+// CreateWebhook creates a new webhook to a namespace.
+//
+// The webhook's URL must use HTTPS, its host cannot resolve to a loopback, private or link-local address and its
+// events must be some of webhook.Events. CreateWebhook returns the created models.Webhook with its secret, what is
+// never returned again, and an error. When error is not nil, the response is nil.
+func (s *service) CreateWebhook(ctx context.Context, tenant string, req *request.WebhookCreate) (*response.WebhookCreate, error) {
+	if _, err := s.store.NamespaceGet(ctx, tenant); err != nil {
+		return nil, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	if err := validateWebhook(ctx, req.URL, req.Events); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, webhookSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	hook := &models.Webhook{
+		ID:        uuid.Generate(),
+		TenantID:  tenant,
+		URL:       req.URL,
+		Secret:    hex.EncodeToString(secret),
+		Events:    req.Events,
+		Active:    true,
+		CreatedAt: clock.Now(),
+	}
+
+	if err := s.store.WebhookCreate(ctx, hook); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditWebhookCreate, models.AuditTarget{Type: models.AuditTargetWebhook, ID: hook.ID}, nil, map[string]interface{}{"url": hook.URL, "events": hook.Events})
+
+	return &response.WebhookCreate{Webhook: *hook, Secret: hook.Secret}, nil
+}
+
+// ListWebhooks lists the webhooks of a namespace. The webhooks' secrets are never returned.
+func (s *service) ListWebhooks(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error) {
+	if _, err := s.store.NamespaceGet(ctx, tenant); err != nil {
+		return nil, 0, NewErrNamespaceNotFound(tenant, err)
+	}
+
+	return s.store.WebhookList(ctx, tenant, pagination)
+}
+
+// UpdateWebhook updates the URL, the events or the active state of a webhook.
+func (s *service) UpdateWebhook(ctx context.Context, tenant string, req *request.WebhookUpdate) (*models.Webhook, error) {
+	hook, err := s.store.WebhookGet(ctx, tenant, req.ID)
+	if err != nil {
+		return nil, NewErrWebhookNotFound(req.ID, err)
+	}
+
+	changes := &models.WebhookUpdate{URL: req.URL, Events: req.Events, Active: req.Active}
+	before := map[string]interface{}{"url": hook.URL, "events": hook.Events, "active": hook.Active}
+
+	if changes.URL != "" {
+		hook.URL = changes.URL
+	}
+
+	if changes.Events != nil {
+		hook.Events = changes.Events
+	}
+
+	if changes.Active != nil {
+		hook.Active = *changes.Active
+	}
+
+	if err := validateWebhook(ctx, hook.URL, hook.Events); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.WebhookUpdate(ctx, tenant, req.ID, changes); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, tenant, models.AuditWebhookUpdate, models.AuditTarget{Type: models.AuditTargetWebhook, ID: hook.ID}, before, map[string]interface{}{"url": hook.URL, "events": hook.Events, "active": hook.Active})
+
+	return hook, nil
+}
+
+// DeleteWebhook deletes a webhook, and its delivery log, from a namespace.
+func (s *service) DeleteWebhook(ctx context.Context, tenant, id string) error {
+	hook, err := s.store.WebhookGet(ctx, tenant, id)
+	if err != nil {
+		return NewErrWebhookNotFound(id, err)
+	}
+
+	if err := s.store.WebhookDelete(ctx, tenant, id); err != nil {
+		return err
+	}
+
+	s.audit(ctx, tenant, models.AuditWebhookDelete, models.AuditTarget{Type: models.AuditTargetWebhook, ID: hook.ID}, map[string]interface{}{"url": hook.URL, "events": hook.Events}, nil)
+
+	return nil
+}
+
+// ListWebhookDeliveries lists the delivery log of a webhook, the newest deliveries first.
+func (s *service) ListWebhookDeliveries(ctx context.Context, tenant, id string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	if _, err := s.store.WebhookGet(ctx, tenant, id); err != nil {
+		return nil, 0, NewErrWebhookNotFound(id, err)
+	}
+
+	return s.store.WebhookDeliveryList(ctx, tenant, id, pagination)
+}
+
+// RedeliverWebhook sends again the payload of a webhook's delivery. It creates a new delivery, so the log keeps the
+// previous attempts.
+func (s *service) RedeliverWebhook(ctx context.Context, tenant, id, delivery string) (*models.WebhookDelivery, error) {
+	previous, err := s.store.WebhookDeliveryGet(ctx, delivery)
+	if err != nil || previous.TenantID != tenant || previous.WebhookID != id {
+		return nil, NewErrWebhookDeliveryNotFound(delivery, err)
+	}
+
+	return s.createWebhookDelivery(ctx, tenant, id, previous.Event, previous.Payload)
+}
+
+// DispatchWebhookEvent creates the deliveries of an event to the namespace's active webhooks subscribed to it and
+// enqueues them to be sent.
+func (s *service) DispatchWebhookEvent(ctx context.Context, event *webhook.Event) error {
+	hooks, err := s.store.WebhookListByEvent(ctx, event.TenantID, event.Event)
+	if err != nil {
+		return err
+	}
+
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range hooks {
+		if _, err := s.createWebhookDelivery(ctx, event.TenantID, hook.ID, event.Event, string(payload)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeliverWebhook sends a delivery to its webhook, recording the attempt in the delivery log. last indicates that it
+// is the last attempt, so a failure marks the delivery as failed instead of pending.
+//
+// DeliverWebhook returns an error only when the attempt failed and may be retried. When the delivery, or its
+// webhook, does not exist anymore, there is nothing to send and it returns nil.
+func (s *service) DeliverWebhook(ctx context.Context, id string, last bool) error {
+	delivery, err := s.store.WebhookDeliveryGet(ctx, id)
+	if err != nil {
+		logrus.WithError(err).WithField("id", id).Warn("Failed to get the webhook's delivery")
+
+		return nil
+	}
+
+	hook, err := s.store.WebhookGet(ctx, delivery.TenantID, delivery.WebhookID)
+	if err != nil {
+		logrus.WithError(err).WithField("id", delivery.WebhookID).Warn("Failed to get the delivery's webhook")
+
+		return nil
+	}
+
+	code, sendErr := webhook.Send(ctx, webhookClient, hook.URL, delivery.ID, delivery.Event, hook.Secret, []byte(delivery.Payload))
+
+	now := clock.Now()
+	update := &models.WebhookDeliveryUpdate{
+		Status:        models.WebhookDeliverySucceeded,
+		Attempts:      delivery.Attempts + 1,
+		StatusCode:    code,
+		LastAttemptAt: &now,
+	}
+
+	if sendErr != nil {
+		update.Status = models.WebhookDeliveryPending
+		if last {
+			update.Status = models.WebhookDeliveryFailed
+		}
+
+		update.Error = sendErr.Error()
+	}
+
+	if err := s.store.WebhookDeliveryUpdate(ctx, delivery.ID, update); err != nil {
+		logrus.WithError(err).WithField("id", delivery.ID).Error("Failed to record the webhook's delivery attempt")
+	}
+
+	return sendErr
+}
+
+// createWebhookDelivery records a pending delivery and enqueues it to be sent.
+func (s *service) createWebhookDelivery(ctx context.Context, tenant, id, event, payload string) (*models.WebhookDelivery, error) {
+	delivery := &models.WebhookDelivery{
+		ID:        uuid.Generate(),
+		TenantID:  tenant,
+		WebhookID: id,
+		Event:     event,
+		Payload:   payload,
+		Status:    models.WebhookDeliveryPending,
+		CreatedAt: clock.Now(),
+	}
+
+	if err := s.store.WebhookDeliveryCreate(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.tasks.EnqueueContext(ctx, asynq.NewTask(TaskWebhookDeliver, []byte(delivery.ID)), asynq.Queue(WebhookQueue), asynq.MaxRetry(WebhookMaxRetry)); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// emit enqueues an event to be dispatched to the namespace's webhooks.
+//
+// When the service has no Enqueuer, there are no workers to deliver the event and it is discarded. As the event's
+// action was already performed when emit is called, a failure to enqueue it is logged instead of returned.
+func (s *service) emit(ctx context.Context, tenant, event string, data interface{}) {
+	if s.tasks == nil {
+		return
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		logrus.WithError(err).WithField("event", event).Error("Failed to encode the webhook's event")
+
+		return
+	}
+
+	payload, err := json.Marshal(&webhook.Event{Event: event, TenantID: tenant, CreatedAt: clock.Now(), Data: raw})
+	if err != nil {
+		logrus.WithError(err).WithField("event", event).Error("Failed to encode the webhook's event")
+
+		return
+	}
+
+	if _, err := s.tasks.EnqueueContext(ctx, asynq.NewTask(TaskWebhookDispatch, payload), asynq.Queue(WebhookQueue)); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"tenant": tenant,
+			"event":  event,
+		}).Error("Failed to enqueue the webhook's event")
+	}
+}
+
+// emitting checks if the events are emitted, so the callers can skip the work to build them when they are not.
+func (s *service) emitting() bool {
+	return s.tasks != nil
+}
+
+// validateWebhook checks if the webhook's URL uses HTTPS, if its host resolves only to addresses a webhook can target
+// and if its events are valid ones.
+func validateWebhook(ctx context.Context, rawURL string, events []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return NewErrWebhookInvalid(map[string]interface{}{"url": rawURL}, err)
+	}
+
+	addrs, err := lookupWebhookHost(ctx, u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return NewErrWebhookInvalid(map[string]interface{}{"url": rawURL}, err)
+	}
+
+	for _, addr := range addrs {
+		if forbiddenWebhookIP(addr.IP) {
+			return NewErrWebhookInvalid(map[string]interface{}{"url": rawURL}, ErrWebhookAddressForbidden)
+		}
+	}
+
+	if len(events) == 0 {
+		return NewErrWebhookInvalid(map[string]interface{}{"events": events}, nil)
+	}
+
+	for _, event := range events {
+		if !webhook.ValidEvent(event) {
+			return NewErrWebhookInvalid(map[string]interface{}{"events": event}, nil)
+		}
+	}
+
+	return nil
+}
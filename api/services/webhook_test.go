@@ -0,0 +1,337 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	servicemocks "github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateWebhook(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	namespace := &models.Namespace{Name: "group1", Owner: "hash1", TenantID: "a736a52b-5777-4f92-b0b8-e359bf484713"}
+
+	Err := errors.New("error")
+
+	lookup := lookupWebhookHost
+	defer func() { lookupWebhookHost = lookup }()
+
+	lookupWebhookHost = func(_ context.Context, host string) ([]net.IPAddr, error) {
+		addrs := map[string]string{
+			"example.com":          "93.184.216.34",
+			"internal.example.com": "10.0.0.1",
+			"127.0.0.1":            "127.0.0.1",
+			"169.254.169.254":      "169.254.169.254",
+		}
+
+		addr, ok := addrs[host]
+		if !ok {
+			return nil, Err
+		}
+
+		return []net.IPAddr{{IP: net.ParseIP(addr)}}, nil
+	}
+
+	cases := []struct {
+		name          string
+		req           *request.WebhookCreate
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "CreateWebhook fails when the namespace is not found",
+			req:  &request.WebhookCreate{URL: "https://example.com", Events: []string{webhook.EventDeviceCreated}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(nil, Err).Once()
+			},
+			expected: NewErrNamespaceNotFound(namespace.TenantID, Err),
+		},
+		{
+			name: "CreateWebhook fails when the URL does not use HTTPS",
+			req:  &request.WebhookCreate{URL: "http://example.com", Events: []string{webhook.EventDeviceCreated}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+			},
+			expected: NewErrWebhookInvalid(map[string]interface{}{"url": "http://example.com"}, nil),
+		},
+		{
+			name: "CreateWebhook fails when the host cannot be resolved",
+			req:  &request.WebhookCreate{URL: "https://unknown.example.com", Events: []string{webhook.EventDeviceCreated}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+			},
+			expected: NewErrWebhookInvalid(map[string]interface{}{"url": "https://unknown.example.com"}, Err),
+		},
+		{
+			name: "CreateWebhook fails when the host resolves to a private address",
+			req:  &request.WebhookCreate{URL: "https://internal.example.com", Events: []string{webhook.EventDeviceCreated}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+			},
+			expected: NewErrWebhookInvalid(map[string]interface{}{"url": "https://internal.example.com"}, ErrWebhookAddressForbidden),
+		},
+		{
+			name: "CreateWebhook fails when the host is a loopback address",
+			req:  &request.WebhookCreate{URL: "https://127.0.0.1:8080", Events: []string{webhook.EventDeviceCreated}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+			},
+			expected: NewErrWebhookInvalid(map[string]interface{}{"url": "https://127.0.0.1:8080"}, ErrWebhookAddressForbidden),
+		},
+		{
+			name: "CreateWebhook fails when the host is a link-local address",
+			req:  &request.WebhookCreate{URL: "https://169.254.169.254", Events: []string{webhook.EventDeviceCreated}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+			},
+			expected: NewErrWebhookInvalid(map[string]interface{}{"url": "https://169.254.169.254"}, ErrWebhookAddressForbidden),
+		},
+		{
+			name: "CreateWebhook fails when an event is invalid",
+			req:  &request.WebhookCreate{URL: "https://example.com", Events: []string{webhook.EventDeviceCreated, "device.unknown"}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+			},
+			expected: NewErrWebhookInvalid(map[string]interface{}{"events": "device.unknown"}, nil),
+		},
+		{
+			name: "CreateWebhook succeeds",
+			req:  &request.WebhookCreate{URL: "https://example.com", Events: []string{webhook.EventDeviceCreated}},
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, namespace.TenantID).Return(namespace, nil).Once()
+				uuidMock.On("Generate").Return("id").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookCreate", ctx, testifymock.AnythingOfType("*models.Webhook")).Return(nil).Once()
+				mock.On("AuditCreate", ctx, testifymock.AnythingOfType("*models.AuditLog")).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			res, err := s.CreateWebhook(ctx, namespace.TenantID, tc.req)
+			assert.Equal(t, tc.expected, err)
+			if err == nil {
+				assert.NotEmpty(t, res.Secret)
+				assert.True(t, res.Active)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestRedeliverWebhook(t *testing.T) {
+	mock := &mocks.Store{}
+	tasks := &servicemocks.Enqueuer{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil, WithEnqueuer(tasks))
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	delivery := &models.WebhookDelivery{ID: "delivery", TenantID: "tenant", WebhookID: "id", Event: webhook.EventDeviceCreated, Payload: "{}", Status: models.WebhookDeliveryFailed, Attempts: 9}
+
+	cases := []struct {
+		name          string
+		tenant        string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name:   "RedeliverWebhook fails when the delivery belongs to another namespace",
+			tenant: "other",
+			requiredMocks: func() {
+				mock.On("WebhookDeliveryGet", ctx, "delivery").Return(delivery, nil).Once()
+			},
+			expected: NewErrWebhookDeliveryNotFound("delivery", nil),
+		},
+		{
+			name:   "RedeliverWebhook succeeds",
+			tenant: "tenant",
+			requiredMocks: func() {
+				mock.On("WebhookDeliveryGet", ctx, "delivery").Return(delivery, nil).Once()
+				uuidMock.On("Generate").Return("redelivery").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookDeliveryCreate", ctx, testifymock.MatchedBy(func(d *models.WebhookDelivery) bool {
+					return d.ID != delivery.ID && d.Payload == delivery.Payload && d.Status == models.WebhookDeliveryPending && d.Attempts == 0
+				})).Return(nil).Once()
+				tasks.On("EnqueueContext", ctx, testifymock.AnythingOfType("*asynq.Task"), asynq.Queue(WebhookQueue), asynq.MaxRetry(WebhookMaxRetry)).Return(&asynq.TaskInfo{}, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			_, err := s.RedeliverWebhook(ctx, tc.tenant, "id", "delivery")
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+	tasks.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestDispatchWebhookEvent(t *testing.T) {
+	mock := &mocks.Store{}
+	tasks := &servicemocks.Enqueuer{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil, WithEnqueuer(tasks))
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	event := &webhook.Event{Event: webhook.EventSessionStarted, TenantID: "tenant", CreatedAt: now, Data: []byte(`{"uid":"uid"}`)}
+
+	mock.On("WebhookListByEvent", ctx, "tenant", webhook.EventSessionStarted).Return([]models.Webhook{{ID: "id1"}, {ID: "id2"}}, nil).Once()
+	uuidMock.On("Generate").Return("delivery1").Once()
+	uuidMock.On("Generate").Return("delivery2").Once()
+	clockMock.On("Now").Return(now).Twice()
+	mock.On("WebhookDeliveryCreate", ctx, testifymock.MatchedBy(func(d *models.WebhookDelivery) bool {
+		return d.WebhookID == "id1" && d.Event == webhook.EventSessionStarted
+	})).Return(nil).Once()
+	mock.On("WebhookDeliveryCreate", ctx, testifymock.MatchedBy(func(d *models.WebhookDelivery) bool {
+		return d.WebhookID == "id2" && d.Event == webhook.EventSessionStarted
+	})).Return(nil).Once()
+	tasks.On("EnqueueContext", ctx, testifymock.AnythingOfType("*asynq.Task"), asynq.Queue(WebhookQueue), asynq.MaxRetry(WebhookMaxRetry)).Return(&asynq.TaskInfo{}, nil).Twice()
+
+	assert.NoError(t, s.DispatchWebhookEvent(ctx, event))
+
+	mock.AssertExpectations(t)
+	tasks.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestDeliverWebhook(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	ctx := context.TODO()
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get(webhook.WebhookSignatureHeader) != webhook.Sign("secret", body) {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	// The test server listens on a loopback address, which the webhooks cannot target.
+	client := webhookClient
+	defer func() { webhookClient = client }()
+
+	webhookClient = server.Client()
+
+	hook := &models.Webhook{ID: "id", TenantID: "tenant", URL: server.URL, Secret: "secret", Active: true}
+
+	cases := []struct {
+		name          string
+		status        int
+		last          bool
+		requiredMocks func()
+		expected      bool
+	}{
+		{
+			name:   "DeliverWebhook succeeds",
+			status: http.StatusOK,
+			requiredMocks: func() {
+				mock.On("WebhookDeliveryGet", ctx, "delivery").Return(&models.WebhookDelivery{ID: "delivery", TenantID: "tenant", WebhookID: "id", Payload: "{}"}, nil).Once()
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(hook, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookDeliveryUpdate", ctx, "delivery", &models.WebhookDeliveryUpdate{Status: models.WebhookDeliverySucceeded, Attempts: 1, StatusCode: http.StatusOK, LastAttemptAt: &now}).Return(nil).Once()
+			},
+			expected: false,
+		},
+		{
+			name:   "DeliverWebhook keeps the delivery pending when a retry fails",
+			status: http.StatusInternalServerError,
+			requiredMocks: func() {
+				mock.On("WebhookDeliveryGet", ctx, "delivery").Return(&models.WebhookDelivery{ID: "delivery", TenantID: "tenant", WebhookID: "id", Payload: "{}", Attempts: 1}, nil).Once()
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(hook, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookDeliveryUpdate", ctx, "delivery", testifymock.MatchedBy(func(u *models.WebhookDeliveryUpdate) bool {
+					return u.Status == models.WebhookDeliveryPending && u.Attempts == 2 && u.StatusCode == http.StatusInternalServerError && u.Error != ""
+				})).Return(nil).Once()
+			},
+			expected: true,
+		},
+		{
+			name:   "DeliverWebhook marks the delivery as failed when the last attempt fails",
+			status: http.StatusInternalServerError,
+			last:   true,
+			requiredMocks: func() {
+				mock.On("WebhookDeliveryGet", ctx, "delivery").Return(&models.WebhookDelivery{ID: "delivery", TenantID: "tenant", WebhookID: "id", Payload: "{}", Attempts: 8}, nil).Once()
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(hook, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("WebhookDeliveryUpdate", ctx, "delivery", testifymock.MatchedBy(func(u *models.WebhookDeliveryUpdate) bool {
+					return u.Status == models.WebhookDeliveryFailed && u.Attempts == 9
+				})).Return(nil).Once()
+			},
+			expected: true,
+		},
+		{
+			name: "DeliverWebhook does nothing when the webhook was deleted",
+			requiredMocks: func() {
+				mock.On("WebhookDeliveryGet", ctx, "delivery").Return(&models.WebhookDelivery{ID: "delivery", TenantID: "tenant", WebhookID: "id"}, nil).Once()
+				mock.On("WebhookGet", ctx, "tenant", "id").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+			status = tc.status
+			err := s.DeliverWebhook(ctx, "delivery", tc.last)
+			assert.Equal(t, tc.expected, err != nil)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestWebhookClientForbiddenAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	_, err := newWebhookClient().Get(server.URL) //nolint:noctx
+	assert.ErrorIs(t, err, ErrWebhookAddressForbidden)
+}
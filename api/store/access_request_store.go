@@ -0,0 +1,21 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type AccessRequestStore interface {
+	// AccessRequestList lists the namespace's access requests with status, or all of them when status is empty.
+	AccessRequestList(ctx context.Context, tenant, status string, pagination paginator.Query) ([]models.AccessRequest, int, error)
+	AccessRequestGet(ctx context.Context, id string) (*models.AccessRequest, error)
+	// AccessRequestGetGrant returns the approved request whose grant still allows username, from ipAddress, to access
+	// the device, returning ErrNoDocuments when there is none.
+	AccessRequestGetGrant(ctx context.Context, deviceUID models.UID, username, ipAddress string) (*models.AccessRequest, error)
+	AccessRequestCreate(ctx context.Context, request *models.AccessRequest) error
+	// AccessRequestDecide decides a request pending for less than models.AccessRequestTimeout, returning
+	// ErrNoDocuments when there is none.
+	AccessRequestDecide(ctx context.Context, id string, decision *models.AccessRequestDecision) error
+}
@@ -0,0 +1,19 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type APIKeyStore interface {
+	APIKeyCreate(ctx context.Context, key *models.APIKey) error
+	APIKeyList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error)
+	APIKeyGet(ctx context.Context, tenant, id string) (*models.APIKey, error)
+	APIKeyGetByName(ctx context.Context, tenant, name string) (*models.APIKey, error)
+	APIKeyGetByDigest(ctx context.Context, digest string) (*models.APIKey, error)
+	APIKeyUpdate(ctx context.Context, tenant, id string, key *models.APIKeyUpdate) error
+	APIKeySetLastUsed(ctx context.Context, id string) error
+	APIKeyDelete(ctx context.Context, tenant, id string) error
+}
@@ -0,0 +1,13 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type AuditStore interface {
+	AuditCreate(ctx context.Context, audit *models.AuditLog) error
+	AuditList(ctx context.Context, tenant string, pagination paginator.Query, filter models.AuditFilter) ([]models.AuditLog, int, error)
+}
@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type AuthBanStore interface {
+	AuthBanList(ctx context.Context, pagination paginator.Query) ([]models.AuthBan, int, error)
+	// AuthBanGetActive returns the unexpired ban of any of the kind and value pairs of keys, returning ErrNoDocuments
+	// when none of them is banned.
+	AuthBanGetActive(ctx context.Context, keys map[string]string) (*models.AuthBan, error)
+	// AuthBanCreate bans a kind and value pair, replacing its previous ban.
+	AuthBanCreate(ctx context.Context, ban *models.AuthBan) error
+	AuthBanDelete(ctx context.Context, id string) (*models.AuthBan, error)
+}
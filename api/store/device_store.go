@@ -12,6 +12,12 @@ type DeviceStore interface {
 	DeviceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, status string, sort string, order string, removed bool) ([]models.Device, int, error)
 	DeviceGet(ctx context.Context, uid models.UID) (*models.Device, error)
 	DeviceUpdate(ctx context.Context, uid models.UID, name *string, publicURL *bool) error
+	DeviceUpdateAttributes(ctx context.Context, uid models.UID, attributes map[string]string) error
+	// DeviceSetHostKeyFingerprint pins the fingerprint of the device's SSH host key, or unpins it when it is empty.
+	DeviceSetHostKeyFingerprint(ctx context.Context, uid models.UID, fingerprint string) error
+	// DeviceSetHealth sets the device's latest health snapshot, appending it to the device's history.
+	DeviceSetHealth(ctx context.Context, uid models.UID, health *models.DeviceHealth) error
+	DeviceAttributeKeys(ctx context.Context, tenant string) ([]string, int, error)
 	DeviceDelete(ctx context.Context, uid models.UID) error
 	DeviceCreate(ctx context.Context, d models.Device, hostname string) error
 	DeviceRename(ctx context.Context, uid models.UID, hostname string) error
@@ -20,6 +20,286 @@ type Store struct {
 	mock.Mock
 }
 
+// APIKeyCreate provides a mock function with given fields: ctx, key
+func (_m *Store) APIKeyCreate(ctx context.Context, key *models.APIKey) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.APIKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APIKeyDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) APIKeyDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APIKeyGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) APIKeyGet(ctx context.Context, tenant string, id string) (*models.APIKey, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.APIKey, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.APIKey); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyGetByDigest provides a mock function with given fields: ctx, digest
+func (_m *Store) APIKeyGetByDigest(ctx context.Context, digest string) (*models.APIKey, error) {
+	ret := _m.Called(ctx, digest)
+
+	var r0 *models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.APIKey, error)); ok {
+		return rf(ctx, digest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.APIKey); ok {
+		r0 = rf(ctx, digest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, digest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyGetByName provides a mock function with given fields: ctx, tenant, name
+func (_m *Store) APIKeyGetByName(ctx context.Context, tenant string, name string) (*models.APIKey, error) {
+	ret := _m.Called(ctx, tenant, name)
+
+	var r0 *models.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.APIKey, error)); ok {
+		return rf(ctx, tenant, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.APIKey); ok {
+		r0 = rf(ctx, tenant, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// APIKeyList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) APIKeyList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.APIKey
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.APIKey, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.APIKey); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// APIKeySetLastUsed provides a mock function with given fields: ctx, id
+func (_m *Store) APIKeySetLastUsed(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// APIKeyUpdate provides a mock function with given fields: ctx, tenant, id, key
+func (_m *Store) APIKeyUpdate(ctx context.Context, tenant string, id string, key *models.APIKeyUpdate) error {
+	ret := _m.Called(ctx, tenant, id, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.APIKeyUpdate) error); ok {
+		r0 = rf(ctx, tenant, id, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccessRequestCreate provides a mock function with given fields: ctx, request
+func (_m *Store) AccessRequestCreate(ctx context.Context, request *models.AccessRequest) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AccessRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccessRequestDecide provides a mock function with given fields: ctx, id, decision
+func (_m *Store) AccessRequestDecide(ctx context.Context, id string, decision *models.AccessRequestDecision) error {
+	ret := _m.Called(ctx, id, decision)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.AccessRequestDecision) error); ok {
+		r0 = rf(ctx, id, decision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AccessRequestGet provides a mock function with given fields: ctx, id
+func (_m *Store) AccessRequestGet(ctx context.Context, id string) (*models.AccessRequest, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.AccessRequest, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.AccessRequest); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccessRequestGetGrant provides a mock function with given fields: ctx, deviceUID, username, ipAddress
+func (_m *Store) AccessRequestGetGrant(ctx context.Context, deviceUID models.UID, username string, ipAddress string) (*models.AccessRequest, error) {
+	ret := _m.Called(ctx, deviceUID, username, ipAddress)
+
+	var r0 *models.AccessRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string) (*models.AccessRequest, error)); ok {
+		return rf(ctx, deviceUID, username, ipAddress)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string) *models.AccessRequest); ok {
+		r0 = rf(ctx, deviceUID, username, ipAddress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, string, string) error); ok {
+		r1 = rf(ctx, deviceUID, username, ipAddress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AccessRequestList provides a mock function with given fields: ctx, tenant, status, pagination
+func (_m *Store) AccessRequestList(ctx context.Context, tenant string, status string, pagination paginator.Query) ([]models.AccessRequest, int, error) {
+	ret := _m.Called(ctx, tenant, status, pagination)
+
+	var r0 []models.AccessRequest
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) ([]models.AccessRequest, int, error)); ok {
+		return rf(ctx, tenant, status, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) []models.AccessRequest); ok {
+		r0 = rf(ctx, tenant, status, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccessRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, status, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, status, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // AnnouncementCreate provides a mock function with given fields: ctx, announcement
 func (_m *Store) AnnouncementCreate(ctx context.Context, announcement *models.Announcement) error {
 	ret := _m.Called(ctx, announcement)
@@ -121,6 +401,185 @@ func (_m *Store) AnnouncementUpdate(ctx context.Context, announcement *models.An
 	return r0
 }
 
+// AuditCreate provides a mock function with given fields: ctx, audit
+func (_m *Store) AuditCreate(ctx context.Context, audit *models.AuditLog) error {
+	ret := _m.Called(ctx, audit)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuditLog) error); ok {
+		r0 = rf(ctx, audit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuditList provides a mock function with given fields: ctx, tenant, pagination, filter
+func (_m *Store) AuditList(ctx context.Context, tenant string, pagination paginator.Query, filter models.AuditFilter) ([]models.AuditLog, int, error) {
+	ret := _m.Called(ctx, tenant, pagination, filter)
+
+	var r0 []models.AuditLog
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query, models.AuditFilter) ([]models.AuditLog, int, error)); ok {
+		return rf(ctx, tenant, pagination, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query, models.AuditFilter) []models.AuditLog); ok {
+		r0 = rf(ctx, tenant, pagination, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query, models.AuditFilter) int); ok {
+		r1 = rf(ctx, tenant, pagination, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query, models.AuditFilter) error); ok {
+		r2 = rf(ctx, tenant, pagination, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// AuthBanCreate provides a mock function with given fields: ctx, ban
+func (_m *Store) AuthBanCreate(ctx context.Context, ban *models.AuthBan) error {
+	ret := _m.Called(ctx, ban)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.AuthBan) error); ok {
+		r0 = rf(ctx, ban)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AuthBanDelete provides a mock function with given fields: ctx, id
+func (_m *Store) AuthBanDelete(ctx context.Context, id string) (*models.AuthBan, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.AuthBan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.AuthBan, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.AuthBan); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthBan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthBanGetActive provides a mock function with given fields: ctx, keys
+func (_m *Store) AuthBanGetActive(ctx context.Context, keys map[string]string) (*models.AuthBan, error) {
+	ret := _m.Called(ctx, keys)
+
+	var r0 *models.AuthBan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]string) (*models.AuthBan, error)); ok {
+		return rf(ctx, keys)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]string) *models.AuthBan); ok {
+		r0 = rf(ctx, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthBan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, map[string]string) error); ok {
+		r1 = rf(ctx, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthBanList provides a mock function with given fields: ctx, pagination
+func (_m *Store) AuthBanList(ctx context.Context, pagination paginator.Query) ([]models.AuthBan, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	var r0 []models.AuthBan
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.AuthBan, int, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.AuthBan); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AuthBan)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceAttributeKeys provides a mock function with given fields: ctx, tenant
+func (_m *Store) DeviceAttributeKeys(ctx context.Context, tenant string) ([]string, int, error) {
+	ret := _m.Called(ctx, tenant)
+
+	var r0 []string
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, int, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, tenant)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // DeviceChooser provides a mock function with given fields: ctx, tenantID, chosen
 func (_m *Store) DeviceChooser(ctx context.Context, tenantID string, chosen []string) error {
 	ret := _m.Called(ctx, tenantID, chosen)
@@ -472,6 +931,34 @@ func (_m *Store) DeviceRename(ctx context.Context, uid models.UID, hostname stri
 	return r0
 }
 
+// DeviceSetHealth provides a mock function with given fields: ctx, uid, health
+func (_m *Store) DeviceSetHealth(ctx context.Context, uid models.UID, health *models.DeviceHealth) error {
+	ret := _m.Called(ctx, uid, health)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.DeviceHealth) error); ok {
+		r0 = rf(ctx, uid, health)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetHostKeyFingerprint provides a mock function with given fields: ctx, uid, fingerprint
+func (_m *Store) DeviceSetHostKeyFingerprint(ctx context.Context, uid models.UID, fingerprint string) error {
+	ret := _m.Called(ctx, uid, fingerprint)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, fingerprint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceSetOnline provides a mock function with given fields: ctx, uid, online
 func (_m *Store) DeviceSetOnline(ctx context.Context, uid models.UID, online bool) error {
 	ret := _m.Called(ctx, uid, online)
@@ -514,6 +1001,20 @@ func (_m *Store) DeviceUpdate(ctx context.Context, uid models.UID, name *string,
 	return r0
 }
 
+// DeviceUpdateAttributes provides a mock function with given fields: ctx, uid, attributes
+func (_m *Store) DeviceUpdateAttributes(ctx context.Context, uid models.UID, attributes map[string]string) error {
+	ret := _m.Called(ctx, uid, attributes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, map[string]string) error); ok {
+		r0 = rf(ctx, uid, attributes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceUpdateLastSeen provides a mock function with given fields: ctx, uid, ts
 func (_m *Store) DeviceUpdateLastSeen(ctx context.Context, uid models.UID, ts time.Time) error {
 	ret := _m.Called(ctx, uid, ts)
@@ -1119,6 +1620,20 @@ func (_m *Store) NamespaceRename(ctx context.Context, tenantID string, name stri
 	return r0, r1
 }
 
+// NamespaceSetMFARequired provides a mock function with given fields: ctx, required, tenantID
+func (_m *Store) NamespaceSetMFARequired(ctx context.Context, required bool, tenantID string) error {
+	ret := _m.Called(ctx, required, tenantID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, bool, string) error); ok {
+		r0 = rf(ctx, required, tenantID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NamespaceSetSessionRecord provides a mock function with given fields: ctx, sessionRecord, tenantID
 func (_m *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error {
 	ret := _m.Called(ctx, sessionRecord, tenantID)
@@ -1147,6 +1662,20 @@ func (_m *Store) NamespaceUpdate(ctx context.Context, tenantID string, namespace
 	return r0
 }
 
+// NamespaceUpdateSettings provides a mock function with given fields: ctx, tenantID, settings
+func (_m *Store) NamespaceUpdateSettings(ctx context.Context, tenantID string, settings *models.NamespaceSettingsUpdate) error {
+	ret := _m.Called(ctx, tenantID, settings)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.NamespaceSettingsUpdate) error); ok {
+		r0 = rf(ctx, tenantID, settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PrivateKeyCreate provides a mock function with given fields: ctx, key
 func (_m *Store) PrivateKeyCreate(ctx context.Context, key *models.PrivateKey) error {
 	ret := _m.Called(ctx, key)
@@ -1335,6 +1864,30 @@ func (_m *Store) PublicKeyList(ctx context.Context, pagination paginator.Query)
 	return r0, r1, r2
 }
 
+// PublicKeyRegistered provides a mock function with given fields: ctx, fingerprint
+func (_m *Store) PublicKeyRegistered(ctx context.Context, fingerprint string) (bool, error) {
+	ret := _m.Called(ctx, fingerprint)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, fingerprint)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PublicKeyRemoveTag provides a mock function with given fields: ctx, tenant, fingerprint, tag
 func (_m *Store) PublicKeyRemoveTag(ctx context.Context, tenant string, fingerprint string, tag string) error {
 	ret := _m.Called(ctx, tenant, fingerprint, tag)
@@ -1554,22 +2107,64 @@ func (_m *Store) SessionList(ctx context.Context, pagination paginator.Query) ([
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
-		r2 = rf(ctx, pagination)
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SessionSetActivity provides a mock function with given fields: ctx, uid, activity
+func (_m *Store) SessionSetActivity(ctx context.Context, uid models.UID, activity *models.SessionActivity) error {
+	ret := _m.Called(ctx, uid, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.SessionActivity) error); ok {
+		r0 = rf(ctx, uid, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionSetAuthenticated provides a mock function with given fields: ctx, uid, authenticated
+func (_m *Store) SessionSetAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error {
+	ret := _m.Called(ctx, uid, authenticated)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, bool) error); ok {
+		r0 = rf(ctx, uid, authenticated)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SessionSetClosureReason provides a mock function with given fields: ctx, uid, reason
+func (_m *Store) SessionSetClosureReason(ctx context.Context, uid models.UID, reason string) error {
+	ret := _m.Called(ctx, uid, reason)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, reason)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
-// SessionSetAuthenticated provides a mock function with given fields: ctx, uid, authenticated
-func (_m *Store) SessionSetAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error {
-	ret := _m.Called(ctx, uid, authenticated)
+// SessionSetFailureReason provides a mock function with given fields: ctx, uid, reason
+func (_m *Store) SessionSetFailureReason(ctx context.Context, uid models.UID, reason string) error {
+	ret := _m.Called(ctx, uid, reason)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID, bool) error); ok {
-		r0 = rf(ctx, uid, authenticated)
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, reason)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1680,6 +2275,117 @@ func (_m *Store) TagsGet(ctx context.Context, tenant string) ([]string, int, err
 	return r0, r1, r2
 }
 
+// UserCACreate provides a mock function with given fields: ctx, ca
+func (_m *Store) UserCACreate(ctx context.Context, ca *models.UserCA) error {
+	ret := _m.Called(ctx, ca)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UserCA) error); ok {
+		r0 = rf(ctx, ca)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserCADelete provides a mock function with given fields: ctx, tenant, fingerprint
+func (_m *Store) UserCADelete(ctx context.Context, tenant string, fingerprint string) error {
+	ret := _m.Called(ctx, tenant, fingerprint)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, fingerprint)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserCAGet provides a mock function with given fields: ctx, tenant, fingerprint
+func (_m *Store) UserCAGet(ctx context.Context, tenant string, fingerprint string) (*models.UserCA, error) {
+	ret := _m.Called(ctx, tenant, fingerprint)
+
+	var r0 *models.UserCA
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.UserCA, error)); ok {
+		return rf(ctx, tenant, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.UserCA); ok {
+		r0 = rf(ctx, tenant, fingerprint)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserCA)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserCAList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) UserCAList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.UserCA, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.UserCA
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.UserCA, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.UserCA); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UserCA)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UserCARegistered provides a mock function with given fields: ctx, fingerprint
+func (_m *Store) UserCARegistered(ctx context.Context, fingerprint string) (bool, error) {
+	ret := _m.Called(ctx, fingerprint)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, fingerprint)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, fingerprint)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UserCreate provides a mock function with given fields: ctx, user
 func (_m *Store) UserCreate(ctx context.Context, user *models.User) error {
 	ret := _m.Called(ctx, user)
@@ -1906,6 +2612,32 @@ func (_m *Store) UserList(ctx context.Context, pagination paginator.Query, filte
 	return r0, r1, r2
 }
 
+// UserRecordMFAFailure provides a mock function with given fields: ctx, id, at
+func (_m *Store) UserRecordMFAFailure(ctx context.Context, id string, at time.Time) (*models.UserMFA, error) {
+	ret := _m.Called(ctx, id, at)
+
+	var r0 *models.UserMFA
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (*models.UserMFA, error)); ok {
+		return rf(ctx, id, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) *models.UserMFA); ok {
+		r0 = rf(ctx, id, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserMFA)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, id, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UserUpdateAccountStatus provides a mock function with given fields: ctx, id
 func (_m *Store) UserUpdateAccountStatus(ctx context.Context, id string) error {
 	ret := _m.Called(ctx, id)
@@ -1948,6 +2680,20 @@ func (_m *Store) UserUpdateFromAdmin(ctx context.Context, name string, username
 	return r0
 }
 
+// UserUpdateMFA provides a mock function with given fields: ctx, id, mfa
+func (_m *Store) UserUpdateMFA(ctx context.Context, id string, mfa models.UserMFA) error {
+	ret := _m.Called(ctx, id, mfa)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UserMFA) error); ok {
+		r0 = rf(ctx, id, mfa)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UserUpdatePassword provides a mock function with given fields: ctx, newPassword, id
 func (_m *Store) UserUpdatePassword(ctx context.Context, newPassword string, id string) error {
 	ret := _m.Called(ctx, newPassword, id)
@@ -1962,6 +2708,220 @@ func (_m *Store) UserUpdatePassword(ctx context.Context, newPassword string, id
 	return r0
 }
 
+// WebhookCreate provides a mock function with given fields: ctx, webhook
+func (_m *Store) WebhookCreate(ctx context.Context, webhook *models.Webhook) error {
+	ret := _m.Called(ctx, webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Webhook) error); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) WebhookDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryCreate provides a mock function with given fields: ctx, delivery
+func (_m *Store) WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WebhookDelivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookDeliveryGet provides a mock function with given fields: ctx, id
+func (_m *Store) WebhookDeliveryGet(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *models.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.WebhookDelivery, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.WebhookDelivery); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookDeliveryList provides a mock function with given fields: ctx, tenant, webhook, pagination
+func (_m *Store) WebhookDeliveryList(ctx context.Context, tenant string, webhook string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	ret := _m.Called(ctx, tenant, webhook, pagination)
+
+	var r0 []models.WebhookDelivery
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) ([]models.WebhookDelivery, int, error)); ok {
+		return rf(ctx, tenant, webhook, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) []models.WebhookDelivery); ok {
+		r0 = rf(ctx, tenant, webhook, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, webhook, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, webhook, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WebhookDeliveryUpdate provides a mock function with given fields: ctx, id, delivery
+func (_m *Store) WebhookDeliveryUpdate(ctx context.Context, id string, delivery *models.WebhookDeliveryUpdate) error {
+	ret := _m.Called(ctx, id, delivery)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.WebhookDeliveryUpdate) error); ok {
+		r0 = rf(ctx, id, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WebhookGet provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) WebhookGet(ctx context.Context, tenant string, id string) (*models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	var r0 *models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Webhook, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Webhook); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) WebhookList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	var r0 []models.Webhook
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.Webhook, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.Webhook); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// WebhookListByEvent provides a mock function with given fields: ctx, tenant, event
+func (_m *Store) WebhookListByEvent(ctx context.Context, tenant string, event string) ([]models.Webhook, error) {
+	ret := _m.Called(ctx, tenant, event)
+
+	var r0 []models.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]models.Webhook, error)); ok {
+		return rf(ctx, tenant, event)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.Webhook); ok {
+		r0 = rf(ctx, tenant, event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WebhookUpdate provides a mock function with given fields: ctx, tenant, id, webhook
+func (_m *Store) WebhookUpdate(ctx context.Context, tenant string, id string, webhook *models.WebhookUpdate) error {
+	ret := _m.Called(ctx, tenant, id, webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *models.WebhookUpdate) error); ok {
+		r0 = rf(ctx, tenant, id, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewStore interface {
 	mock.TestingT
 	Cleanup(func())
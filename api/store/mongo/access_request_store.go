@@ -0,0 +1,122 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) AccessRequestList(ctx context.Context, tenant, status string, pagination paginator.Query) ([]models.AccessRequest, int, error) {
+	match := bson.M{
+		"tenant_id": tenant,
+	}
+
+	if status != "" {
+		match["status"] = status
+	}
+
+	query := []bson.M{
+		{
+			"$match": match,
+		},
+		{
+			"$sort": bson.M{
+				"created_at": -1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("access_requests"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	cursor, err := s.db.Collection("access_requests").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	requests := make([]models.AccessRequest, 0)
+	if err := cursor.All(ctx, &requests); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return requests, count, nil
+}
+
+func (s *Store) AccessRequestGet(ctx context.Context, id string) (*models.AccessRequest, error) {
+	request := new(models.AccessRequest)
+	if err := s.db.Collection("access_requests").FindOne(ctx, bson.M{"id": id}).Decode(&request); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return request, nil
+}
+
+func (s *Store) AccessRequestGetGrant(ctx context.Context, deviceUID models.UID, username, ipAddress string) (*models.AccessRequest, error) {
+	filter := bson.M{
+		"device_uid":  deviceUID,
+		"username":    username,
+		"ip_address":  ipAddress,
+		"status":      models.AccessRequestApproved,
+		"grant_until": bson.M{"$gt": clock.Now()},
+	}
+
+	// When more than one grant is valid, the one lasting longer is returned.
+	opts := options.FindOne().SetSort(bson.M{"grant_until": -1})
+
+	request := new(models.AccessRequest)
+	if err := s.db.Collection("access_requests").FindOne(ctx, filter, opts).Decode(&request); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return request, nil
+}
+
+func (s *Store) AccessRequestCreate(ctx context.Context, request *models.AccessRequest) error {
+	if _, err := s.db.Collection("access_requests").InsertOne(ctx, request); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) AccessRequestDecide(ctx context.Context, id string, decision *models.AccessRequestDecision) error {
+	filter := bson.M{
+		"id":         id,
+		"status":     models.AccessRequestPending,
+		"created_at": bson.M{"$gt": clock.Now().Add(-models.AccessRequestTimeout)},
+	}
+
+	update := bson.M{
+		"status":     decision.Status,
+		"decided_by": decision.DecidedBy,
+		"decided_at": decision.DecidedAt,
+	}
+
+	if decision.GrantUntil != nil {
+		update["grant_until"] = decision.GrantUntil
+	}
+
+	result, err := s.db.Collection("access_requests").UpdateOne(ctx, filter, bson.M{"$set": update})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccessRequest(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	now := clock.Now()
+
+	pending := &models.AccessRequest{ID: "pending", TenantID: data.Namespace.TenantID, DeviceUID: "device", Username: "root", IPAddress: "10.0.0.1", Status: models.AccessRequestPending, CreatedAt: now}
+	expired := &models.AccessRequest{ID: "expired", TenantID: data.Namespace.TenantID, DeviceUID: "device", Username: "root", IPAddress: "10.0.0.1", Status: models.AccessRequestPending, CreatedAt: now.Add(-time.Hour)}
+
+	err := mongostore.AccessRequestCreate(data.Context, pending)
+	assert.NoError(t, err)
+	err = mongostore.AccessRequestCreate(data.Context, expired)
+	assert.NoError(t, err)
+
+	requests, count, err := mongostore.AccessRequestList(data.Context, data.Namespace.TenantID, models.AccessRequestPending, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "pending", requests[0].ID)
+
+	_, err = mongostore.AccessRequestGetGrant(data.Context, "device", "root", "10.0.0.1")
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	until := now.Add(time.Hour)
+	decision := &models.AccessRequestDecision{Status: models.AccessRequestApproved, DecidedBy: "admin", DecidedAt: now, GrantUntil: &until}
+
+	err = mongostore.AccessRequestDecide(data.Context, "pending", decision)
+	assert.NoError(t, err)
+
+	// A request can only be decided once and before it expires.
+	err = mongostore.AccessRequestDecide(data.Context, "pending", decision)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+	err = mongostore.AccessRequestDecide(data.Context, "expired", decision)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	request, err := mongostore.AccessRequestGet(data.Context, "pending")
+	assert.NoError(t, err)
+	assert.Equal(t, models.AccessRequestApproved, request.Status)
+	assert.Equal(t, "admin", request.DecidedBy)
+
+	grant, err := mongostore.AccessRequestGetGrant(data.Context, "device", "root", "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, "pending", grant.ID)
+
+	_, err = mongostore.AccessRequestGetGrant(data.Context, "device", "root", "10.0.0.2")
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
@@ -0,0 +1,123 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) APIKeyCreate(ctx context.Context, key *models.APIKey) error {
+	if _, err := s.db.Collection("api_keys").InsertOne(ctx, key); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) APIKeyList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.APIKey, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+		{
+			"$sort": bson.M{
+				"created_at": 1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("api_keys"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	cursor, err := s.db.Collection("api_keys").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	keys := make([]models.APIKey, 0)
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return keys, count, nil
+}
+
+func (s *Store) APIKeyGet(ctx context.Context, tenant, id string) (*models.APIKey, error) {
+	key := new(models.APIKey)
+	if err := s.db.Collection("api_keys").FindOne(ctx, bson.M{"_id": id, "tenant_id": tenant}).Decode(&key); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return key, nil
+}
+
+func (s *Store) APIKeyGetByName(ctx context.Context, tenant, name string) (*models.APIKey, error) {
+	key := new(models.APIKey)
+	if err := s.db.Collection("api_keys").FindOne(ctx, bson.M{"name": name, "tenant_id": tenant}).Decode(&key); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return key, nil
+}
+
+func (s *Store) APIKeyGetByDigest(ctx context.Context, digest string) (*models.APIKey, error) {
+	key := new(models.APIKey)
+	if err := s.db.Collection("api_keys").FindOne(ctx, bson.M{"digest": digest}).Decode(&key); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return key, nil
+}
+
+func (s *Store) APIKeyUpdate(ctx context.Context, tenant, id string, key *models.APIKeyUpdate) error {
+	result, err := s.db.Collection("api_keys").UpdateOne(ctx, bson.M{"_id": id, "tenant_id": tenant}, bson.M{"$set": key})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) APIKeySetLastUsed(ctx context.Context, id string) error {
+	result, err := s.db.Collection("api_keys").UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"last_used_at": clock.Now()}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) APIKeyDelete(ctx context.Context, tenant, id string) error {
+	result, err := s.db.Collection("api_keys").DeleteOne(ctx, bson.M{"_id": id, "tenant_id": tenant})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.DeletedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
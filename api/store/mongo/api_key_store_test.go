@@ -0,0 +1,126 @@
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyCreate(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	err := mongostore.APIKeyCreate(data.Context, &models.APIKey{
+		ID:       "id",
+		TenantID: data.Namespace.TenantID,
+		Name:     "name",
+		Role:     "operator",
+		Digest:   "digest",
+	})
+	assert.NoError(t, err)
+}
+
+func TestAPIKeyList(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	err := mongostore.APIKeyCreate(data.Context, &models.APIKey{ID: "id1", TenantID: data.Namespace.TenantID, Name: "name1", Role: "operator", Digest: "digest1", CreatedAt: time.Now()})
+	assert.NoError(t, err)
+	err = mongostore.APIKeyCreate(data.Context, &models.APIKey{ID: "id2", TenantID: "other", Name: "name2", Role: "operator", Digest: "digest2", CreatedAt: time.Now()})
+	assert.NoError(t, err)
+
+	keys, count, err := mongostore.APIKeyList(data.Context, data.Namespace.TenantID, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "id1", keys[0].ID)
+}
+
+func TestAPIKeyGetByDigest(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	err := mongostore.APIKeyCreate(data.Context, &models.APIKey{ID: "id", TenantID: data.Namespace.TenantID, Name: "name", Role: "operator", Digest: "digest"})
+	assert.NoError(t, err)
+
+	key, err := mongostore.APIKeyGetByDigest(data.Context, "digest")
+	assert.NoError(t, err)
+	assert.Equal(t, "id", key.ID)
+
+	_, err = mongostore.APIKeyGetByDigest(data.Context, "other")
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
+func TestAPIKeyUpdate(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	err := mongostore.APIKeyCreate(data.Context, &models.APIKey{ID: "id", TenantID: data.Namespace.TenantID, Name: "name", Role: "operator", Digest: "digest"})
+	assert.NoError(t, err)
+
+	err = mongostore.APIKeyUpdate(data.Context, data.Namespace.TenantID, "id", &models.APIKeyUpdate{Role: "observer"})
+	assert.NoError(t, err)
+
+	key, err := mongostore.APIKeyGet(data.Context, data.Namespace.TenantID, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, "name", key.Name)
+	assert.Equal(t, "observer", key.Role)
+}
+
+func TestAPIKeySetLastUsed(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	err := mongostore.APIKeyCreate(data.Context, &models.APIKey{ID: "id", TenantID: data.Namespace.TenantID, Name: "name", Role: "operator", Digest: "digest"})
+	assert.NoError(t, err)
+
+	err = mongostore.APIKeySetLastUsed(data.Context, "id")
+	assert.NoError(t, err)
+
+	key, err := mongostore.APIKeyGet(data.Context, data.Namespace.TenantID, "id")
+	assert.NoError(t, err)
+	assert.NotNil(t, key.LastUsedAt)
+}
+
+func TestAPIKeyDelete(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	err := mongostore.APIKeyCreate(data.Context, &models.APIKey{ID: "id", TenantID: data.Namespace.TenantID, Name: "name", Role: "operator", Digest: "digest"})
+	assert.NoError(t, err)
+
+	err = mongostore.APIKeyDelete(data.Context, data.Namespace.TenantID, "id")
+	assert.NoError(t, err)
+
+	err = mongostore.APIKeyDelete(data.Context, data.Namespace.TenantID, "id")
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
@@ -0,0 +1,83 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) AuditCreate(ctx context.Context, audit *models.AuditLog) error {
+	audit.CreatedAt = clock.Now()
+
+	if _, err := s.db.Collection("audit_logs").InsertOne(ctx, audit); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) AuditList(ctx context.Context, tenant string, pagination paginator.Query, filter models.AuditFilter) ([]models.AuditLog, int, error) {
+	match := bson.M{"tenant_id": tenant}
+
+	if filter.Action != "" {
+		match["action"] = filter.Action
+	}
+
+	if filter.ActorID != "" {
+		match["actor.id"] = filter.ActorID
+	}
+
+	if filter.TargetID != "" {
+		match["target.id"] = filter.TargetID
+	}
+
+	if !filter.From.IsZero() || !filter.To.IsZero() {
+		interval := bson.M{}
+		if !filter.From.IsZero() {
+			interval["$gte"] = filter.From
+		}
+
+		if !filter.To.IsZero() {
+			interval["$lte"] = filter.To
+		}
+
+		match["created_at"] = interval
+	}
+
+	query := []bson.M{
+		{
+			"$match": match,
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("audit_logs"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{
+		"$sort": bson.M{
+			"created_at": -1,
+		},
+	})
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	cursor, err := s.db.Collection("audit_logs").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	audits := make([]models.AuditLog, 0)
+	if err := cursor.All(ctx, &audits); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return audits, count, nil
+}
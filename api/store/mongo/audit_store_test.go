@@ -0,0 +1,80 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditCreate(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	err := mongostore.AuditCreate(data.Context, &models.AuditLog{
+		TenantID: data.Namespace.TenantID,
+		Actor:    models.AuditActor{ID: data.User.ID, Username: data.User.Username, Role: "owner"},
+		Action:   models.AuditDeviceDelete,
+		Target:   models.AuditTarget{Type: models.AuditTargetDevice, ID: data.Device.UID},
+	})
+	assert.NoError(t, err)
+}
+
+func TestAuditList(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	audits := []models.AuditLog{
+		{
+			TenantID: data.Namespace.TenantID,
+			Actor:    models.AuditActor{ID: "actor1"},
+			Action:   models.AuditDeviceDelete,
+			Target:   models.AuditTarget{Type: models.AuditTargetDevice, ID: "device1"},
+		},
+		{
+			TenantID: data.Namespace.TenantID,
+			Actor:    models.AuditActor{ID: "actor2"},
+			Action:   models.AuditDeviceUpdateStatus,
+			Target:   models.AuditTarget{Type: models.AuditTargetDevice, ID: "device2"},
+			Before:   map[string]interface{}{"status": "pending"},
+			After:    map[string]interface{}{"status": "accepted"},
+		},
+		{
+			TenantID: "other-tenant",
+			Actor:    models.AuditActor{ID: "actor1"},
+			Action:   models.AuditDeviceDelete,
+			Target:   models.AuditTarget{Type: models.AuditTargetDevice, ID: "device3"},
+		},
+	}
+
+	for i := range audits {
+		err := mongostore.AuditCreate(data.Context, &audits[i])
+		assert.NoError(t, err)
+	}
+
+	list, count, err := mongostore.AuditList(data.Context, data.Namespace.TenantID, paginator.Query{Page: -1, PerPage: -1}, models.AuditFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Len(t, list, 2)
+
+	list, count, err = mongostore.AuditList(data.Context, data.Namespace.TenantID, paginator.Query{Page: -1, PerPage: -1}, models.AuditFilter{Action: models.AuditDeviceUpdateStatus})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "device2", list[0].Target.ID)
+	assert.Equal(t, "accepted", list[0].After["status"])
+
+	_, count, err = mongostore.AuditList(data.Context, data.Namespace.TenantID, paginator.Query{Page: -1, PerPage: -1}, models.AuditFilter{ActorID: "actor1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
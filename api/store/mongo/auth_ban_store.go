@@ -0,0 +1,103 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) AuthBanList(ctx context.Context, pagination paginator.Query) ([]models.AuthBan, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"expires_at": bson.M{"$gt": clock.Now()},
+			},
+		},
+		{
+			"$sort": bson.M{
+				"created_at": -1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("auth_bans"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	cursor, err := s.db.Collection("auth_bans").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	bans := make([]models.AuthBan, 0)
+	if err := cursor.All(ctx, &bans); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return bans, count, nil
+}
+
+func (s *Store) AuthBanGetActive(ctx context.Context, keys map[string]string) (*models.AuthBan, error) {
+	if len(keys) == 0 {
+		return nil, store.ErrNoDocuments
+	}
+
+	filter := make([]bson.M, 0, len(keys))
+	for kind, value := range keys {
+		filter = append(filter, bson.M{"kind": kind, "value": value})
+	}
+
+	// When more than one key is banned, the ban lasting longer is returned.
+	opts := options.FindOne().SetSort(bson.M{"expires_at": -1})
+
+	ban := new(models.AuthBan)
+	if err := s.db.Collection("auth_bans").FindOne(ctx, bson.M{"$or": filter, "expires_at": bson.M{"$gt": clock.Now()}}, opts).Decode(&ban); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return ban, nil
+}
+
+func (s *Store) AuthBanCreate(ctx context.Context, ban *models.AuthBan) error {
+	opts := options.Update().SetUpsert(true)
+	update := bson.M{
+		"$set": bson.M{
+			"failures":   ban.Failures,
+			"created_at": ban.CreatedAt,
+			"expires_at": ban.ExpiresAt,
+		},
+	}
+
+	if _, err := s.db.Collection("auth_bans").UpdateOne(ctx, bson.M{"kind": ban.Kind, "value": ban.Value}, update, opts); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) AuthBanDelete(ctx context.Context, id string) (*models.AuthBan, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, store.ErrNoDocuments
+	}
+
+	ban := new(models.AuthBan)
+	if err := s.db.Collection("auth_bans").FindOneAndDelete(ctx, bson.M{"_id": objID}).Decode(&ban); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return ban, nil
+}
@@ -0,0 +1,53 @@
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthBan(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	now := clock.Now()
+
+	err := mongostore.AuthBanCreate(data.Context, &models.AuthBan{Kind: models.AuthLimitIP, Value: "10.0.0.1", Failures: 20, CreatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	assert.NoError(t, err)
+	err = mongostore.AuthBanCreate(data.Context, &models.AuthBan{Kind: models.AuthLimitIP, Value: "10.0.0.2", Failures: 20, CreatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(-time.Minute)})
+	assert.NoError(t, err)
+
+	// A new ban of the same key replaces the previous one.
+	err = mongostore.AuthBanCreate(data.Context, &models.AuthBan{Kind: models.AuthLimitIP, Value: "10.0.0.1", Failures: 20, CreatedAt: now, ExpiresAt: now.Add(2 * time.Hour)})
+	assert.NoError(t, err)
+
+	bans, count, err := mongostore.AuthBanList(data.Context, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "10.0.0.1", bans[0].Value)
+
+	ban, err := mongostore.AuthBanGetActive(data.Context, map[string]string{models.AuthLimitIP: "10.0.0.1", models.AuthLimitDevice: "namespace.device"})
+	assert.NoError(t, err)
+	assert.Equal(t, bans[0].ID, ban.ID)
+
+	_, err = mongostore.AuthBanGetActive(data.Context, map[string]string{models.AuthLimitIP: "10.0.0.2"})
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	deleted, err := mongostore.AuthBanDelete(data.Context, ban.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.1", deleted.Value)
+
+	_, err = mongostore.AuthBanDelete(data.Context, ban.ID)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
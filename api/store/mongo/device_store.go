@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/clock"
@@ -59,6 +60,10 @@ func (s *Store) DeviceList(ctx context.Context, pagination paginator.Query, filt
 		{
 			"$unwind": "$namespace",
 		},
+		// The health history is only returned with the device itself.
+		{
+			"$unset": "health_history",
+		},
 	}
 
 	if status != "" {
@@ -240,12 +245,22 @@ func (s *Store) DeviceCreate(ctx context.Context, d models.Device, hostname stri
 		logrus.Error(err)
 	}
 
+	// The attributes sent by the device only seed the ones of a new device, so they don't override the changes made
+	// through the API on the device's next authentication.
+	attributes := d.Attributes
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+
+	d.Attributes = nil
+
 	q := bson.M{
 		"$setOnInsert": bson.M{
 			"name":       hostname,
 			"status":     "pending",
 			"created_at": clock.Now(),
 			"tags":       []string{},
+			"attributes": attributes,
 		},
 		"$set": d,
 	}
@@ -531,3 +546,121 @@ func (s *Store) DeviceRemovedDelete(ctx context.Context, tenant string, uid mode
 
 	return nil
 }
+
+// DeviceUpdateAttributes replaces the attributes of a device.
+func (s *Store) DeviceUpdateAttributes(ctx context.Context, uid models.UID, attributes map[string]string) error {
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"attributes": attributes}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetHostKeyFingerprint(ctx context.Context, uid models.UID, fingerprint string) error {
+	update := bson.M{"$set": bson.M{"host_key_fingerprint": fingerprint}}
+	if fingerprint == "" {
+		update = bson.M{"$unset": bson.M{"host_key_fingerprint": ""}}
+	}
+
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, update)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+// DeviceSetHealth sets the device's latest health snapshot, appending it to the device's history, which keeps only
+// the latest models.DeviceHealthHistorySize snapshots.
+func (s *Store) DeviceSetHealth(ctx context.Context, uid models.UID, health *models.DeviceHealth) error {
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{
+		"$set": bson.M{"health": health},
+		"$push": bson.M{
+			"health_history": bson.M{
+				"$each":  []*models.DeviceHealth{health},
+				"$slice": -models.DeviceHealthHistorySize,
+			},
+		},
+	})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+// DeviceAttributeKeys returns the sorted attribute keys used by the devices of a namespace.
+func (s *Store) DeviceAttributeKeys(ctx context.Context, tenant string) ([]string, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id":  tenant,
+				"attributes": bson.M{"$type": "object"},
+			},
+		},
+		{
+			"$project": bson.M{
+				"attributes": bson.M{"$objectToArray": "$attributes"},
+			},
+		},
+		{
+			"$unwind": "$attributes",
+		},
+		{
+			"$group": bson.M{
+				"_id": "$attributes.k",
+			},
+		},
+		{
+			"$sort": bson.M{
+				"_id": 1,
+			},
+		},
+	}
+
+	cursor, err := s.db.Collection("devices").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	keys := make([]string, 0)
+	for cursor.Next(ctx) {
+		var key struct {
+			ID string `bson:"_id"`
+		}
+
+		if err := cursor.Decode(&key); err != nil {
+			return nil, 0, FromMongoError(err)
+		}
+
+		keys = append(keys, key.ID)
+	}
+
+	return keys, len(keys), FromMongoError(cursor.Err())
+}
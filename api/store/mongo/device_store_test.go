@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -226,6 +227,125 @@ func TestDevicesList(t *testing.T) {
 	assert.NotEmpty(t, devices)
 }
 
+func TestDeviceAttributes(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	device := data.Device
+	device.Attributes = map[string]string{"site": "lisbon"}
+
+	err = mongostore.DeviceCreate(data.Context, device, "hostname")
+	assert.NoError(t, err)
+
+	// The attributes sent on the next authentication don't override the device's ones.
+	device.Attributes = map[string]string{"site": "porto"}
+
+	err = mongostore.DeviceCreate(data.Context, device, "hostname")
+	assert.NoError(t, err)
+
+	d, err := mongostore.DeviceGet(data.Context, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"site": "lisbon"}, d.Attributes)
+
+	err = mongostore.DeviceUpdateAttributes(data.Context, models.UID(data.Device.UID), map[string]string{"site": "lisbon", "rack": "r12"})
+	assert.NoError(t, err)
+
+	filter := []models.Filter{{Type: "property", Params: &models.PropertyParams{Name: "attributes.rack", Operator: "eq", Value: "r12"}}}
+	_, count, err := mongostore.DeviceList(data.Context, paginator.Query{Page: -1, PerPage: -1}, filter, "", "", "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	keys, count, err := mongostore.DeviceAttributeKeys(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, []string{"rack", "site"}, keys)
+
+	err = mongostore.DeviceUpdateAttributes(data.Context, models.UID("invalid"), map[string]string{})
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
+func TestDeviceSetHostKeyFingerprint(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceSetHostKeyFingerprint(data.Context, models.UID(data.Device.UID), "SHA256:fingerprint")
+	assert.NoError(t, err)
+
+	// The next authentication of the device does not unpin its host key.
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	d, err := mongostore.DeviceGet(data.Context, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, "SHA256:fingerprint", d.HostKeyFingerprint)
+
+	err = mongostore.DeviceSetHostKeyFingerprint(data.Context, models.UID(data.Device.UID), "")
+	assert.NoError(t, err)
+
+	d, err = mongostore.DeviceGet(data.Context, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, "", d.HostKeyFingerprint)
+
+	err = mongostore.DeviceSetHostKeyFingerprint(data.Context, models.UID("invalid"), "")
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
+func TestDeviceSetHealth(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	for i := 0; i < models.DeviceHealthHistorySize+1; i++ {
+		err = mongostore.DeviceSetHealth(data.Context, models.UID(data.Device.UID), &models.DeviceHealth{Uptime: uint64(i), DiskUsage: 95})
+		assert.NoError(t, err)
+	}
+
+	d, err := mongostore.DeviceGet(data.Context, models.UID(data.Device.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(models.DeviceHealthHistorySize), d.Health.Uptime)
+	assert.Len(t, d.HealthHistory, models.DeviceHealthHistorySize)
+	assert.Equal(t, uint64(1), d.HealthHistory[0].Uptime)
+
+	devices, count, err := mongostore.DeviceList(data.Context, paginator.Query{Page: -1, PerPage: -1}, []models.Filter{
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "health.disk_usage", Operator: "gt", Value: 90},
+		},
+	}, "", "", "", false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Nil(t, devices[0].HealthHistory)
+
+	err = mongostore.DeviceSetHealth(data.Context, models.UID("invalid"), &models.DeviceHealth{})
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
 func TestDeviceListByUsage(t *testing.T) {
 	data := initData()
 
@@ -65,6 +65,13 @@ func GenerateMigrations() []migrate.Migration {
 		migration53,
 		migration54,
 		migration55,
+		migration56,
+		migration57,
+		migration58,
+		migration59,
+		migration60,
+		migration61,
+		migration62,
 	}
 }
 
@@ -0,0 +1,67 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration57 = migrate.Migration{
+	Version:     57,
+	Description: "create indexes to api_keys' digest, tenant_id and name",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   57,
+			"action":    "Up",
+		}).Info("Applying migration")
+		digest := "digest"
+		tenantName := "tenant_id_1_name_1"
+		unique := true
+
+		if _, err := db.Collection("api_keys").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{
+				Keys: bson.D{
+					bson.E{Key: "digest", Value: 1},
+				},
+				Options: &options.IndexOptions{ //nolint:exhaustruct
+					Name:   &digest,
+					Unique: &unique,
+				},
+			},
+			{
+				Keys: bson.D{
+					bson.E{Key: "tenant_id", Value: 1},
+					bson.E{Key: "name", Value: 1},
+				},
+				Options: &options.IndexOptions{ //nolint:exhaustruct
+					Name:   &tenantName,
+					Unique: &unique,
+				},
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   57,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		for _, name := range []string{"digest", "tenant_id_1_name_1"} {
+			if _, err := db.Collection("api_keys").Indexes().DropOne(context.Background(), name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
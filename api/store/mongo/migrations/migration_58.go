@@ -0,0 +1,83 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration58 = migrate.Migration{
+	Version:     58,
+	Description: "create indexes to webhooks' tenant_id and to webhook_deliveries' webhook_id and created_at",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   58,
+			"action":    "Up",
+		}).Info("Applying migration")
+		tenantID := "tenant_id"
+		webhookCreatedAt := "webhook_id_1_created_at_-1"
+		createdAt := "created_at"
+		// Deliveries are kept, as the webhook's delivery log, for 30 days.
+		expire := int32(30 * 24 * 60 * 60)
+
+		if _, err := db.Collection("webhooks").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: "tenant_id", Value: 1},
+			},
+			Options: &options.IndexOptions{ //nolint:exhaustruct
+				Name: &tenantID,
+			},
+		}); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("webhook_deliveries").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{
+				Keys: bson.D{
+					bson.E{Key: "webhook_id", Value: 1},
+					bson.E{Key: "created_at", Value: -1},
+				},
+				Options: &options.IndexOptions{ //nolint:exhaustruct
+					Name: &webhookCreatedAt,
+				},
+			},
+			{
+				Keys: bson.D{
+					bson.E{Key: "created_at", Value: 1},
+				},
+				Options: &options.IndexOptions{ //nolint:exhaustruct
+					Name:               &createdAt,
+					ExpireAfterSeconds: &expire,
+				},
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   58,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("webhooks").Indexes().DropOne(context.Background(), "tenant_id"); err != nil {
+			return err
+		}
+
+		for _, name := range []string{"webhook_id_1_created_at_-1", "created_at"} {
+			if _, err := db.Collection("webhook_deliveries").Indexes().DropOne(context.Background(), name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration59 = migrate.Migration{
+	Version:     59,
+	Description: "create a wildcard index to devices' attributes",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   59,
+			"action":    "Up",
+		}).Info("Applying migration")
+		name := "attributes"
+
+		if _, err := db.Collection("devices").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: "attributes.$**", Value: 1},
+			},
+			Options: &options.IndexOptions{ //nolint:exhaustruct
+				Name: &name,
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   59,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("devices").Indexes().DropOne(context.Background(), "attributes"); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration60 = migrate.Migration{
+	Version:     60,
+	Description: "create a unique index to user_cas' tenant_id and fingerprint",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   60,
+			"action":    "Up",
+		}).Info("Applying migration")
+		name := "tenant_id_1_fingerprint_1"
+		unique := true
+
+		if _, err := db.Collection("user_cas").Indexes().CreateOne(context.Background(), mongo.IndexModel{
+			Keys: bson.D{
+				bson.E{Key: "tenant_id", Value: 1},
+				bson.E{Key: "fingerprint", Value: 1},
+			},
+			Options: &options.IndexOptions{ //nolint:exhaustruct
+				Name:   &name,
+				Unique: &unique,
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   60,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("user_cas").Indexes().DropOne(context.Background(), "tenant_id_1_fingerprint_1"); err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration61 = migrate.Migration{
+	Version:     61,
+	Description: "create a unique index to auth_bans' kind and value and a ttl to its expires_at",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   61,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("auth_bans").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{
+				Keys: bson.D{
+					bson.E{Key: "kind", Value: 1},
+					bson.E{Key: "value", Value: 1},
+				},
+				Options: options.Index().SetName("kind_1_value_1").SetUnique(true),
+			},
+			{
+				Keys:    bson.D{bson.E{Key: "expires_at", Value: 1}},
+				Options: options.Index().SetName("ttl").SetExpireAfterSeconds(0),
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   61,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		for _, name := range []string{"kind_1_value_1", "ttl"} {
+			if _, err := db.Collection("auth_bans").Indexes().DropOne(context.Background(), name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
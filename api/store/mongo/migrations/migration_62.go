@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration62 = migrate.Migration{
+	Version:     62,
+	Description: "create a unique index to access_requests' id and an index to its grants",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   62,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		if _, err := db.Collection("access_requests").Indexes().CreateMany(context.Background(), []mongo.IndexModel{
+			{
+				Keys:    bson.D{bson.E{Key: "id", Value: 1}},
+				Options: options.Index().SetName("id_1").SetUnique(true),
+			},
+			{
+				Keys: bson.D{
+					bson.E{Key: "device_uid", Value: 1},
+					bson.E{Key: "username", Value: 1},
+					bson.E{Key: "ip_address", Value: 1},
+				},
+				Options: options.Index().SetName("device_uid_1_username_1_ip_address_1"),
+			},
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   62,
+			"action":    "Down",
+		}).Info("Applying migration")
+
+		for _, name := range []string{"id_1", "device_uid_1_username_1_ip_address_1"} {
+			if _, err := db.Collection("access_requests").Indexes().DropOne(context.Background(), name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -13,6 +14,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (s *Store) NamespaceList(ctx context.Context, pagination paginator.Query, filters []models.Filter, export bool) ([]models.Namespace, int, error) {
@@ -196,6 +198,15 @@ func (s *Store) NamespaceCreate(ctx context.Context, namespace *models.Namespace
 }
 
 func (s *Store) NamespaceDelete(ctx context.Context, tenantID string) error {
+	// The recorded frames kept outside the database are deleted, by session, after the namespace.
+	var recorded []models.UID
+	if s.recorder != nil {
+		var err error
+		if recorded, err = s.namespaceRecordedSessions(ctx, tenantID); err != nil {
+			return err
+		}
+	}
+
 	session, err := s.db.Client().StartSession()
 	if err != nil {
 		return err
@@ -216,7 +227,7 @@ func (s *Store) NamespaceDelete(ctx context.Context, tenantID string) error {
 			logrus.Error(err)
 		}
 
-		collections := []string{"devices", "sessions", "connected_devices", "firewall_rules", "public_keys", "recorded_sessions"}
+		collections := []string{"devices", "sessions", "connected_devices", "firewall_rules", "public_keys", "recorded_sessions", "webhooks", "webhook_deliveries", "user_cas"}
 		for _, collection := range collections {
 			if _, err := s.db.Collection(collection).DeleteMany(sessCtx, bson.M{"tenant_id": tenantID}); err != nil {
 				return nil, FromMongoError(err)
@@ -237,9 +248,40 @@ func (s *Store) NamespaceDelete(ctx context.Context, tenantID string) error {
 		return err
 	}
 
+	for _, uid := range recorded {
+		if err := s.recorder.Delete(ctx, uid); err != nil {
+			logrus.WithError(err).WithField("uid", uid).Error("Failed to delete the recorded session")
+		}
+	}
+
 	return nil
 }
 
+// namespaceRecordedSessions lists the UID of the namespace's recorded sessions.
+func (s *Store) namespaceRecordedSessions(ctx context.Context, tenantID string) ([]models.UID, error) {
+	cursor, err := s.db.Collection("sessions").Find(ctx, bson.M{"tenant_id": tenantID, "recorded": true}, options.Find().SetProjection(bson.M{"uid": 1}))
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	defer cursor.Close(ctx)
+
+	uids := make([]models.UID, 0)
+	for cursor.Next(ctx) {
+		var session struct {
+			UID models.UID `bson:"uid"`
+		}
+
+		if err := cursor.Decode(&session); err != nil {
+			return nil, FromMongoError(err)
+		}
+
+		uids = append(uids, session.UID)
+	}
+
+	return uids, FromMongoError(cursor.Err())
+}
+
 func (s *Store) NamespaceRename(ctx context.Context, tenantID string, name string) (*models.Namespace, error) {
 	if _, err := s.db.Collection("namespaces").UpdateOne(ctx, bson.M{"tenant_id": tenantID}, bson.M{"$set": bson.M{"name": name}}); err != nil {
 		return nil, FromMongoError(err)
@@ -328,6 +370,31 @@ func (s *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord boo
 	return nil
 }
 
+func (s *Store) NamespaceSetMFARequired(ctx context.Context, required bool, tenantID string) error {
+	if _, err := s.db.Collection("namespaces").UpdateOne(ctx, bson.M{"tenant_id": tenantID}, bson.M{"$set": bson.M{"settings.mfa_required": required}}); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) NamespaceUpdateSettings(ctx context.Context, tenantID string, settings *models.NamespaceSettingsUpdate) error {
+	result, err := s.db.Collection("namespaces").UpdateOne(ctx, bson.M{"tenant_id": tenantID}, bson.M{"$set": settings})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
 func (s *Store) NamespaceGetSessionRecord(ctx context.Context, tenantID string) (bool, error) {
 	var settings struct {
 		Settings *models.NamespaceSettings `json:"settings" bson:"settings"`
@@ -5,6 +5,7 @@ import (
 
 	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
 	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -176,6 +177,52 @@ func TestNamespaceUpdate(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestNamespaceUpdateSettings(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	forwarding := &models.ReverseForwarding{
+		Enabled: true,
+		Rules:   []models.ReverseForwardingRule{{Action: models.ReverseForwardingAllow, FromPort: 8000, ToPort: 8999}},
+	}
+	enabled := true
+	tags := []string{"production"}
+
+	err = mongostore.NamespaceUpdateSettings(data.Context, data.Namespace.TenantID, &models.NamespaceSettingsUpdate{
+		ReverseForwarding: forwarding,
+		AgentForwarding:   &enabled,
+		ProtectedTags:     &tags,
+	})
+	assert.NoError(t, err)
+
+	timeouts := &models.SessionTimeouts{IdleTimeout: 15, MaxDuration: 480}
+
+	// The settings that are not set are kept.
+	err = mongostore.NamespaceUpdateSettings(data.Context, data.Namespace.TenantID, &models.NamespaceSettingsUpdate{
+		SessionTimeouts: timeouts,
+		AgentUpdate:     &models.AgentUpdate{Version: "v0.13.0"},
+	})
+	assert.NoError(t, err)
+
+	namespace, err := mongostore.NamespaceGet(data.Context, data.Namespace.TenantID)
+	assert.NoError(t, err)
+	assert.Equal(t, forwarding, namespace.Settings.ReverseForwarding)
+	assert.True(t, namespace.Settings.AgentForwarding)
+	assert.Equal(t, timeouts, namespace.Settings.SessionTimeouts)
+	assert.Equal(t, tags, namespace.Settings.ProtectedTags)
+	assert.Equal(t, &models.AgentUpdate{Version: "v0.13.0"}, namespace.Settings.AgentUpdate)
+
+	err = mongostore.NamespaceUpdateSettings(data.Context, "invalid", &models.NamespaceSettingsUpdate{AgentForwarding: &enabled})
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
 func TestNamespaceRemoveMember(t *testing.T) {
 	data := initData()
 
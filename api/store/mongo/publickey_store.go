@@ -8,6 +8,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 func (s *Store) PublicKeyGet(ctx context.Context, fingerprint string, tenantID string) (*models.PublicKey, error) {
@@ -89,3 +90,13 @@ func (s *Store) PublicKeyDelete(ctx context.Context, fingerprint string, tenantI
 
 	return err
 }
+
+// PublicKeyRegistered checks if the public key is registered in any namespace.
+func (s *Store) PublicKeyRegistered(ctx context.Context, fingerprint string) (bool, error) {
+	count, err := s.db.Collection("public_keys").CountDocuments(ctx, bson.M{"fingerprint": fingerprint}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, FromMongoError(err)
+	}
+
+	return count > 0, nil
+}
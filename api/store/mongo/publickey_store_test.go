@@ -103,3 +103,23 @@ func TestPublicKeyDelete(t *testing.T) {
 	err = mongostore.PublicKeyDelete(data.Context, data.PublicKey.Fingerprint, data.PublicKey.TenantID)
 	assert.NoError(t, err)
 }
+
+func TestPublicKeyRegistered(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	err := mongostore.PublicKeyCreate(data.Context, &data.PublicKey)
+	assert.NoError(t, err)
+
+	registered, err := mongostore.PublicKeyRegistered(data.Context, data.PublicKey.Fingerprint)
+	assert.NoError(t, err)
+	assert.True(t, registered)
+
+	registered, err = mongostore.PublicKeyRegistered(data.Context, "other")
+	assert.NoError(t, err)
+	assert.False(t, registered)
+}
@@ -51,19 +51,21 @@ func BuildFilterQuery(filters []models.Filter) ([]bson.M, error) {
 			return bson.M{"$eq": value}, nil
 		},
 		"gt": func(value interface{}) (bson.M, error) {
-			switch v := value.(type) {
-			case int:
-				value = v
-			case string:
-				var err error
-				value, err = strconv.Atoi(v)
-				if err != nil {
-					return nil, err
-				}
+			value, err := number(value)
+			if err != nil {
+				return nil, err
 			}
 
 			return bson.M{"$gt": value}, nil
 		},
+		"lt": func(value interface{}) (bson.M, error) {
+			value, err := number(value)
+			if err != nil {
+				return nil, err
+			}
+
+			return bson.M{"$lt": value}, nil
+		},
 	}
 
 	operations := map[string]func() (string, error){
@@ -141,6 +143,20 @@ func BuildFilterQuery(filters []models.Filter) ([]bson.M, error) {
 	return queryMatcher, nil
 }
 
+// number converts a filter's value, given as a string, to the number it compares to, keeping the others as they are.
+func number(value interface{}) (interface{}, error) {
+	v, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	if i, err := strconv.Atoi(v); err == nil {
+		return i, nil
+	}
+
+	return strconv.ParseFloat(v, 64)
+}
+
 // BuildPaginationQuery creates a MongoDB's query from a paginator.Query with pagination to limit the number of returned results.
 func BuildPaginationQuery(pagination paginator.Query) []bson.M {
 	if pagination.PerPage == -1 {
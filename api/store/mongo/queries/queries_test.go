@@ -91,6 +91,51 @@ func TestBuildQuery(t *testing.T) {
 				err:  nil,
 			},
 		},
+		{
+			description: "Success when property is a device's attribute",
+			filters: []models.Filter{
+				{
+					Type: "property",
+					Params: &models.PropertyParams{
+						Name:     "attributes.site",
+						Operator: "eq",
+						Value:    "lisbon",
+					},
+				},
+			},
+			expected: Expected{
+				data: []bson.M{{"$match": bson.M{"$or": []bson.M{{"attributes.site": bson.M{"$eq": "lisbon"}}}}}},
+				err:  nil,
+			},
+		},
+		{
+			description: "Success when property is compared to a number",
+			filters: []models.Filter{
+				{
+					Type: "property",
+					Params: &models.PropertyParams{
+						Name:     "health.disk_usage",
+						Operator: "gt",
+						Value:    "90",
+					},
+				},
+				{
+					Type: "property",
+					Params: &models.PropertyParams{
+						Name:     "health.memory.usage",
+						Operator: "lt",
+						Value:    "12.5",
+					},
+				},
+			},
+			expected: Expected{
+				data: []bson.M{{"$match": bson.M{"$or": []bson.M{
+					{"health.disk_usage": bson.M{"$gt": 90}},
+					{"health.memory.usage": bson.M{"$lt": 12.5}},
+				}}}},
+				err: nil,
+			},
+		},
 		{
 			description: "Fail when operator in operator is invalid",
 			filters: []models.Filter{
@@ -4,11 +4,13 @@ import (
 	"context"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -40,6 +42,11 @@ func (s *Store) SessionList(ctx context.Context, pagination paginator.Query) ([]
 				"active": bson.M{"$anyElementTrue": []interface{}{"$active"}},
 			},
 		},
+		{
+			"$project": bson.M{
+				"activity": 0,
+			},
+		},
 	}
 
 	// Only match for the respective tenant if requested
@@ -151,6 +158,45 @@ func (s *Store) SessionSetRecorded(ctx context.Context, uid models.UID, recorded
 	return FromMongoError(err)
 }
 
+func (s *Store) SessionSetActivity(ctx context.Context, uid models.UID, activity *models.SessionActivity) error {
+	result, err := s.db.Collection("sessions").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"activity": activity}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) SessionSetClosureReason(ctx context.Context, uid models.UID, reason string) error {
+	result, err := s.db.Collection("sessions").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"closure_reason": reason}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) SessionSetFailureReason(ctx context.Context, uid models.UID, reason string) error {
+	result, err := s.db.Collection("sessions").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"failure_reason": reason}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
 func (s *Store) SessionCreate(ctx context.Context, session models.Session) (*models.Session, error) {
 	session.StartedAt = clock.Now()
 	session.LastSeen = session.StartedAt
@@ -226,13 +272,19 @@ func (s *Store) SessionDeleteActives(ctx context.Context, uid models.UID) error
 		return FromMongoError(err)
 	}
 
-	_, err = s.db.Collection("active_sessions").DeleteMany(ctx, bson.M{"uid": session.UID})
+	if _, err := s.db.Collection("active_sessions").DeleteMany(ctx, bson.M{"uid": session.UID}); err != nil {
+		return FromMongoError(err)
+	}
 
-	return FromMongoError(err)
+	return nil
 }
 
 func (s *Store) SessionCreateRecordFrame(ctx context.Context, uid models.UID, recordSession *models.RecordedSession) error {
-	if _, err := s.db.Collection("recorded_sessions").InsertOne(ctx, &recordSession); err != nil {
+	if s.recorder != nil {
+		if err := s.recorder.Record(ctx, uid, recordSession); err != nil {
+			return err
+		}
+	} else if _, err := s.db.Collection("recorded_sessions").InsertOne(ctx, &recordSession); err != nil {
 		return FromMongoError(err)
 	}
 
@@ -250,12 +302,20 @@ func (s *Store) SessionUpdateDeviceUID(ctx context.Context, oldUID models.UID, n
 }
 
 func (s *Store) SessionDeleteRecordFrame(ctx context.Context, uid models.UID) error {
+	if s.recorder != nil {
+		return s.recorder.Delete(ctx, uid)
+	}
+
 	_, err := s.db.Collection("recorded_sessions").DeleteMany(ctx, bson.M{"uid": uid})
 
 	return FromMongoError(err)
 }
 
 func (s *Store) SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]models.RecordedSession, int, error) {
+	if s.recorder != nil {
+		return s.sessionGetRecordFrameFromRecorder(ctx, uid)
+	}
+
 	sessionRecord := make([]models.RecordedSession, 0)
 
 	query := []bson.M{
@@ -272,6 +332,11 @@ func (s *Store) SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]mo
 			},
 		})
 	}
+
+	query = append(query, bson.M{
+		"$sort": bson.M{"time": 1},
+	})
+
 	cursor, err := s.db.Collection("recorded_sessions").Aggregate(ctx, query)
 	if err != nil {
 		return sessionRecord, 0, err
@@ -308,3 +373,27 @@ func (s *Store) SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]mo
 
 	return sessionRecord, count, nil
 }
+
+// sessionGetRecordFrameFromRecorder gets the frames kept by the recorder. As the recorder doesn't know the session's
+// tenant, the session is matched against the tenant from context before reading them.
+func (s *Store) sessionGetRecordFrameFromRecorder(ctx context.Context, uid models.UID) ([]models.RecordedSession, int, error) {
+	filter := bson.M{"uid": uid}
+	if tenant := gateway.TenantFromContext(ctx); tenant != nil {
+		filter["tenant_id"] = tenant.ID
+	}
+
+	if err := s.db.Collection("sessions").FindOne(ctx, filter).Err(); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return []models.RecordedSession{}, 0, nil
+		}
+
+		return nil, 0, FromMongoError(err)
+	}
+
+	frames, err := s.recorder.Frames(ctx, uid)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return frames, len(frames), nil
+}
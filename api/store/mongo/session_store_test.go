@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/record"
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -131,6 +133,102 @@ func TestSessionSetRecorded(t *testing.T) {
 	assert.Equal(t, returnedSession.Recorded, true)
 }
 
+func TestSessionSetActivity(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	_, err = mongostore.SessionCreate(data.Context, data.Session)
+	assert.NoError(t, err)
+
+	code := 1
+	activity := &models.SessionActivity{
+		Command:  "ls /root",
+		Stdout:   models.SessionStream{Data: "file\n", Size: 5},
+		Stderr:   models.SessionStream{},
+		ExitCode: &code,
+	}
+
+	err = mongostore.SessionSetActivity(data.Context, models.UID(data.Session.UID), activity)
+	assert.NoError(t, err)
+
+	session, err := mongostore.SessionGet(data.Context, models.UID(data.Session.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, activity, session.Activity)
+
+	sessions, _, err := mongostore.SessionList(data.Context, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Nil(t, sessions[0].Activity)
+
+	err = mongostore.SessionSetActivity(data.Context, "invalid", activity)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
+func TestSessionSetClosureReason(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	_, err = mongostore.SessionCreate(data.Context, data.Session)
+	assert.NoError(t, err)
+
+	err = mongostore.SessionSetClosureReason(data.Context, models.UID(data.Session.UID), models.SessionClosureIdleTimeout)
+	assert.NoError(t, err)
+
+	session, err := mongostore.SessionGet(data.Context, models.UID(data.Session.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, models.SessionClosureIdleTimeout, session.ClosureReason)
+
+	err = mongostore.SessionSetClosureReason(data.Context, "invalid", models.SessionClosureIdleTimeout)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
+func TestSessionSetFailureReason(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	_, err := mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	_, err = mongostore.SessionCreate(data.Context, data.Session)
+	assert.NoError(t, err)
+
+	err = mongostore.SessionSetFailureReason(data.Context, models.UID(data.Session.UID), models.AuthFailureDeviceRejected)
+	assert.NoError(t, err)
+
+	session, err := mongostore.SessionGet(data.Context, models.UID(data.Session.UID))
+	assert.NoError(t, err)
+	assert.Equal(t, models.AuthFailureDeviceRejected, session.FailureReason)
+
+	err = mongostore.SessionSetFailureReason(data.Context, "invalid", models.AuthFailureDeviceRejected)
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
 func TestSessionKeepAlive(t *testing.T) {
 	data := initData()
 
@@ -175,6 +273,37 @@ func TestSessionDeleteActives(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestSessionCreateRecordFrameRecorder(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	storage, err := record.NewFileSystem(t.TempDir())
+	assert.NoError(t, err)
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache(), WithRecorder(record.NewRecorder(storage)))
+
+	_, err = mongostore.NamespaceCreate(data.Context, &data.Namespace)
+	assert.NoError(t, err)
+
+	err = mongostore.DeviceCreate(data.Context, data.Device, "hostname")
+	assert.NoError(t, err)
+
+	_, err = mongostore.SessionCreate(data.Context, data.Session)
+	assert.NoError(t, err)
+
+	uid := models.UID(data.Session.UID)
+
+	err = mongostore.SessionCreateRecordFrame(data.Context, uid, &data.RecordedSession)
+	assert.NoError(t, err)
+
+	// The frame is put into the storage as it is recorded.
+	objects, err := storage.List(data.Context, string(uid)+"/")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1)
+}
+
 func TestSessionCreateRecordFrame(t *testing.T) {
 	data := initData()
 
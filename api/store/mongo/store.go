@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/record"
 	"github.com/shellhub-io/shellhub/pkg/cache"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -16,14 +17,30 @@ var (
 )
 
 type Store struct {
-	db    *mongo.Database
-	cache cache.Cache
+	db       *mongo.Database
+	cache    cache.Cache
+	recorder *record.Recorder
 }
 
 var _ store.Store = (*Store)(nil)
 
-func NewStore(db *mongo.Database, cache cache.Cache) *Store {
-	return &Store{db: db, cache: cache}
+// Option configures an optional feature of the Store.
+type Option func(*Store)
+
+// WithRecorder keeps the session's recorded frames in the recorder's storage instead of the database.
+func WithRecorder(recorder *record.Recorder) Option {
+	return func(s *Store) {
+		s.recorder = recorder
+	}
+}
+
+func NewStore(db *mongo.Database, cache cache.Cache, opts ...Option) *Store {
+	s := &Store{db: db, cache: cache}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 func (s *Store) Database() *mongo.Database {
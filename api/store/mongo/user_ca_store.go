@@ -0,0 +1,89 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) UserCAList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.UserCA, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+		{
+			"$sort": bson.M{
+				"created_at": 1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("user_cas"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	cursor, err := s.db.Collection("user_cas").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	cas := make([]models.UserCA, 0)
+	if err := cursor.All(ctx, &cas); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return cas, count, nil
+}
+
+func (s *Store) UserCAGet(ctx context.Context, tenant, fingerprint string) (*models.UserCA, error) {
+	ca := new(models.UserCA)
+	if err := s.db.Collection("user_cas").FindOne(ctx, bson.M{"tenant_id": tenant, "fingerprint": fingerprint}).Decode(&ca); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return ca, nil
+}
+
+func (s *Store) UserCACreate(ctx context.Context, ca *models.UserCA) error {
+	if _, err := s.db.Collection("user_cas").InsertOne(ctx, ca); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) UserCADelete(ctx context.Context, tenant, fingerprint string) error {
+	result, err := s.db.Collection("user_cas").DeleteOne(ctx, bson.M{"tenant_id": tenant, "fingerprint": fingerprint})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.DeletedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// UserCARegistered checks if the user certificate authority is trusted by any namespace.
+func (s *Store) UserCARegistered(ctx context.Context, fingerprint string) (bool, error) {
+	count, err := s.db.Collection("user_cas").CountDocuments(ctx, bson.M{"fingerprint": fingerprint}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, FromMongoError(err)
+	}
+
+	return count > 0, nil
+}
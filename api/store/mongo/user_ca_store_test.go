@@ -0,0 +1,52 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserCA(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	err := mongostore.UserCACreate(data.Context, &models.UserCA{Fingerprint: "fingerprint1", TenantID: data.Namespace.TenantID, Name: "ca1"})
+	assert.NoError(t, err)
+	err = mongostore.UserCACreate(data.Context, &models.UserCA{Fingerprint: "fingerprint2", TenantID: "other", Name: "ca2"})
+	assert.NoError(t, err)
+
+	cas, count, err := mongostore.UserCAList(data.Context, data.Namespace.TenantID, paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "ca1", cas[0].Name)
+
+	ca, err := mongostore.UserCAGet(data.Context, data.Namespace.TenantID, "fingerprint1")
+	assert.NoError(t, err)
+	assert.Equal(t, "ca1", ca.Name)
+
+	_, err = mongostore.UserCAGet(data.Context, data.Namespace.TenantID, "fingerprint2")
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	registered, err := mongostore.UserCARegistered(data.Context, "fingerprint2")
+	assert.NoError(t, err)
+	assert.True(t, registered)
+
+	registered, err = mongostore.UserCARegistered(data.Context, "fingerprint3")
+	assert.NoError(t, err)
+	assert.False(t, registered)
+
+	err = mongostore.UserCADelete(data.Context, data.Namespace.TenantID, "fingerprint1")
+	assert.NoError(t, err)
+
+	err = mongostore.UserCADelete(data.Context, data.Namespace.TenantID, "fingerprint1")
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
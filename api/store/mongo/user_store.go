@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"time"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
 	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
@@ -213,6 +214,37 @@ func (s *Store) UserUpdatePassword(ctx context.Context, newPassword string, id s
 	return nil
 }
 
+func (s *Store) UserUpdateMFA(ctx context.Context, id string, mfa models.UserMFA) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if _, err := s.db.Collection("users").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": bson.M{"mfa": mfa}}); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) UserRecordMFAFailure(ctx context.Context, id string, at time.Time) (*models.UserMFA, error) {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	user := new(models.User)
+	if err := s.db.Collection("users").FindOneAndUpdate(ctx,
+		bson.M{"_id": objID},
+		bson.M{"$inc": bson.M{"mfa.failures": 1}, "$set": bson.M{"mfa.failed_at": at}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(user); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return &user.MFA, nil
+}
+
 func (s *Store) UserUpdateFromAdmin(ctx context.Context, name string, username string, email string, password string, id string) error {
 	user, _, err := s.UserGetByID(ctx, id, false)
 	objID, _ := primitive.ObjectIDFromHex(id)
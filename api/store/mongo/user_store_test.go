@@ -3,6 +3,7 @@ package mongo
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
 	"github.com/shellhub-io/shellhub/api/pkg/guard"
@@ -222,6 +223,56 @@ func TestUserUpdatePassword(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestUserUpdateMFA(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	user := models.User{UserData: models.UserData{Name: "name", Username: "username", Email: "email"}, UserPassword: models.UserPassword{Password: "password"}}
+
+	result, err := db.Client().Database("test").Collection("users").InsertOne(data.Context, user)
+	assert.NoError(t, err)
+
+	objID := result.InsertedID.(primitive.ObjectID).Hex()
+
+	err = mongostore.UserUpdateMFA(data.Context, objID, models.UserMFA{Enabled: true, Secret: "secret", RecoveryCodes: []string{"code"}})
+	assert.NoError(t, err)
+
+	us, _, err := mongostore.UserGetByID(data.Context, objID, false)
+	assert.NoError(t, err)
+	assert.Equal(t, models.UserMFA{Enabled: true, Secret: "secret", RecoveryCodes: []string{"code"}}, us.MFA)
+	assert.Equal(t, "username", us.Username)
+}
+
+func TestUserRecordMFAFailure(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	user := models.User{UserData: models.UserData{Name: "name", Username: "username", Email: "email"}, MFA: models.UserMFA{Enabled: true, Secret: "secret"}}
+
+	result, err := db.Client().Database("test").Collection("users").InsertOne(data.Context, user)
+	assert.NoError(t, err)
+
+	objID := result.InsertedID.(primitive.ObjectID).Hex()
+
+	at := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	mfa, err := mongostore.UserRecordMFAFailure(data.Context, objID, at)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mfa.Failures)
+
+	mfa, err = mongostore.UserRecordMFAFailure(data.Context, objID, at)
+	assert.NoError(t, err)
+	assert.Equal(t, &models.UserMFA{Enabled: true, Secret: "secret", Failures: 2, FailedAt: at}, mfa)
+}
+
 func TestUpdateUserFromAdmin(t *testing.T) {
 	data := initData()
 
@@ -0,0 +1,189 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func (s *Store) WebhookCreate(ctx context.Context, webhook *models.Webhook) error {
+	if _, err := s.db.Collection("webhooks").InsertOne(ctx, webhook); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) WebhookList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+		{
+			"$sort": bson.M{
+				"created_at": 1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("webhooks"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	cursor, err := s.db.Collection("webhooks").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := make([]models.Webhook, 0)
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return webhooks, count, nil
+}
+
+func (s *Store) WebhookListByEvent(ctx context.Context, tenant, event string) ([]models.Webhook, error) {
+	cursor, err := s.db.Collection("webhooks").Find(ctx, bson.M{"tenant_id": tenant, "events": event, "active": true})
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	webhooks := make([]models.Webhook, 0)
+	if err := cursor.All(ctx, &webhooks); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return webhooks, nil
+}
+
+func (s *Store) WebhookGet(ctx context.Context, tenant, id string) (*models.Webhook, error) {
+	webhook := new(models.Webhook)
+	if err := s.db.Collection("webhooks").FindOne(ctx, bson.M{"_id": id, "tenant_id": tenant}).Decode(&webhook); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return webhook, nil
+}
+
+func (s *Store) WebhookUpdate(ctx context.Context, tenant, id string, webhook *models.WebhookUpdate) error {
+	result, err := s.db.Collection("webhooks").UpdateOne(ctx, bson.M{"_id": id, "tenant_id": tenant}, bson.M{"$set": webhook})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) WebhookDelete(ctx context.Context, tenant, id string) error {
+	session, err := s.db.Client().StartSession()
+	if err != nil {
+		return FromMongoError(err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		result, err := s.db.Collection("webhooks").DeleteOne(sessCtx, bson.M{"_id": id, "tenant_id": tenant})
+		if err != nil {
+			return nil, FromMongoError(err)
+		}
+
+		if result.DeletedCount == 0 {
+			return nil, store.ErrNoDocuments
+		}
+
+		if _, err := s.db.Collection("webhook_deliveries").DeleteMany(sessCtx, bson.M{"webhook_id": id}); err != nil {
+			return nil, FromMongoError(err)
+		}
+
+		return nil, nil
+	})
+
+	return err
+}
+
+func (s *Store) WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) error {
+	if _, err := s.db.Collection("webhook_deliveries").InsertOne(ctx, delivery); err != nil {
+		return FromMongoError(err)
+	}
+
+	return nil
+}
+
+func (s *Store) WebhookDeliveryList(ctx context.Context, tenant, webhook string, pagination paginator.Query) ([]models.WebhookDelivery, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id":  tenant,
+				"webhook_id": webhook,
+			},
+		},
+		{
+			"$sort": bson.M{
+				"created_at": -1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("webhook_deliveries"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	cursor, err := s.db.Collection("webhook_deliveries").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	deliveries := make([]models.WebhookDelivery, 0)
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return deliveries, count, nil
+}
+
+func (s *Store) WebhookDeliveryGet(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	delivery := new(models.WebhookDelivery)
+	if err := s.db.Collection("webhook_deliveries").FindOne(ctx, bson.M{"_id": id}).Decode(&delivery); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return delivery, nil
+}
+
+func (s *Store) WebhookDeliveryUpdate(ctx context.Context, id string, delivery *models.WebhookDeliveryUpdate) error {
+	result, err := s.db.Collection("webhook_deliveries").UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": delivery})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if result.MatchedCount == 0 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package mongo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookListByEvent(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	err := mongostore.WebhookCreate(data.Context, &models.Webhook{ID: "id1", TenantID: data.Namespace.TenantID, URL: "https://example.com", Events: []string{"device.created"}, Active: true})
+	assert.NoError(t, err)
+	err = mongostore.WebhookCreate(data.Context, &models.Webhook{ID: "id2", TenantID: data.Namespace.TenantID, URL: "https://example.com", Events: []string{"device.created"}, Active: false})
+	assert.NoError(t, err)
+	err = mongostore.WebhookCreate(data.Context, &models.Webhook{ID: "id3", TenantID: data.Namespace.TenantID, URL: "https://example.com", Events: []string{"session.started"}, Active: true})
+	assert.NoError(t, err)
+
+	webhooks, err := mongostore.WebhookListByEvent(data.Context, data.Namespace.TenantID, "device.created")
+	assert.NoError(t, err)
+	assert.Len(t, webhooks, 1)
+	assert.Equal(t, "id1", webhooks[0].ID)
+}
+
+func TestWebhookDelete(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	err := mongostore.WebhookCreate(data.Context, &models.Webhook{ID: "id", TenantID: data.Namespace.TenantID, URL: "https://example.com", Events: []string{"device.created"}, Active: true})
+	assert.NoError(t, err)
+	err = mongostore.WebhookDeliveryCreate(data.Context, &models.WebhookDelivery{ID: "delivery", TenantID: data.Namespace.TenantID, WebhookID: "id", Event: "device.created", CreatedAt: time.Now()})
+	assert.NoError(t, err)
+
+	err = mongostore.WebhookDelete(data.Context, data.Namespace.TenantID, "id")
+	assert.NoError(t, err)
+
+	_, err = mongostore.WebhookDeliveryGet(data.Context, "delivery")
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+
+	err = mongostore.WebhookDelete(data.Context, data.Namespace.TenantID, "id")
+	assert.EqualError(t, err, store.ErrNoDocuments.Error())
+}
+
+func TestWebhookDeliveryList(t *testing.T) {
+	data := initData()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	now := time.Now()
+
+	err := mongostore.WebhookDeliveryCreate(data.Context, &models.WebhookDelivery{ID: "old", TenantID: data.Namespace.TenantID, WebhookID: "id", Event: "device.created", CreatedAt: now.Add(-time.Hour)})
+	assert.NoError(t, err)
+	err = mongostore.WebhookDeliveryCreate(data.Context, &models.WebhookDelivery{ID: "new", TenantID: data.Namespace.TenantID, WebhookID: "id", Event: "device.created", CreatedAt: now})
+	assert.NoError(t, err)
+
+	deliveries, count, err := mongostore.WebhookDeliveryList(data.Context, data.Namespace.TenantID, "id", paginator.Query{Page: -1, PerPage: -1})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Equal(t, "new", deliveries[0].ID)
+	assert.Equal(t, "old", deliveries[1].ID)
+}
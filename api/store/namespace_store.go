@@ -21,4 +21,6 @@ type NamespaceStore interface {
 	NamespaceGetFirst(ctx context.Context, id string) (*models.Namespace, error)
 	NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error
 	NamespaceGetSessionRecord(ctx context.Context, tenantID string) (bool, error)
+	NamespaceSetMFARequired(ctx context.Context, required bool, tenantID string) error
+	NamespaceUpdateSettings(ctx context.Context, tenantID string, settings *models.NamespaceSettingsUpdate) error
 }
@@ -13,4 +13,5 @@ type PublicKeyStore interface {
 	PublicKeyCreate(ctx context.Context, key *models.PublicKey) error
 	PublicKeyUpdate(ctx context.Context, fingerprint string, tenantID string, key *models.PublicKeyUpdate) (*models.PublicKey, error)
 	PublicKeyDelete(ctx context.Context, fingerprint string, tenantID string) error
+	PublicKeyRegistered(ctx context.Context, fingerprint string) (bool, error)
 }
@@ -0,0 +1,116 @@
+package record
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileSystem is a Storage that keeps the objects as files under a root directory.
+type FileSystem struct {
+	root string
+}
+
+var _ Storage = (*FileSystem)(nil)
+
+// NewFileSystem creates a FileSystem storage, creating its root directory when it does not exist.
+func NewFileSystem(root string) (*FileSystem, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &FileSystem{root: root}, nil
+}
+
+func (f *FileSystem) path(key string) string {
+	return filepath.Join(f.root, filepath.FromSlash(filepath.Clean("/"+key)))
+}
+
+func (f *FileSystem) Put(_ context.Context, key string, r io.Reader) error {
+	path := f.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	// The content is written to a temporary file and renamed, so a reader never sees a partial object.
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (f *FileSystem) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(f.path(key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	return file, err
+}
+
+func (f *FileSystem) List(_ context.Context, prefix string) ([]Object, error) {
+	objects := make([]Object, 0)
+
+	err := filepath.WalkDir(f.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".tmp-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(f.root, path)
+		if err != nil {
+			return err
+		}
+
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			objects = append(objects, Object{Key: key})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Key < objects[j].Key
+	})
+
+	return objects, nil
+}
+
+func (f *FileSystem) Delete(_ context.Context, key string) error {
+	path := f.path(key)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	// Removes the object's directory when it becomes empty, what fails, and is ignored, otherwise.
+	if dir := filepath.Dir(path); dir != f.root {
+		os.Remove(dir) //nolint:errcheck
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+package record
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// chunkExtension is the extension of the chunks' keys.
+const chunkExtension = ".jsonl.gz"
+
+// Recorder puts the recorded frames of the sessions, as compressed chunks, into a Storage. Each frame is put as soon
+// as it is recorded, so no frame is kept by the API and every API instance reads the same frames.
+type Recorder struct {
+	storage Storage
+}
+
+// NewRecorder creates a Recorder.
+func NewRecorder(storage Storage) *Recorder {
+	return &Recorder{
+		storage: storage,
+	}
+}
+
+// Record puts a frame of a session into the storage.
+func (r *Recorder) Record(ctx context.Context, uid models.UID, frame *models.RecordedSession) error {
+	return r.write(ctx, uid, []models.RecordedSession{*frame})
+}
+
+// Frames returns the frames of a session, ordered by time.
+func (r *Recorder) Frames(ctx context.Context, uid models.UID) ([]models.RecordedSession, error) {
+	objects, err := r.storage.List(ctx, string(uid)+"/")
+	if err != nil {
+		return nil, err
+	}
+
+	frames := make([]models.RecordedSession, 0)
+	for _, object := range objects {
+		chunk, err := r.read(ctx, object.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		frames = append(frames, chunk...)
+	}
+
+	return frames, nil
+}
+
+// Delete deletes the frames of a session.
+func (r *Recorder) Delete(ctx context.Context, uid models.UID) error {
+	objects, err := r.storage.List(ctx, string(uid)+"/")
+	if err != nil {
+		return err
+	}
+
+	for _, object := range objects {
+		if err := r.storage.Delete(ctx, object.Key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteBefore deletes the chunks which first frame was recorded until limit. It returns the number of deleted
+// chunks.
+func (r *Recorder) DeleteBefore(ctx context.Context, limit time.Time) (int, error) {
+	objects, err := r.storage.List(ctx, "")
+	if err != nil {
+		return 0, err
+	}
+
+	var deleted int
+	for _, object := range objects {
+		recorded, err := chunkTime(object.Key)
+		if err != nil || recorded.After(limit) {
+			continue
+		}
+
+		if err := r.storage.Delete(ctx, object.Key); err != nil {
+			return deleted, err
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+// write compresses the frames of a session as a chunk and puts it into the storage.
+func (r *Recorder) write(ctx context.Context, uid models.UID, frames []models.RecordedSession) error {
+	if len(frames) == 0 {
+		return nil
+	}
+
+	var data bytes.Buffer
+
+	writer := gzip.NewWriter(&data)
+	encoder := json.NewEncoder(writer)
+	for _, frame := range frames {
+		if err := encoder.Encode(frame); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return r.storage.Put(ctx, chunkKey(uid, frames[0].Time), &data)
+}
+
+// read decompresses the frames of a chunk.
+func (r *Recorder) read(ctx context.Context, key string) ([]models.RecordedSession, error) {
+	object, err := r.storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	defer object.Close()
+
+	reader, err := gzip.NewReader(object)
+	if err != nil {
+		return nil, err
+	}
+
+	defer reader.Close()
+
+	frames := make([]models.RecordedSession, 0)
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var frame models.RecordedSession
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return nil, err
+		}
+
+		frames = append(frames, frame)
+	}
+
+	return frames, scanner.Err()
+}
+
+// chunkKey returns the key of a session's chunk. The time is zero padded, so the keys are ordered by time.
+func chunkKey(uid models.UID, first time.Time) string {
+	return fmt.Sprintf("%s/%020d%s", uid, first.UnixNano(), chunkExtension)
+}
+
+// chunkTime parses the first frame time from a chunk's key.
+func chunkTime(key string) (time.Time, error) {
+	name := path.Base(key)
+	if !strings.HasSuffix(name, chunkExtension) {
+		return time.Time{}, errors.New("invalid chunk key")
+	}
+
+	nano, err := strconv.ParseInt(strings.TrimSuffix(name, chunkExtension), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(0, nano), nil
+}
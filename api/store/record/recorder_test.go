@@ -0,0 +1,77 @@
+package record
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSystem(t *testing.T) {
+	ctx := context.TODO()
+
+	storage, err := NewFileSystem(t.TempDir())
+	assert.NoError(t, err)
+
+	assert.NoError(t, storage.Put(ctx, "uid/2", strings.NewReader("second")))
+	assert.NoError(t, storage.Put(ctx, "uid/1", strings.NewReader("first")))
+	assert.NoError(t, storage.Put(ctx, "other/1", strings.NewReader("other")))
+
+	objects, err := storage.List(ctx, "uid/")
+	assert.NoError(t, err)
+	assert.Equal(t, []Object{{Key: "uid/1"}, {Key: "uid/2"}}, objects)
+
+	_, err = storage.Get(ctx, "uid/3")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, storage.Delete(ctx, "uid/1"))
+	assert.NoError(t, storage.Delete(ctx, "uid/1"))
+
+	objects, err = storage.List(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Object{{Key: "other/1"}, {Key: "uid/2"}}, objects)
+}
+
+func TestRecorder(t *testing.T) {
+	ctx := context.TODO()
+
+	storage, err := NewFileSystem(t.TempDir())
+	assert.NoError(t, err)
+
+	recorder := NewRecorder(storage)
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	frame := func(message string, offset time.Duration) *models.RecordedSession {
+		return &models.RecordedSession{UID: "uid", Message: message, Time: start.Add(offset), Width: 80, Height: 24}
+	}
+
+	for i, f := range []*models.RecordedSession{frame("ls\r\n", 0), frame("file\r\n", time.Second), frame("$ ", time.Hour)} {
+		assert.NoError(t, recorder.Record(ctx, "uid", f))
+
+		// The frame is put into the storage as it is recorded.
+		objects, err := storage.List(ctx, "uid/")
+		assert.NoError(t, err)
+		assert.Len(t, objects, i+1)
+	}
+
+	frames, err := recorder.Frames(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.RecordedSession{*frame("ls\r\n", 0), *frame("file\r\n", time.Second), *frame("$ ", time.Hour)}, frames)
+
+	deleted, err := recorder.DeleteBefore(ctx, start.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	frames, err = recorder.Frames(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.RecordedSession{*frame("$ ", time.Hour)}, frames)
+
+	assert.NoError(t, recorder.Delete(ctx, "uid"))
+
+	frames, err = recorder.Frames(ctx, "uid")
+	assert.NoError(t, err)
+	assert.Empty(t, frames)
+}
@@ -0,0 +1,109 @@
+package record
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeS3 is a minimal S3 compatible server, keeping the objects of a single bucket in memory.
+type fakeS3 struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string][]byte
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access/") {
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/"+f.bucket), "/")
+
+	switch {
+	case r.Method == http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[key] = body
+	case r.Method == http.MethodGet && key == "":
+		keys := make([]string, 0)
+		for k := range f.objects {
+			if strings.HasPrefix(k, r.URL.Query().Get("prefix")) {
+				keys = append(keys, k)
+			}
+		}
+
+		sort.Strings(keys)
+
+		fmt.Fprint(w, "<ListBucketResult>")
+		for _, k := range keys {
+			fmt.Fprintf(w, "<Contents><Key>%s</Key></Contents>", k)
+		}
+		fmt.Fprint(w, "<IsTruncated>false</IsTruncated></ListBucketResult>")
+	case r.Method == http.MethodGet:
+		body, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		w.Write(body) //nolint:errcheck
+	case r.Method == http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3(t *testing.T) {
+	ctx := context.TODO()
+
+	server := httptest.NewServer(&fakeS3{bucket: "records", objects: make(map[string][]byte)})
+	defer server.Close()
+
+	storage, err := NewS3(server.URL, "us-east-1", "records", "access", "secret")
+	assert.NoError(t, err)
+
+	assert.NoError(t, storage.Put(ctx, "uid/2", strings.NewReader("second")))
+	assert.NoError(t, storage.Put(ctx, "uid/1", strings.NewReader("first")))
+	assert.NoError(t, storage.Put(ctx, "other/1", strings.NewReader("other")))
+
+	objects, err := storage.List(ctx, "uid/")
+	assert.NoError(t, err)
+	assert.Equal(t, []Object{{Key: "uid/1"}, {Key: "uid/2"}}, objects)
+
+	object, err := storage.Get(ctx, "uid/1")
+	assert.NoError(t, err)
+
+	body, err := io.ReadAll(object)
+	assert.NoError(t, err)
+	assert.NoError(t, object.Close())
+	assert.Equal(t, "first", string(body))
+
+	_, err = storage.Get(ctx, "uid/3")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	assert.NoError(t, storage.Delete(ctx, "uid/1"))
+
+	objects, err = storage.List(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Object{{Key: "other/1"}, {Key: "uid/2"}}, objects)
+}
+
+func TestCanonicalQuery(t *testing.T) {
+	query := map[string][]string{"prefix": {"a b/"}, "list-type": {"2"}}
+
+	assert.Equal(t, "list-type=2&prefix=a%20b%2F", canonicalQuery(query))
+}
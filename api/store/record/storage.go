@@ -0,0 +1,69 @@
+// Package record stores the session's recorded frames outside the database.
+//
+// Frames are put by a Recorder, as gzip compressed chunks of JSON lines, into a Storage.
+// A chunk's key is "{session uid}/{first frame time}.jsonl.gz", what allows listing a session's chunks in order and
+// cleaning chunks by time without reading them.
+package record
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNotFound is returned by a Storage when the object does not exist.
+var ErrNotFound = errors.New("object not found")
+
+// Object is an object stored by a Storage.
+type Object struct {
+	Key string
+}
+
+// Storage is an object storage where the recorded chunks are kept.
+type Storage interface {
+	// Put stores the content of r under key, replacing any previous object.
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get returns the content of an object. When the object does not exist, it returns ErrNotFound.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// List lists the objects which key starts with prefix, ordered by key.
+	List(ctx context.Context, prefix string) ([]Object, error)
+	// Delete deletes an object. Deleting an object that does not exist is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+const (
+	StorageMongo      = "mongo"
+	StorageFileSystem = "fs"
+	StorageS3         = "s3"
+)
+
+// Config contains the environment variables used to configure where the recorded sessions are stored.
+type Config struct {
+	// Storage is the backend where recorded sessions are stored. It can be "mongo", the default, "fs" or "s3".
+	Storage string `envconfig:"record_storage" default:"mongo"`
+	// Path is the directory where the "fs" storage keeps the recorded sessions.
+	Path string `envconfig:"record_storage_path" default:"/var/lib/shellhub/records"`
+	// S3Endpoint is the URL of a S3 compatible service, e.g. "https://s3.us-east-1.amazonaws.com" or
+	// "http://minio:9000".
+	S3Endpoint  string `envconfig:"record_s3_endpoint"`
+	S3Region    string `envconfig:"record_s3_region" default:"us-east-1"`
+	S3Bucket    string `envconfig:"record_s3_bucket"`
+	S3AccessKey string `envconfig:"record_s3_access_key"`
+	S3SecretKey string `envconfig:"record_s3_secret_key"`
+}
+
+// NewStorage creates the Storage defined by the configuration. When the configured storage is "mongo", the frames are
+// kept in the database, so it returns a nil Storage.
+func NewStorage(cfg Config) (Storage, error) {
+	switch cfg.Storage {
+	case StorageMongo, "":
+		return nil, nil
+	case StorageFileSystem:
+		return NewFileSystem(cfg.Path)
+	case StorageS3:
+		return NewS3(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey)
+	default:
+		return nil, fmt.Errorf("invalid record storage: %s", cfg.Storage)
+	}
+}
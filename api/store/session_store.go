@@ -19,4 +19,7 @@ type SessionStore interface {
 	SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]models.RecordedSession, int, error)
 	SessionDeleteRecordFrame(ctx context.Context, uid models.UID) error
 	SessionSetRecorded(ctx context.Context, uid models.UID, recorded bool) error
+	SessionSetActivity(ctx context.Context, uid models.UID, activity *models.SessionActivity) error
+	SessionSetClosureReason(ctx context.Context, uid models.UID, reason string) error
+	SessionSetFailureReason(ctx context.Context, uid models.UID, reason string) error
 }
@@ -15,4 +15,10 @@ type Store interface {
 	PrivateKeyStore
 	LicenseStore
 	StatsStore
+	AuditStore
+	APIKeyStore
+	WebhookStore
+	UserCAStore
+	AuthBanStore
+	AccessRequestStore
 }
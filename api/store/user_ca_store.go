@@ -0,0 +1,16 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type UserCAStore interface {
+	UserCAList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.UserCA, int, error)
+	UserCAGet(ctx context.Context, tenant, fingerprint string) (*models.UserCA, error)
+	UserCACreate(ctx context.Context, ca *models.UserCA) error
+	UserCADelete(ctx context.Context, tenant, fingerprint string) error
+	UserCARegistered(ctx context.Context, fingerprint string) (bool, error)
+}
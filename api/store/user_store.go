@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -15,6 +16,9 @@ type UserStore interface {
 	UserGetByID(ctx context.Context, id string, ns bool) (*models.User, int, error)
 	UserUpdateData(ctx context.Context, id string, user models.User) error
 	UserUpdatePassword(ctx context.Context, newPassword string, id string) error
+	UserUpdateMFA(ctx context.Context, id string, mfa models.UserMFA) error
+	// UserRecordMFAFailure counts a failed MFA code of the user, at the time it failed, returning the user's MFA.
+	UserRecordMFAFailure(ctx context.Context, id string, at time.Time) (*models.UserMFA, error)
 	UserUpdateFromAdmin(ctx context.Context, name string, username string, email string, password string, id string) error
 	UserCreateToken(ctx context.Context, token *models.UserTokenRecover) error
 	UserGetToken(ctx context.Context, id string) (*models.UserTokenRecover, error)
@@ -0,0 +1,24 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type WebhookStore interface {
+	WebhookCreate(ctx context.Context, webhook *models.Webhook) error
+	WebhookList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Webhook, int, error)
+	// WebhookListByEvent lists the namespace's active webhooks subscribed to an event.
+	WebhookListByEvent(ctx context.Context, tenant, event string) ([]models.Webhook, error)
+	WebhookGet(ctx context.Context, tenant, id string) (*models.Webhook, error)
+	WebhookUpdate(ctx context.Context, tenant, id string, webhook *models.WebhookUpdate) error
+	// WebhookDelete deletes a webhook and its deliveries.
+	WebhookDelete(ctx context.Context, tenant, id string) error
+	WebhookDeliveryCreate(ctx context.Context, delivery *models.WebhookDelivery) error
+	// WebhookDeliveryList lists a webhook's deliveries, the newest first.
+	WebhookDeliveryList(ctx context.Context, tenant, webhook string, pagination paginator.Query) ([]models.WebhookDelivery, int, error)
+	WebhookDeliveryGet(ctx context.Context, id string) (*models.WebhookDelivery, error)
+	WebhookDeliveryUpdate(ctx context.Context, id string, delivery *models.WebhookDeliveryUpdate) error
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/api/pkg/metrics"
+	"github.com/shellhub-io/shellhub/api/store/record"
 	"github.com/shellhub-io/shellhub/api/workers/stores"
 	"github.com/sirupsen/logrus"
 	"go.mongodb.org/mongo-driver/bson"
@@ -32,7 +34,10 @@ func StartCleaner(ctx context.Context) error {
 		return fmt.Errorf("invalid time interval: %w", fmt.Errorf("%d is not a valid time interval", envs.SessionRecordCleanupRetention))
 	}
 
-	limit := time.Now().UTC().AddDate(0, 0, envs.SessionRecordCleanupRetention*-1)
+	storage, err := record.NewStorage(envs.Config)
+	if err != nil {
+		return fmt.Errorf("failed to configure the record storage: %w", err)
+	}
 
 	store, err := stores.NewMongoStore(ctx, envs.MongoURI)
 	if err != nil {
@@ -52,10 +57,20 @@ func StartCleaner(ctx context.Context) error {
 	)
 
 	mux := asynq.NewServeMux()
+	mux.Use(metrics.JobMiddleware)
 
 	// Handle session_record:cleanup task
 	mux.HandleFunc("session_record:cleanup", func(ctx context.Context, task *asynq.Task) error {
-		if _, err := store.Database.Collection("recorded_sessions").DeleteMany(ctx,
+		limit := time.Now().UTC().AddDate(0, 0, envs.SessionRecordCleanupRetention*-1)
+
+		if storage != nil {
+			deleted, err := record.NewRecorder(storage).DeleteBefore(ctx, limit)
+			if err != nil {
+				return err
+			}
+
+			logrus.WithField("chunks", deleted).Debug("Session record chunks deleted")
+		} else if _, err := store.Database.Collection("recorded_sessions").DeleteMany(ctx,
 			bson.M{"time": bson.D{{"$lte", limit}}},
 		); err != nil {
 			return err
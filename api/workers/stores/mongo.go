@@ -3,6 +3,7 @@ package stores
 import (
 	"context"
 
+	"github.com/shellhub-io/shellhub/api/pkg/metrics"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/connstring"
@@ -18,7 +19,7 @@ func NewMongoStore(ctx context.Context, uri string) (*MongoStore, error) {
 		return nil, err
 	}
 
-	mongoClientOptions := options.Client().ApplyURI(uri)
+	mongoClientOptions := options.Client().ApplyURI(uri).SetMonitor(metrics.CommandMonitor())
 
 	mongoClient, err := mongo.Connect(ctx, mongoClientOptions)
 	if err != nil {
@@ -0,0 +1,72 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/api/pkg/metrics"
+	"github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/pkg/api/webhook"
+)
+
+const (
+	// webhookRetryDelay is the delay before the first retry of a failed delivery. It doubles on each retry.
+	webhookRetryDelay = 30 * time.Second
+	// webhookMaxRetryDelay is the maximum delay between the retries of a failed delivery.
+	webhookMaxRetryDelay = 2 * time.Hour
+)
+
+// StartWebhooks starts a worker to dispatch the namespace's events to their webhooks and to deliver them, retrying
+// the failed deliveries with an exponential backoff.
+//
+// The worker processes only the services.WebhookQueue, so its tasks are not taken by the other workers.
+func StartWebhooks(ctx context.Context, redis asynq.RedisConnOpt, service services.WebhookService) error {
+	srv := asynq.NewServer(
+		redis,
+		asynq.Config{ //nolint:exhaustruct
+			Concurrency: runtime.NumCPU(),
+			Queues: map[string]int{
+				services.WebhookQueue: 1,
+			},
+			RetryDelayFunc: webhookRetryDelayFunc,
+		},
+	)
+
+	mux := asynq.NewServeMux()
+	mux.Use(metrics.JobMiddleware)
+
+	mux.HandleFunc(services.TaskWebhookDispatch, func(ctx context.Context, task *asynq.Task) error {
+		var event webhook.Event
+		if err := json.Unmarshal(task.Payload(), &event); err != nil {
+			return fmt.Errorf("invalid webhook event: %v: %w", err, asynq.SkipRetry)
+		}
+
+		return service.DispatchWebhookEvent(ctx, &event)
+	})
+
+	mux.HandleFunc(services.TaskWebhookDeliver, func(ctx context.Context, task *asynq.Task) error {
+		retried, _ := asynq.GetRetryCount(ctx)
+		max, _ := asynq.GetMaxRetry(ctx)
+
+		return service.DeliverWebhook(ctx, string(task.Payload()), retried >= max)
+	})
+
+	return srv.Run(mux)
+}
+
+// webhookRetryDelayFunc returns the delay before the n-th retry of a webhook's task.
+func webhookRetryDelayFunc(n int, _ error, _ *asynq.Task) time.Duration {
+	if n >= 12 {
+		return webhookMaxRetryDelay
+	}
+
+	if delay := webhookRetryDelay << n; delay < webhookMaxRetryDelay {
+		return delay
+	}
+
+	return webhookMaxRetryDelay
+}
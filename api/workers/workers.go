@@ -2,6 +2,7 @@ package workers
 
 import (
 	"github.com/kelseyhightower/envconfig"
+	"github.com/shellhub-io/shellhub/api/store/record"
 )
 
 type Envs struct {
@@ -9,6 +10,7 @@ type Envs struct {
 	RedisURI                      string `envconfig:"redis_uri" default:"redis://redis:6379"`
 	SessionRecordCleanupSchedule  string `envconfig:"session_record_cleanup_schedule" default:"@daily"`
 	SessionRecordCleanupRetention int    `envconfig:"record_retention" default:"0"`
+	record.Config
 }
 
 func getEnvs() (*Envs, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/shellhub-io/shellhub/api/store/mongo"
@@ -286,9 +287,66 @@ func main() {
 		},
 	})
 
+	banCmd := &cobra.Command{
+		Use:   "ban",
+		Short: "Manage SSH authentication bans",
+		Long:  `Manage SSH authentication bans`,
+	}
+	banCmd.AddCommand(&cobra.Command{
+		Use:     "list",
+		Short:   "List the SSH authentication bans",
+		Long:    `List the SSH authentication bans`,
+		Example: `cli ban list`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bans, err := services.AuthBanList()
+			if err != nil {
+				return err
+			}
+
+			for _, ban := range bans {
+				cmd.Println("ID:", ban.ID)
+				cmd.Println("Kind:", ban.Kind)
+				cmd.Println("Value:", ban.Value)
+				cmd.Println("Failures:", ban.Failures)
+				cmd.Println("Expires at:", ban.ExpiresAt.Format(time.RFC3339))
+			}
+
+			return nil
+		},
+	})
+	banCmd.AddCommand(&cobra.Command{
+		Use:     "lift <id>",
+		Short:   "Lift a SSH authentication ban",
+		Long:    `Lift a SSH authentication ban`,
+		Example: `cli ban lift 507f1f77bcf86cd799439011`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var input struct {
+				ID string
+			}
+
+			if err := bind(args, &input); err != nil {
+				return err
+			}
+
+			ban, err := services.AuthBanLift(input.ID)
+			if err != nil {
+				return err
+			}
+
+			cmd.Println("Ban lifted successfully")
+			cmd.Println("Kind:", ban.Kind)
+			cmd.Println("Value:", ban.Value)
+
+			return nil
+		},
+	})
+
 	rootCmd.AddCommand(userCmd)
 	rootCmd.AddCommand(namespaceCmd)
 	rootCmd.AddCommand(memberCmd)
+	rootCmd.AddCommand(banCmd)
 
 	rootCmd.AddCommand(&cobra.Command{
 		Deprecated: "This command is deprecated and will be removed in a future release.",
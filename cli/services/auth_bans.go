@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// AuthBanList lists the unexpired SSH authentication bans.
+func (s *service) AuthBanList() ([]models.AuthBan, error) {
+	bans, _, err := s.store.AuthBanList(context.Background(), paginator.Query{Page: -1, PerPage: -1})
+	if err != nil {
+		return nil, ErrFailedListAuthBans
+	}
+
+	return bans, nil
+}
+
+// AuthBanLift lifts a SSH authentication ban. The failed attempts of the banned key are already forgotten when it is
+// banned, so it can try to authenticate again right away.
+func (s *service) AuthBanLift(id string) (*models.AuthBan, error) {
+	ban, err := s.store.AuthBanDelete(context.Background(), id)
+	if err != nil {
+		return nil, ErrAuthBanNotFound
+	}
+
+	return ban, nil
+}
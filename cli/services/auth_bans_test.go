@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthBanList(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock))
+
+	ctx := context.Background()
+
+	Err := errors.New("error")
+	bans := []models.AuthBan{
+		{ID: "id", Kind: models.AuthLimitIP, Value: "192.168.1.1", Failures: 10},
+	}
+
+	tests := []struct {
+		description   string
+		requiredMocks func()
+		expected      []models.AuthBan
+		expectedErr   error
+	}{
+		{
+			description: "Fails to list the bans",
+			requiredMocks: func() {
+				mock.On("AuthBanList", ctx, paginator.Query{Page: -1, PerPage: -1}).Return(nil, 0, Err).Once()
+			},
+			expected:    nil,
+			expectedErr: ErrFailedListAuthBans,
+		},
+		{
+			description: "Success to list the bans",
+			requiredMocks: func() {
+				mock.On("AuthBanList", ctx, paginator.Query{Page: -1, PerPage: -1}).Return(bans, len(bans), nil).Once()
+			},
+			expected:    bans,
+			expectedErr: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			test.requiredMocks()
+			list, err := s.AuthBanList()
+			assert.Equal(t, test.expected, list)
+			assert.Equal(t, test.expectedErr, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestAuthBanLift(t *testing.T) {
+	mock := &mocks.Store{}
+	s := NewService(store.Store(mock))
+
+	ctx := context.Background()
+
+	Err := errors.New("error")
+	ban := &models.AuthBan{ID: "id", Kind: models.AuthLimitIP, Value: "192.168.1.1", Failures: 10}
+
+	tests := []struct {
+		description   string
+		id            string
+		requiredMocks func()
+		expected      *models.AuthBan
+		expectedErr   error
+	}{
+		{
+			description: "Fails to find the ban",
+			id:          "notfound",
+			requiredMocks: func() {
+				mock.On("AuthBanDelete", ctx, "notfound").Return(nil, Err).Once()
+			},
+			expected:    nil,
+			expectedErr: ErrAuthBanNotFound,
+		},
+		{
+			description: "Success to lift the ban",
+			id:          ban.ID,
+			requiredMocks: func() {
+				mock.On("AuthBanDelete", ctx, ban.ID).Return(ban, nil).Once()
+			},
+			expected:    ban,
+			expectedErr: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			test.requiredMocks()
+			lifted, err := s.AuthBanLift(test.id)
+			assert.Equal(t, test.expected, lifted)
+			assert.Equal(t, test.expectedErr, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -21,4 +21,6 @@ var (
 	ErrUserNameAndEmailExists      = errors.New("user name and email already exists")
 	ErrNamespaceInvalid            = errors.New("namespace is invalid")
 	ErrFailedNamespaceAddMember    = errors.New("could not add this member to this namespace")
+	ErrFailedListAuthBans          = errors.New("failed to list the authentication bans")
+	ErrAuthBanNotFound             = errors.New("authentication ban not found")
 )
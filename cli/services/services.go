@@ -17,6 +17,8 @@ type Services interface {
 	NamespaceAddMember(username, namespace, role string) (*models.Namespace, error)
 	NamespaceRemoveMember(username, namespace string) (*models.Namespace, error)
 	NamespaceDelete(namespace string) error
+	AuthBanList() ([]models.AuthBan, error)
+	AuthBanLift(id string) (*models.AuthBan, error)
 }
 
 type service struct {
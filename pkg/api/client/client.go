@@ -7,8 +7,10 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"time"
 
 	resty "github.com/go-resty/resty/v2"
+	"github.com/gorilla/websocket"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/sirupsen/logrus"
 )
@@ -17,15 +19,26 @@ const (
 	DeviceUIDHeader = "X-Device-UID"
 )
 
+const (
+	// RetryWaitTime is the base of the exponential, and jittered, backoff between the retries of a failed request.
+	RetryWaitTime = time.Second
+	// RetryMaxWaitTime is the cap of the backoff between the retries of a failed request, which spreads the retries of
+	// many agents when the server is restarted.
+	RetryMaxWaitTime = 2 * time.Minute
+)
+
 var (
 	ErrConnectionFailed = errors.New("connection failed")
 	ErrNotFound         = errors.New("not found")
 	ErrUnknown          = errors.New("unknown error")
+	ErrProxyScheme      = errors.New("proxy scheme must be http or socks5")
 )
 
 func NewClient(opts ...Opt) Client {
 	httpClient := resty.New()
 	httpClient.SetRetryCount(math.MaxInt32)
+	httpClient.SetRetryWaitTime(RetryWaitTime)
+	httpClient.SetRetryMaxWaitTime(RetryMaxWaitTime)
 	httpClient.AddRetryCondition(func(r *resty.Response, err error) bool {
 		if _, ok := err.(net.Error); ok {
 			return true
@@ -39,6 +52,7 @@ func NewClient(opts ...Opt) Client {
 		port:   apiPort,
 		scheme: apiScheme,
 		http:   httpClient,
+		proxy:  http.ProxyFromEnvironment,
 	}
 
 	for _, opt := range opts {
@@ -47,6 +61,10 @@ func NewClient(opts ...Opt) Client {
 		}
 	}
 
+	if transport, ok := httpClient.GetClient().Transport.(*http.Transport); ok {
+		transport.Proxy = c.proxy
+	}
+
 	if c.logger != nil {
 		httpClient.SetLogger(&LeveledLogger{c.logger})
 	}
@@ -65,6 +83,16 @@ type client struct {
 	port   int
 	http   *resty.Client
 	logger *logrus.Logger
+	// proxy returns the proxy used to connect to the server, both by the HTTP requests and the websockets.
+	proxy func(*http.Request) (*url.URL, error)
+}
+
+// dialer returns the dialer of the websockets to the server.
+func (c *client) dialer() *websocket.Dialer {
+	return &websocket.Dialer{
+		Proxy:            c.proxy,
+		HandshakeTimeout: 45 * time.Second,
+	}
 }
 
 func (c *client) ListDevices() ([]models.Device, error) {
@@ -32,6 +32,8 @@ type publicAPI interface {
 	AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthResponse, error)
 	NewReverseListener(token string) (*revdial.Listener, error)
 	AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (*models.PublicKeyAuthResponse, error)
+	AuthCertificate(req *models.CertificateAuthRequest, token string) (*models.CertificateEvaluation, error)
+	ReportDeviceHealth(health *models.DeviceHealth, token string) error
 }
 
 func (c *client) GetInfo(agentVersion string) (*models.Info, error) {
@@ -94,14 +96,14 @@ func (c *client) NewReverseListener(token string) (*revdial.Listener, error) {
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 
 	url := regexp.MustCompile(`^http`).ReplaceAllString(buildURL(c, "/ssh/connection"), "ws")
-	conn, _, err := websocket.DefaultDialer.Dial(url, req.Header)
+	conn, _, err := c.dialer().Dial(url, req.Header)
 	if err != nil {
 		return nil, err
 	}
 
 	listener := revdial.NewListener(wsconnadapter.New(conn),
 		func(ctx context.Context, path string) (*websocket.Conn, *http.Response, error) {
-			return tunnelDial(ctx, strings.Replace(c.scheme, "http", "ws", 1), c.host, c.port, path)
+			return c.tunnelDial(ctx, strings.Replace(c.scheme, "http", "ws", 1), c.host, c.port, path)
 		},
 	)
 
@@ -122,6 +124,42 @@ func (c *client) AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (
 	return res, nil
 }
 
-func tunnelDial(ctx context.Context, protocol, address string, port int, path string) (*websocket.Conn, *http.Response, error) {
-	return websocket.DefaultDialer.DialContext(ctx, strings.Join([]string{fmt.Sprintf("%s://%s:%d", protocol, address, port), path}, ""), nil)
+// AuthCertificate checks if a user certificate allows the user to log in the device authenticated by token.
+func (c *client) AuthCertificate(req *models.CertificateAuthRequest, token string) (*models.CertificateEvaluation, error) {
+	var evaluation *models.CertificateEvaluation
+	res, err := c.http.R().
+		SetBody(req).
+		SetResult(&evaluation).
+		SetAuthToken(token).
+		Post(buildURL(c, "/api/auth/ssh/certificate"))
+	if err != nil {
+		return nil, err
+	}
+
+	if res.IsError() {
+		return nil, fmt.Errorf("%w: %s", ErrUnknown, res.Status())
+	}
+
+	return evaluation, nil
+}
+
+// ReportDeviceHealth reports the health of the device authenticated by token.
+func (c *client) ReportDeviceHealth(health *models.DeviceHealth, token string) error {
+	res, err := c.http.R().
+		SetBody(health).
+		SetAuthToken(token).
+		Post(buildURL(c, "/api/devices/health"))
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return fmt.Errorf("%w: %s", ErrUnknown, res.Status())
+	}
+
+	return nil
+}
+
+func (c *client) tunnelDial(ctx context.Context, protocol, address string, port int, path string) (*websocket.Conn, *http.Response, error) {
+	return c.dialer().DialContext(ctx, strings.Join([]string{fmt.Sprintf("%s://%s:%d", protocol, address, port), path}, ""), nil)
 }
@@ -37,6 +37,29 @@ func (_m *Client) AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthR
 	return r0, r1
 }
 
+// AuthCertificate provides a mock function with given fields: req, token
+func (_m *Client) AuthCertificate(req *models.CertificateAuthRequest, token string) (*models.CertificateEvaluation, error) {
+	ret := _m.Called(req, token)
+
+	var r0 *models.CertificateEvaluation
+	if rf, ok := ret.Get(0).(func(*models.CertificateAuthRequest, string) *models.CertificateEvaluation); ok {
+		r0 = rf(req, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CertificateEvaluation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.CertificateAuthRequest, string) error); ok {
+		r1 = rf(req, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AuthPublicKey provides a mock function with given fields: req, token
 func (_m *Client) AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (*models.PublicKeyAuthResponse, error) {
 	ret := _m.Called(req, token)
@@ -174,3 +197,17 @@ func (_m *Client) NewReverseListener(token string) (*revdial.Listener, error) {
 
 	return r0, r1
 }
+
+// ReportDeviceHealth provides a mock function with given fields: health, token
+func (_m *Client) ReportDeviceHealth(health *models.DeviceHealth, token string) error {
+	ret := _m.Called(health, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.DeviceHealth, string) error); ok {
+		r0 = rf(health, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
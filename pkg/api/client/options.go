@@ -1,10 +1,12 @@
 package client
 
 import (
+	"net/http"
 	"net/url"
 	"strconv"
 
 	"github.com/sirupsen/logrus"
+	"golang.org/x/net/http/httpproxy"
 )
 
 type Opt func(*client) error
@@ -65,3 +67,27 @@ func WithLogger(logger *logrus.Logger) Opt {
 		return nil
 	}
 }
+
+// WithProxy connects to the server through an explicit HTTP or SOCKS5 proxy, authenticated by the URL's user
+// information when it has one, instead of the proxy set by the HTTP_PROXY and HTTPS_PROXY environment variables. The
+// hosts listed in the NO_PROXY environment variable are still connected directly.
+func WithProxy(proxy *url.URL) Opt {
+	return func(c *client) error {
+		if proxy.Scheme != "http" && proxy.Scheme != "socks5" {
+			return ErrProxyScheme
+		}
+
+		config := &httpproxy.Config{
+			HTTPProxy:  proxy.String(),
+			HTTPSProxy: proxy.String(),
+			NoProxy:    httpproxy.FromEnvironment().NoProxy,
+		}
+
+		proxyFunc := config.ProxyFunc()
+		c.proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+
+		return nil
+	}
+}
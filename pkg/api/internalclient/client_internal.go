@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
@@ -27,6 +29,8 @@ type internalAPI interface {
 	GetPublicKey(fingerprint, tenant string) (*models.PublicKey, error)
 	CreatePrivateKey() (*models.PrivateKey, error)
 	EvaluateKey(fingerprint string, dev *models.Device, username string) (bool, error)
+	EvaluateCertificate(cert []byte, dev *models.Device, username, address string) (*models.CertificateEvaluation, error)
+	KeyRegistered(fingerprint string) (bool, error)
 	DevicesOffline(id string) error
 	DevicesHeartbeat(id string) error
 	FirewallEvaluate(lookup map[string]string) error
@@ -34,11 +38,21 @@ type internalAPI interface {
 	FinishSession(uid string) []error
 	KeepAliveSession(uid string) []error
 	RecordSession(session *models.SessionRecorded, recordURL string)
+	RecordSessionActivity(uid string, activity *models.SessionActivity) error
+	SetSessionClosure(uid, reason string) error
 	BillingEvaluate(tenantID string) (*models.Namespace, int, error)
 	Lookup(lookup map[string]string) (string, []error)
 	DeviceLookup(lookup map[string]string) (*models.Device, []error)
 	ReportUsage(ur *models.UsageRecord) (int, error)
 	ReportDelete(ns *models.Namespace) (int, error)
+	EvaluateReverseForwarding(tenant string, port uint32) (bool, error)
+	EvaluateAgentForwarding(tenant string) (bool, error)
+	GetSessionTimeouts(tenant string) (*models.SessionTimeouts, error)
+	GetSessionRecord(tenant string) (bool, error)
+	EvaluateAuthAttempt(attempt request.AuthAttempt) (*models.AuthEvaluation, error)
+	RecordAuthFailure(failure request.AuthFailure) error
+	CreateAccessRequest(req request.AccessRequestCreate) (*models.AccessRequest, error)
+	GetAccessRequest(id string) (*models.AccessRequest, error)
 }
 
 func (c *client) LookupDevice() {
@@ -115,6 +129,209 @@ func (c *client) EvaluateKey(fingerprint string, dev *models.Device, username st
 	return false, nil
 }
 
+// ErrCertificateRejected is returned when the API does not accept a user certificate to access a device.
+var ErrCertificateRejected = errors.New("the certificate was rejected")
+
+// EvaluateCertificate makes a HTTP request to ShellHub API server to check if a user certificate, in the authorized
+// keys format, grants username access to the device when connecting from address.
+func (c *client) EvaluateCertificate(cert []byte, dev *models.Device, username, address string) (*models.CertificateEvaluation, error) {
+	evaluation := new(models.CertificateEvaluation)
+
+	resp, err := c.http.R().
+		SetBody(struct {
+			Certificate []byte         `json:"certificate"`
+			Device      *models.Device `json:"device"`
+			Address     string         `json:"address"`
+		}{Certificate: cert, Device: dev, Address: address}).
+		SetResult(evaluation).
+		Post(buildURL(c, fmt.Sprintf("/internal/sshkeys/certificates/evaluate/%s", username)))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ErrCertificateRejected
+	}
+
+	return evaluation, nil
+}
+
+// KeyRegistered makes a HTTP request to ShellHub API server to check if a public key, or a user certificate authority,
+// with the fingerprint is registered in any namespace.
+func (c *client) KeyRegistered(fingerprint string) (bool, error) {
+	resp, err := c.http.R().
+		Get(buildURL(c, fmt.Sprintf("/internal/sshkeys/registered/%s", fingerprint)))
+	if err != nil {
+		return false, err
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, ErrUnknown
+	}
+}
+
+// EvaluateReverseForwarding makes a HTTP request to ShellHub API server to check if the namespace's policy allows a
+// remote port forwarding to bind port on its devices.
+func (c *client) EvaluateReverseForwarding(tenant string, port uint32) (bool, error) {
+	var allowed bool
+
+	resp, err := c.http.R().
+		SetQueryParam("port", strconv.FormatUint(uint64(port), 10)).
+		SetResult(&allowed).
+		Get(buildURL(c, fmt.Sprintf("/internal/namespaces/%s/forwarding/evaluate", tenant)))
+	if err != nil {
+		return false, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return false, nil
+	}
+
+	return allowed, nil
+}
+
+// EvaluateAgentForwarding makes a HTTP request to ShellHub API server to check if the client's SSH agent can be
+// forwarded to the namespace's devices.
+func (c *client) EvaluateAgentForwarding(tenant string) (bool, error) {
+	var allowed bool
+
+	resp, err := c.http.R().
+		SetResult(&allowed).
+		Get(buildURL(c, fmt.Sprintf("/internal/namespaces/%s/agent-forwarding/evaluate", tenant)))
+	if err != nil {
+		return false, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return false, nil
+	}
+
+	return allowed, nil
+}
+
+// GetSessionTimeouts makes a HTTP request to ShellHub API server to get the limits of how long the SSH sessions to the
+// namespace's devices can last.
+func (c *client) GetSessionTimeouts(tenant string) (*models.SessionTimeouts, error) {
+	timeouts := new(models.SessionTimeouts)
+
+	resp, err := c.http.R().
+		SetResult(timeouts).
+		Get(buildURL(c, fmt.Sprintf("/internal/namespaces/%s/session-timeouts", tenant)))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ErrUnknown
+	}
+
+	return timeouts, nil
+}
+
+// GetSessionRecord makes a HTTP request to ShellHub API server to check if the sessions to the namespace's devices are
+// recorded.
+func (c *client) GetSessionRecord(tenant string) (bool, error) {
+	var recorded bool
+
+	resp, err := c.http.R().
+		SetResult(&recorded).
+		Get(buildURL(c, fmt.Sprintf("/internal/namespaces/%s/session-record", tenant)))
+	if err != nil {
+		return false, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return false, ErrUnknown
+	}
+
+	return recorded, nil
+}
+
+// EvaluateAuthAttempt makes a HTTP request to ShellHub API server to check if a SSH authentication attempt is banned
+// or must be delayed.
+func (c *client) EvaluateAuthAttempt(attempt request.AuthAttempt) (*models.AuthEvaluation, error) {
+	evaluation := new(models.AuthEvaluation)
+
+	resp, err := c.http.R().
+		SetBody(attempt).
+		SetResult(evaluation).
+		Post(buildURL(c, "/internal/auth/ssh/attempts/evaluate"))
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, ErrUnknown
+	}
+
+	return evaluation, nil
+}
+
+// RecordAuthFailure makes a HTTP request to ShellHub API server to record a failed SSH authentication.
+func (c *client) RecordAuthFailure(failure request.AuthFailure) error {
+	resp, err := c.http.R().
+		SetBody(failure).
+		Post(buildURL(c, "/internal/auth/ssh/failures"))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ErrUnknown
+	}
+
+	return nil
+}
+
+// CreateAccessRequest makes a HTTP request to ShellHub API server to request the access of a session to a device. It
+// returns nil when the device's connections do not need to be approved.
+func (c *client) CreateAccessRequest(req request.AccessRequestCreate) (*models.AccessRequest, error) {
+	access := new(models.AccessRequest)
+
+	resp, err := c.http.R().
+		SetBody(req).
+		SetResult(access).
+		Post(buildURL(c, "/internal/access-requests"))
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return access, nil
+	case http.StatusNoContent:
+		return nil, nil
+	default:
+		return nil, ErrUnknown
+	}
+}
+
+// GetAccessRequest makes a HTTP request to ShellHub API server to get an access request.
+func (c *client) GetAccessRequest(id string) (*models.AccessRequest, error) {
+	access := new(models.AccessRequest)
+
+	resp, err := c.http.R().
+		SetResult(access).
+		Get(buildURL(c, fmt.Sprintf("/internal/access-requests/%s", id)))
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return access, nil
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, ErrUnknown
+	}
+}
+
 func (c *client) CreatePrivateKey() (*models.PrivateKey, error) {
 	var privKey *models.PrivateKey
 	_, err := c.http.R().
@@ -193,6 +410,39 @@ func (c *client) SessionAsAuthenticated(uid string) []error {
 	return errors
 }
 
+// RecordSessionActivity makes a HTTP request to ShellHub API server to record what was done in a non-interactive
+// session.
+func (c *client) RecordSessionActivity(uid string, activity *models.SessionActivity) error {
+	resp, err := c.http.R().
+		SetBody(activity).
+		Post(buildURL(c, fmt.Sprintf("/internal/sessions/%s/activity", uid)))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ErrUnknown
+	}
+
+	return nil
+}
+
+// SetSessionClosure makes a HTTP request to ShellHub API server to record why the SSH server closed a session.
+func (c *client) SetSessionClosure(uid, reason string) error {
+	resp, err := c.http.R().
+		SetBody(map[string]string{"reason": reason}).
+		Post(buildURL(c, fmt.Sprintf("/internal/sessions/%s/closure", uid)))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ErrUnknown
+	}
+
+	return nil
+}
+
 func (c *client) FinishSession(uid string) []error {
 	var errors []error
 	_, err := c.http.R().
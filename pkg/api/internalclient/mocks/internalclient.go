@@ -4,6 +4,8 @@ package mocks
 
 import (
 	models "github.com/shellhub-io/shellhub/pkg/models"
+
+	request "github.com/shellhub-io/shellhub/pkg/api/request"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -42,6 +44,29 @@ func (_m *Client) BillingEvaluate(tenantID string) (*models.Namespace, int, erro
 	return r0, r1, r2
 }
 
+// CreateAccessRequest provides a mock function with given fields: req
+func (_m *Client) CreateAccessRequest(req request.AccessRequestCreate) (*models.AccessRequest, error) {
+	ret := _m.Called(req)
+
+	var r0 *models.AccessRequest
+	if rf, ok := ret.Get(0).(func(request.AccessRequestCreate) *models.AccessRequest); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(request.AccessRequestCreate) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePrivateKey provides a mock function with given fields:
 func (_m *Client) CreatePrivateKey() (*models.PrivateKey, error) {
 	ret := _m.Called()
@@ -118,6 +143,73 @@ func (_m *Client) DevicesOffline(id string) error {
 	return r0
 }
 
+// EvaluateAgentForwarding provides a mock function with given fields: tenant
+func (_m *Client) EvaluateAgentForwarding(tenant string) (bool, error) {
+	ret := _m.Called(tenant)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(tenant)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvaluateAuthAttempt provides a mock function with given fields: attempt
+func (_m *Client) EvaluateAuthAttempt(attempt request.AuthAttempt) (*models.AuthEvaluation, error) {
+	ret := _m.Called(attempt)
+
+	var r0 *models.AuthEvaluation
+	if rf, ok := ret.Get(0).(func(request.AuthAttempt) *models.AuthEvaluation); ok {
+		r0 = rf(attempt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AuthEvaluation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(request.AuthAttempt) error); ok {
+		r1 = rf(attempt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EvaluateCertificate provides a mock function with given fields: cert, dev, username, address
+func (_m *Client) EvaluateCertificate(cert []byte, dev *models.Device, username string, address string) (*models.CertificateEvaluation, error) {
+	ret := _m.Called(cert, dev, username, address)
+
+	var r0 *models.CertificateEvaluation
+	if rf, ok := ret.Get(0).(func([]byte, *models.Device, string, string) *models.CertificateEvaluation); ok {
+		r0 = rf(cert, dev, username, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CertificateEvaluation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte, *models.Device, string, string) error); ok {
+		r1 = rf(cert, dev, username, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EvaluateKey provides a mock function with given fields: fingerprint, dev, username
 func (_m *Client) EvaluateKey(fingerprint string, dev *models.Device, username string) (bool, error) {
 	ret := _m.Called(fingerprint, dev, username)
@@ -139,6 +231,27 @@ func (_m *Client) EvaluateKey(fingerprint string, dev *models.Device, username s
 	return r0, r1
 }
 
+// EvaluateReverseForwarding provides a mock function with given fields: tenant, port
+func (_m *Client) EvaluateReverseForwarding(tenant string, port uint32) (bool, error) {
+	ret := _m.Called(tenant, port)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string, uint32) bool); ok {
+		r0 = rf(tenant, port)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, uint32) error); ok {
+		r1 = rf(tenant, port)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FinishSession provides a mock function with given fields: uid
 func (_m *Client) FinishSession(uid string) []error {
 	ret := _m.Called(uid)
@@ -169,6 +282,29 @@ func (_m *Client) FirewallEvaluate(lookup map[string]string) error {
 	return r0
 }
 
+// GetAccessRequest provides a mock function with given fields: id
+func (_m *Client) GetAccessRequest(id string) (*models.AccessRequest, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.AccessRequest
+	if rf, ok := ret.Get(0).(func(string) *models.AccessRequest); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccessRequest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDevice provides a mock function with given fields: uid
 func (_m *Client) GetDevice(uid string) (*models.Device, error) {
 	ret := _m.Called(uid)
@@ -215,6 +351,50 @@ func (_m *Client) GetPublicKey(fingerprint string, tenant string) (*models.Publi
 	return r0, r1
 }
 
+// GetSessionRecord provides a mock function with given fields: tenant
+func (_m *Client) GetSessionRecord(tenant string) (bool, error) {
+	ret := _m.Called(tenant)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(tenant)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSessionTimeouts provides a mock function with given fields: tenant
+func (_m *Client) GetSessionTimeouts(tenant string) (*models.SessionTimeouts, error) {
+	ret := _m.Called(tenant)
+
+	var r0 *models.SessionTimeouts
+	if rf, ok := ret.Get(0).(func(string) *models.SessionTimeouts); ok {
+		r0 = rf(tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SessionTimeouts)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tenant)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // KeepAliveSession provides a mock function with given fields: uid
 func (_m *Client) KeepAliveSession(uid string) []error {
 	ret := _m.Called(uid)
@@ -231,6 +411,27 @@ func (_m *Client) KeepAliveSession(uid string) []error {
 	return r0
 }
 
+// KeyRegistered provides a mock function with given fields: fingerprint
+func (_m *Client) KeyRegistered(fingerprint string) (bool, error) {
+	ret := _m.Called(fingerprint)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(fingerprint)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(fingerprint)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListDevices provides a mock function with given fields:
 func (_m *Client) ListDevices() ([]models.Device, error) {
 	ret := _m.Called()
@@ -282,11 +483,39 @@ func (_m *Client) LookupDevice() {
 	_m.Called()
 }
 
+// RecordAuthFailure provides a mock function with given fields: failure
+func (_m *Client) RecordAuthFailure(failure request.AuthFailure) error {
+	ret := _m.Called(failure)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(request.AuthFailure) error); ok {
+		r0 = rf(failure)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RecordSession provides a mock function with given fields: session, recordURL
 func (_m *Client) RecordSession(session *models.SessionRecorded, recordURL string) {
 	_m.Called(session, recordURL)
 }
 
+// RecordSessionActivity provides a mock function with given fields: uid, activity
+func (_m *Client) RecordSessionActivity(uid string, activity *models.SessionActivity) error {
+	ret := _m.Called(uid, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *models.SessionActivity) error); ok {
+		r0 = rf(uid, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ReportDelete provides a mock function with given fields: ns
 func (_m *Client) ReportDelete(ns *models.Namespace) (int, error) {
 	ret := _m.Called(ns)
@@ -344,3 +573,17 @@ func (_m *Client) SessionAsAuthenticated(uid string) []error {
 
 	return r0
 }
+
+// SetSessionClosure provides a mock function with given fields: uid, reason
+func (_m *Client) SetSessionClosure(uid string, reason string) error {
+	ret := _m.Called(uid, reason)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(uid, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
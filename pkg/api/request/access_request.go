@@ -0,0 +1,38 @@
+package request
+
+// AccessRequestParam is a structure to represent and validate an access request ID as path param.
+type AccessRequestParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// AccessRequestList is the structure to represent the request data for list access requests endpoint.
+type AccessRequestList struct {
+	// Status filters the requests by their status, listing all of them when empty.
+	Status string `query:"status" validate:"omitempty,oneof=pending approved denied"`
+}
+
+// AccessRequestCreate is the structure to represent the request data for create access request endpoint.
+type AccessRequestCreate struct {
+	DeviceUID  string `json:"device_uid" validate:"required"`
+	SessionUID string `json:"session_uid" validate:"required"`
+	Username   string `json:"username" validate:"required"`
+	IPAddress  string `json:"ip_address" validate:"required"`
+}
+
+// AccessRequestGet is the structure to represent the request data for get access request endpoint.
+type AccessRequestGet struct {
+	AccessRequestParam
+}
+
+// AccessRequestApprove is the structure to represent the request data for approve access request endpoint.
+type AccessRequestApprove struct {
+	AccessRequestParam
+	// Duration is how long, in minutes and up to a day, the approval grants the same user, from the same address,
+	// access to the device without a new request. When zero, only the waiting session is approved.
+	Duration int `json:"duration" validate:"min=0,max=1440"`
+}
+
+// AccessRequestDeny is the structure to represent the request data for deny access request endpoint.
+type AccessRequestDeny struct {
+	AccessRequestParam
+}
@@ -0,0 +1,30 @@
+package request
+
+import (
+	"time"
+)
+
+// APIKeyParam is a structure to represent and validate an API key ID as path param.
+type APIKeyParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// APIKeyCreate is the structure to represent the request data for create API key endpoint.
+type APIKeyCreate struct {
+	Name string `json:"name" validate:"required,min=3,max=64"`
+	RoleBody
+	// ExpiresAt is the optional time, in RFC3339 format, when the key stops being accepted.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// APIKeyUpdate is the structure to represent the request data for update API key endpoint.
+type APIKeyUpdate struct {
+	APIKeyParam
+	Name string `json:"name" validate:"omitempty,min=3,max=64"`
+	Role string `json:"role" validate:"omitempty,oneof=administrator operator observer"`
+}
+
+// APIKeyDelete is the structure to represent the request data for delete API key endpoint.
+type APIKeyDelete struct {
+	APIKeyParam
+}
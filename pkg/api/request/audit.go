@@ -0,0 +1,21 @@
+package request
+
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+)
+
+// AuditList is the structure to represent the request data for list audit logs endpoint.
+type AuditList struct {
+	paginator.Query
+	// Action filters the entries by the audited action, e.g. "device.delete".
+	Action string `query:"action"`
+	// Actor filters the entries by the user's ID who performed the action.
+	Actor string `query:"actor"`
+	// Target filters the entries by the resource's ID affected by the action.
+	Target string `query:"target"`
+	// From and To filter the entries by its creation time, in RFC3339 format.
+	From time.Time `query:"from"`
+	To   time.Time `query:"to"`
+}
@@ -9,3 +9,32 @@ type AuthTokenGet struct {
 type AuthTokenSwap struct {
 	TenantParam
 }
+
+// AuthAttempt is the structure to represent a SSH authentication attempt to be limited.
+type AuthAttempt struct {
+	// IPAddress is the address the attempt comes from.
+	IPAddress string `json:"ip_address"`
+	// Device is the target's SSHID, e.g. `namespace.device`, empty when the target is unknown.
+	Device   string `json:"device"`
+	Username string `json:"username"`
+}
+
+// AuthFailure is the structure to represent the request data for record SSH authentication failure endpoint.
+type AuthFailure struct {
+	AuthAttempt
+	// DeviceUID is the target device's UID, empty when the device was not found.
+	DeviceUID string `json:"device_uid"`
+	// SessionUID is the session whose authentication failed, empty when it was not created yet.
+	SessionUID string `json:"session_uid"`
+	Reason     string `json:"reason" validate:"required,oneof=device_not_found public_key_rejected device_rejected"`
+}
+
+// AuthBanIDParam is a structure to represent and validate an authentication ban ID as path param.
+type AuthBanIDParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// AuthBanLift is the structure to represent the request data for lift authentication ban endpoint.
+type AuthBanLift struct {
+	AuthBanIDParam
+}
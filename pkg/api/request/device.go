@@ -1,5 +1,7 @@
 package request
 
+import "github.com/shellhub-io/shellhub/pkg/models"
+
 // DeviceParam is a structure to represent and validate a device UID as path param.
 type DeviceParam struct {
 	UID string `param:"uid" validate:"required"`
@@ -88,6 +90,26 @@ type DeviceAuth struct {
 	Identity  *DeviceIdentity `json:"identity,omitempty" validate:"required_without=Hostname,omitempty"`
 	PublicKey string          `json:"public_key" validate:"required"`
 	TenantID  string          `json:"tenant_id" validate:"required"`
+	// Attributes seeds the device's attributes when it is registered. It does not take part in the device's UID.
+	Attributes map[string]string `json:"attributes,omitempty" validate:"omitempty,attributes" hash:"-"`
+	// HostKeyFingerprint is the SHA256 fingerprint of the agent's SSH host key. It does not take part in the device's UID.
+	HostKeyFingerprint string `json:"host_key_fingerprint,omitempty" validate:"omitempty,startswith=SHA256:" hash:"-"`
+}
+
+// DeviceUpdateAttributes is the structure to represent the request data for device update attributes endpoint.
+type DeviceUpdateAttributes struct {
+	DeviceParam
+	Attributes map[string]string `json:"attributes" validate:"required,attributes"`
+}
+
+// DeviceResetHostKey is the structure to represent the request data for the device's host key reset endpoint.
+type DeviceResetHostKey struct {
+	DeviceParam
+}
+
+// DeviceHealth is the structure to represent the request data for the device health report endpoint.
+type DeviceHealth struct {
+	models.DeviceHealth
 }
 
 type DeviceGetPublicURL struct {
@@ -100,3 +122,26 @@ type DeviceUpdate struct {
 	Name      *string `json:"name"`
 	PublicURL *bool   `json:"public_url"`
 }
+
+// DeviceBulk is the structure to represent the request data for the device bulk operation endpoint.
+//
+// The devices are selected either by their UIDs or by a base64 encoded filter, the same accepted by the device list
+// endpoint.
+type DeviceBulk struct {
+	UIDs      []string `json:"uids" validate:"required_without=Filter,excluded_with=Filter,max=1000,unique"`
+	Filter    string   `json:"filter" validate:"required_without=UIDs,excluded_with=UIDs,omitempty,base64"`
+	Action    string   `json:"action" validate:"required,oneof=accept reject remove add_tag remove_tag public_url"`
+	Tag       string   `json:"tag" validate:"required_if=Action add_tag,required_if=Action remove_tag,omitempty,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	PublicURL *bool    `json:"public_url" validate:"required_if=Action public_url"`
+}
+
+// DeviceInventory is the structure to represent the request data for the device inventory endpoint.
+//
+// Host is the SSH gateway's address as reached by the inventory's consumer. When empty, the address the request was
+// sent to is used.
+type DeviceInventory struct {
+	Format string `query:"format" validate:"omitempty,oneof=ansible ssh_config"`
+	Host   string `query:"host" validate:"omitempty,hostname_rfc1123|ip"`
+	Port   int    `query:"port" validate:"omitempty,min=1,max=65535"`
+	User   string `query:"user" validate:"omitempty,max=32,printascii,excludesall=@: "`
+}
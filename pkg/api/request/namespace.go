@@ -1,5 +1,7 @@
 package request
 
+import "github.com/shellhub-io/shellhub/pkg/models"
+
 // TenantParam is a structure to represent and validate a namespace tenant as path param.
 type TenantParam struct {
 	Tenant string `param:"tenant" validate:"required,min=3,max=255,ascii,excludes=/@&:"`
@@ -62,3 +64,71 @@ type SessionEditRecordStatus struct {
 	TenantParam
 	SessionRecord bool `json:"session_record"`
 }
+
+// NamespaceEditMFA is the structure to represent the request data for edit namespace MFA requirement endpoint.
+type NamespaceEditMFA struct {
+	TenantParam
+	Required bool `json:"required"`
+}
+
+// NamespaceEditReverseForwarding is the structure to represent the request data for edit namespace remote port
+// forwarding policy endpoint.
+type NamespaceEditReverseForwarding struct {
+	TenantParam
+	Enabled bool                           `json:"enabled"`
+	Rules   []models.ReverseForwardingRule `json:"rules" validate:"max=32,dive"`
+}
+
+// NamespaceEvaluateReverseForwarding is the structure to represent the request data for evaluate namespace remote port
+// forwarding policy endpoint.
+type NamespaceEvaluateReverseForwarding struct {
+	TenantParam
+	Port uint32 `query:"port" validate:"max=65535"`
+}
+
+// NamespaceEditAgentForwarding is the structure to represent the request data for edit namespace agent forwarding
+// endpoint.
+type NamespaceEditAgentForwarding struct {
+	TenantParam
+	Enabled bool `json:"enabled"`
+}
+
+// NamespaceEvaluateAgentForwarding is the structure to represent the request data for evaluate namespace agent
+// forwarding endpoint.
+type NamespaceEvaluateAgentForwarding struct {
+	TenantParam
+}
+
+// NamespaceEditSessionTimeouts is the structure to represent the request data for edit namespace session timeouts
+// endpoint.
+type NamespaceEditSessionTimeouts struct {
+	TenantParam
+	// IdleTimeout is limited to a day and MaxDuration to a week, both in minutes.
+	IdleTimeout int `json:"idle_timeout" validate:"min=0,max=1440"`
+	MaxDuration int `json:"max_duration" validate:"min=0,max=10080"`
+}
+
+// NamespaceGetSessionTimeouts is the structure to represent the request data for get namespace session timeouts
+// endpoint.
+type NamespaceGetSessionTimeouts struct {
+	TenantParam
+}
+
+// NamespaceGetSessionRecord is the structure to represent the request data for get namespace session record endpoint.
+type NamespaceGetSessionRecord struct {
+	TenantParam
+}
+
+// NamespaceEditProtectedTags is the structure to represent the request data for edit namespace protected tags
+// endpoint.
+type NamespaceEditProtectedTags struct {
+	TenantParam
+	Tags []string `json:"tags" validate:"max=32,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+}
+
+// NamespaceEditAgentUpdate is the structure to represent the request data for edit namespace agent update endpoint.
+type NamespaceEditAgentUpdate struct {
+	TenantParam
+	Hold    bool   `json:"hold"`
+	Version string `json:"version" validate:"omitempty,version"`
+}
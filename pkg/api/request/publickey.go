@@ -1,5 +1,7 @@
 package request
 
+import "github.com/shellhub-io/shellhub/pkg/models"
+
 // FingerprintParam is a structure to represent and validate a public key fingerprint as path param.
 type FingerprintParam struct {
 	Fingerprint string `param:"fingerprint" validate:"required"`
@@ -69,3 +71,34 @@ type PublicKeyAuth struct {
 	Fingerprint string `json:"fingerprint" validate:"required"`
 	Data        string `json:"data" validate:"required"`
 }
+
+// UserCACreate is the structure to represent the request data for create user certificate authority endpoint.
+type UserCACreate struct {
+	Name string `json:"name" validate:"required,max=255"`
+	// Data is the authority's public key in the authorized keys format.
+	Data []byte `json:"data" validate:"required"`
+}
+
+// UserCADelete is the structure to represent the request data for delete user certificate authority endpoint.
+type UserCADelete struct {
+	FingerprintParam
+}
+
+// CertificateEvaluate is the structure to represent the request data for evaluate certificate endpoint.
+type CertificateEvaluate struct {
+	Username string `param:"username" validate:"required"`
+	// Certificate is the user certificate in the authorized keys format.
+	Certificate []byte        `json:"certificate" validate:"required"`
+	Device      models.Device `json:"device"`
+	// Address is the address the user is connecting from.
+	Address string `json:"address" validate:"required"`
+}
+
+// CertificateAuth is the structure to represent the request data for certificate auth endpoint.
+type CertificateAuth struct {
+	// Certificate is the user certificate in the authorized keys format.
+	Certificate []byte `json:"certificate" validate:"required"`
+	Username    string `json:"username" validate:"required"`
+	// Address is the address the user is connecting from.
+	Address string `json:"address" validate:"required"`
+}
@@ -1,5 +1,7 @@
 package request
 
+import "github.com/shellhub-io/shellhub/pkg/models"
+
 // SessionIDParam is a structure to represent and validate a session UID as path param.
 type SessionIDParam struct {
 	// UID is the session's UID.
@@ -25,6 +27,8 @@ type SessionCreate struct {
 	IPAddress string `json:"ip_address" validate:"required"`
 	Type      string `json:"type" validate:"required"`
 	Term      string `json:"term" validate:""`
+	// AccessRequest is the approved request the session is waiting for, when its device has a protected tag.
+	AccessRequest string `json:"access_request"`
 }
 
 // SessionFinish is the structure to represent the request data for finish session endpoint.
@@ -36,3 +40,47 @@ type SessionFinish struct {
 type SessionKeepAlive struct {
 	SessionIDParam
 }
+
+// SessionClosure is the structure to represent the request data for set session closure reason endpoint.
+type SessionClosure struct {
+	SessionIDParam
+	Reason string `json:"reason" validate:"required,oneof=idle_timeout max_duration"`
+}
+
+// SessionRecord is the structure to represent the request data for record session endpoint.
+type SessionRecord struct {
+	SessionIDParam
+	Message string `json:"message"`
+	Width   int    `json:"width"`
+	Height  int    `json:"height"`
+}
+
+// SessionRecordPlay is the structure to represent the request data for play session endpoint.
+type SessionRecordPlay struct {
+	SessionIDParam
+}
+
+// SessionRecordDelete is the structure to represent the request data for delete session record endpoint.
+type SessionRecordDelete struct {
+	SessionIDParam
+}
+
+// SessionRecordExport is the structure to represent the request data for export session record endpoint.
+type SessionRecordExport struct {
+	SessionIDParam
+	// Format is the file format of the exported record. Only "asciicast", the asciinema v2 format, is supported.
+	Format string `query:"format" validate:"required,oneof=asciicast"`
+}
+
+// SessionShadow is the structure to represent the request data for shadow session endpoint.
+type SessionShadow struct {
+	SessionIDParam
+	// Writable allows the member to type on the session, instead of only watching it.
+	Writable bool `json:"writable"`
+}
+
+// SessionActivityRecord is the structure to represent the request data for record session activity endpoint.
+type SessionActivityRecord struct {
+	SessionIDParam
+	models.SessionActivity
+}
@@ -25,3 +25,18 @@ type UserAuth struct {
 	Username string `json:"username" validate:"required"`
 	Password string `json:"password" validate:"required"`
 }
+
+// UserMFACode is the structure to represent the request body for the endpoints that confirm an action over the user's
+// MFA with a TOTP code.
+type UserMFACode struct {
+	// Code is a TOTP code or, when disabling MFA, one of the recovery codes.
+	Code string `json:"code" validate:"required"`
+}
+
+// UserMFAAuth is the structure to represent the request body for the user MFA auth endpoint.
+type UserMFAAuth struct {
+	// Token is the "mfa pending" token returned by the user auth endpoint.
+	Token string `json:"token" validate:"required"`
+	// Code is a TOTP code or one of the recovery codes.
+	Code string `json:"code" validate:"required"`
+}
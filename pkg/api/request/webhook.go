@@ -0,0 +1,36 @@
+package request
+
+// WebhookParam is a structure to represent and validate a webhook ID as path param.
+type WebhookParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// WebhookCreate is the structure to represent the request data for create webhook endpoint.
+type WebhookCreate struct {
+	URL    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events" validate:"required,min=1"`
+}
+
+// WebhookUpdate is the structure to represent the request data for update webhook endpoint.
+type WebhookUpdate struct {
+	WebhookParam
+	URL    string   `json:"url" validate:"omitempty,url"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+}
+
+// WebhookDelete is the structure to represent the request data for delete webhook endpoint.
+type WebhookDelete struct {
+	WebhookParam
+}
+
+// WebhookDeliveryList is the structure to represent the request data for list webhook's deliveries endpoint.
+type WebhookDeliveryList struct {
+	WebhookParam
+}
+
+// WebhookRedeliver is the structure to represent the request data for redeliver webhook's delivery endpoint.
+type WebhookRedeliver struct {
+	WebhookParam
+	Delivery string `param:"delivery" validate:"required"`
+}
@@ -0,0 +1,13 @@
+package response
+
+import (
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// APIKeyCreate is the structure to represent the response data for create API key endpoint.
+//
+// Key is the API key's secret in plain text. It is returned only on creation and cannot be recovered later.
+type APIKeyCreate struct {
+	models.APIKey
+	Key string `json:"key"`
+}
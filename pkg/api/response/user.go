@@ -0,0 +1,10 @@
+package response
+
+// UserMFAGenerate is the structure to represent the response data for generate user MFA endpoint.
+//
+// RecoveryCodes are returned only when the secret is generated and cannot be recovered later.
+type UserMFAGenerate struct {
+	Secret        string   `json:"secret"`
+	URI           string   `json:"uri"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
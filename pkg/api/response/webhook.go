@@ -0,0 +1,14 @@
+package response
+
+import (
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// WebhookCreate is the structure to represent the response data for create webhook endpoint.
+//
+// Secret is the key used to sign the webhook's deliveries. It is returned only on creation and cannot be recovered
+// later.
+type WebhookCreate struct {
+	models.Webhook
+	Secret string `json:"secret"`
+}
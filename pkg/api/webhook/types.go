@@ -1,5 +1,10 @@
 package webhook
 
+import (
+	"encoding/json"
+	"time"
+)
+
 // Webhook request headers.
 const (
 	// A unique ID that identifies the delivered webhook.
@@ -16,6 +21,57 @@ const (
 	WebhookIncomingConnectionEvent = "incoming_connection"
 )
 
+// Namespace's webhook events.
+const (
+	EventDeviceCreated  = "device.created"
+	EventDeviceAccepted = "device.accepted"
+	EventDeviceRejected = "device.rejected"
+	EventDeviceRemoved  = "device.removed"
+	EventDeviceOnline   = "device.online"
+	EventDeviceOffline  = "device.offline"
+
+	EventSessionStarted       = "session.started"
+	EventSessionAuthenticated = "session.authenticated"
+	EventSessionClosed        = "session.closed"
+
+	EventAccessRequested = "access_request.created"
+)
+
+// Events lists the events a namespace's webhook can subscribe to. There is no firewall event, as the open source API
+// has no firewall rules.
+var Events = []string{
+	EventDeviceCreated,
+	EventDeviceAccepted,
+	EventDeviceRejected,
+	EventDeviceRemoved,
+	EventDeviceOnline,
+	EventDeviceOffline,
+	EventSessionStarted,
+	EventSessionAuthenticated,
+	EventSessionClosed,
+	EventAccessRequested,
+}
+
+// ValidEvent checks if event is one of the Events.
+func ValidEvent(event string) bool {
+	for _, e := range Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Event is the body payload delivered to a namespace's webhooks. Data holds the resource the event refers to, like
+// the models.Device or the models.Session.
+type Event struct {
+	Event     string          `json:"event"`
+	TenantID  string          `json:"tenant_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
 // IncomingConnectionWebhookRequest is the body payload.
 type IncomingConnectionWebhookRequest struct {
 	Username  string `json:"username"`
@@ -1,6 +1,8 @@
 package webhook
 
 import (
+	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -21,6 +23,7 @@ var (
 	ErrConnectionFailed = errors.New("connection failed")
 	ErrForbidden        = errors.New("not allowed")
 	ErrUnknown          = errors.New("unknown error")
+	ErrUnsuccessful     = errors.New("unsuccessful response")
 )
 
 type Webhook interface {
@@ -110,3 +113,40 @@ func buildURL(w *webhookClient, uri string) string {
 
 	return u.String()
 }
+
+// Sign returns the hexadecimal HMAC SHA256 of a body, what is sent in WebhookSignatureHeader so the receiver can check
+// the body was sent by ShellHub.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Send posts an event's body to a namespace's webhook, signed with the webhook's secret.
+//
+// It returns the response's status code and an error when the request fails or the status is not successful.
+func Send(ctx context.Context, client *http.Client, url, id, event, secret string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookIDHeader, id)
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookSignatureHeader, Sign(secret, body))
+
+	res, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return res.StatusCode, fmt.Errorf("%w: %s", ErrUnsuccessful, res.Status)
+	}
+
+	return res.StatusCode, nil
+}
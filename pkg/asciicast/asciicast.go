@@ -0,0 +1,97 @@
+// Package asciicast encodes recorded sessions in the asciinema v2 file format, so they can be archived and replayed
+// offline by standard tools.
+//
+// See https://docs.asciinema.org/manual/asciicast/v2/ for the format specification.
+package asciicast
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// Version is the asciicast format version written by the encoder.
+const Version = 2
+
+// ContentType is the media type of an asciicast file.
+const ContentType = "application/x-asciicast"
+
+const (
+	// EventOutput is the event type of data written to the terminal.
+	EventOutput = "o"
+	// EventResize is the event type of a terminal resize. Its data is formatted as "{width}x{height}".
+	EventResize = "r"
+)
+
+// Header is the first line of an asciicast file.
+type Header struct {
+	Version   int   `json:"version"`
+	Width     int   `json:"width"`
+	Height    int   `json:"height"`
+	Timestamp int64 `json:"timestamp,omitempty"`
+}
+
+// Encode writes the frames of a recorded session to w as an asciicast file.
+//
+// The header is built from the first frame's size and time, and each frame becomes an output event at the time elapsed
+// since the first one. When a frame's size differs from the previous one, a resize event is written before it, a
+// dimension missing from a frame keeping its previous value.
+func Encode(w io.Writer, frames []models.RecordedSession) error {
+	if len(frames) == 0 {
+		return nil
+	}
+
+	first := frames[0]
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(Header{
+		Version:   Version,
+		Width:     first.Width,
+		Height:    first.Height,
+		Timestamp: first.Time.Unix(),
+	}); err != nil {
+		return err
+	}
+
+	width, height := first.Width, first.Height
+	for _, frame := range frames {
+		elapsed := seconds(frame.Time.Sub(first.Time).Seconds())
+
+		w, h := width, height
+		if frame.Width != 0 {
+			w = frame.Width
+		}
+
+		if frame.Height != 0 {
+			h = frame.Height
+		}
+
+		if w != width || h != height {
+			width, height = w, h
+
+			if err := enc.Encode([]interface{}{elapsed, EventResize, fmt.Sprintf("%dx%d", width, height)}); err != nil {
+				return err
+			}
+		}
+
+		if err := enc.Encode([]interface{}{elapsed, EventOutput, frame.Message}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// seconds rounds a time in seconds to microseconds, avoiding float noise in the events' times. Times before the first
+// frame, what would only happen with unordered frames, are clamped to zero.
+func seconds(s float64) float64 {
+	if s < 0 {
+		return 0
+	}
+
+	return math.Round(s*1e6) / 1e6
+}
@@ -0,0 +1,71 @@
+package asciicast
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+
+	cases := []struct {
+		description string
+		frames      []models.RecordedSession
+		expected    string
+	}{
+		{
+			description: "writes nothing when there are no frames",
+			frames:      nil,
+			expected:    "",
+		},
+		{
+			description: "writes the header and output events",
+			frames: []models.RecordedSession{
+				{Message: "$ ", Time: start, Width: 80, Height: 24},
+				{Message: "ls\r\n", Time: start.Add(1500 * time.Millisecond), Width: 80, Height: 24},
+			},
+			expected: `{"version":2,"width":80,"height":24,"timestamp":1600000000}
+[0,"o","$ "]
+[1.5,"o","ls\r\n"]
+`,
+		},
+		{
+			description: "writes a resize event when the size changes",
+			frames: []models.RecordedSession{
+				{Message: "$ ", Time: start, Width: 80, Height: 24},
+				{Message: "\u001b[H", Time: start.Add(2 * time.Second), Width: 120, Height: 40},
+			},
+			expected: `{"version":2,"width":80,"height":24,"timestamp":1600000000}
+[0,"o","$ "]
+[2,"r","120x40"]
+[2,"o","\u001b[H"]
+`,
+		},
+		{
+			description: "keeps the dimension missing from a frame",
+			frames: []models.RecordedSession{
+				{Message: "$ ", Time: start, Width: 80, Height: 24},
+				{Message: "ls\r\n", Time: start.Add(time.Second), Width: 0, Height: 24},
+				{Message: "\u001b[H", Time: start.Add(2 * time.Second), Width: 0, Height: 40},
+			},
+			expected: `{"version":2,"width":80,"height":24,"timestamp":1600000000}
+[0,"o","$ "]
+[1,"o","ls\r\n"]
+[2,"r","80x40"]
+[2,"o","\u001b[H"]
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var buffer bytes.Buffer
+			assert.NoError(t, Encode(&buffer, tc.frames))
+			assert.Equal(t, tc.expected, buffer.String())
+		})
+	}
+}
@@ -9,4 +9,7 @@ type Cache interface {
 	Get(ctx context.Context, key string, value interface{}) error
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
+	// Incr atomically increments the counter at key, setting its expire time to ttl, and returns the incremented
+	// value. A missing counter starts from zero. The counter is kept as a string, so it is read with a *string value.
+	Incr(ctx context.Context, key string, ttl time.Duration) (int, error)
 }
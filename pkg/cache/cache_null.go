@@ -24,3 +24,7 @@ func (n *nullCache) Set(ctx context.Context, key string, value interface{}, ttl
 func (n *nullCache) Delete(ctx context.Context, key string) error {
 	return nil
 }
+
+func (n *nullCache) Incr(ctx context.Context, key string, ttl time.Duration) (int, error) {
+	return 1, nil
+}
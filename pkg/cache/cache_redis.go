@@ -9,7 +9,8 @@ import (
 )
 
 type redisCache struct {
-	cache *rediscache.Cache
+	client *redis.Client
+	cache  *rediscache.Cache
 }
 
 var _ Cache = &redisCache{}
@@ -21,9 +22,12 @@ func NewRedisCache(uri string) (Cache, error) {
 		return nil, err
 	}
 
+	client := redis.NewClient(opt)
+
 	return &redisCache{
+		client: client,
 		cache: rediscache.New(&rediscache.Options{
-			Redis: redis.NewClient(opt),
+			Redis: client,
 		}),
 	}, nil
 }
@@ -52,3 +56,18 @@ func (c *redisCache) Delete(ctx context.Context, key string) error {
 
 	return c.cache.Delete(ctx, key)
 }
+
+// Incr increments the counter at key and sets its expire time, in a transaction.
+func (c *redisCache) Incr(ctx context.Context, key string, ttl time.Duration) (int, error) {
+	var incr *redis.IntCmd
+	if _, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, ttl)
+
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return int(incr.Val()), nil
+}
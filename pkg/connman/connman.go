@@ -40,6 +40,12 @@ func (m *ConnectionManager) Set(key string, conn net.Conn) {
 
 				continue
 			case <-dialer.Done():
+				m.lock.Lock()
+				if m.dialers[key] == dialer {
+					delete(m.dialers, key)
+				}
+				m.lock.Unlock()
+
 				m.DialerDoneCallback(key, dialer)
 
 				return
@@ -60,3 +66,11 @@ func (m *ConnectionManager) Dial(ctx context.Context, key string) (net.Conn, err
 
 	return dialer.Dial(ctx)
 }
+
+// Size returns the number of connections held by the manager.
+func (m *ConnectionManager) Size() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return len(m.dialers)
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
@@ -34,6 +35,7 @@ type Tunnel struct {
 	ConnectionHandler func(*http.Request) (string, error)
 	CloseHandler      func(string)
 	KeepAliveHandler  func(string)
+	DialHandler       func(string, time.Duration, error)
 	connman           *connman.ConnectionManager
 	id                chan string
 	online            chan bool
@@ -50,6 +52,8 @@ func NewTunnel(connectionPath, dialerPath string) *Tunnel {
 		},
 		KeepAliveHandler: func(string) {
 		},
+		DialHandler: func(string, time.Duration, error) {
+		},
 		connman: connman.New(),
 		id:      make(chan string),
 		online:  make(chan bool),
@@ -94,11 +98,21 @@ func (t *Tunnel) Router() http.Handler {
 }
 
 func (t *Tunnel) Dial(ctx context.Context, id string) (net.Conn, error) {
-	return t.connman.Dial(ctx, id)
+	start := time.Now()
+
+	conn, err := t.connman.Dial(ctx, id)
+	t.DialHandler(id, time.Since(start), err)
+
+	return conn, err
+}
+
+// Connections returns the number of connections held by the tunnel.
+func (t *Tunnel) Connections() int {
+	return t.connman.Size()
 }
 
 func (t *Tunnel) SendRequest(ctx context.Context, id string, req *http.Request) (*http.Response, error) {
-	conn, err := t.connman.Dial(ctx, id)
+	conn, err := t.Dial(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,156 @@
+// Package inventory renders the devices of a namespace as inventories for automation tools, so they can be reached
+// through the ShellHub gateway without keeping a hand-maintained hosts file.
+//
+// See https://docs.ansible.com/ansible/latest/dev_guide/developing_inventory.html for the Ansible dynamic inventory
+// format.
+package inventory
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	// FormatAnsible is the Ansible dynamic inventory JSON format.
+	FormatAnsible = "ansible"
+	// FormatSSHConfig is the OpenSSH client configuration format.
+	FormatSSHConfig = "ssh_config"
+)
+
+// GroupUngrouped is the Ansible group of the devices without tags.
+const GroupUngrouped = "ungrouped"
+
+// Gateway is the ShellHub SSH gateway the devices are reached through.
+type Gateway struct {
+	// Host is the gateway's address, without the port.
+	Host string
+	// Port is the gateway's SSH port.
+	Port int
+	// User is the user logged in on the devices.
+	User string
+}
+
+// SSHID returns the SSHID of a device, the identifier used by the gateway to route a connection to it.
+func SSHID(device models.Device, host string) string {
+	return fmt.Sprintf("%s.%s@%s", device.Namespace, device.Name, host)
+}
+
+// Group is an Ansible inventory group.
+type Group struct {
+	Hosts    []string `json:"hosts,omitempty"`
+	Children []string `json:"children,omitempty"`
+}
+
+// HostVars are the Ansible variables of a device.
+type HostVars struct {
+	AnsibleHost string            `json:"ansible_host"`
+	AnsiblePort int               `json:"ansible_port"`
+	AnsibleUser string            `json:"ansible_user"`
+	UID         string            `json:"shellhub_uid"`
+	SSHID       string            `json:"shellhub_sshid"`
+	Platform    string            `json:"shellhub_platform"`
+	Arch        string            `json:"shellhub_arch"`
+	Online      bool              `json:"shellhub_online"`
+	Tags        []string          `json:"shellhub_tags"`
+	Attributes  map[string]string `json:"shellhub_attributes,omitempty"`
+}
+
+// Meta is the Ansible inventory's "_meta" entry, carrying the variables of every host so Ansible does not need to
+// request them host by host.
+type Meta struct {
+	HostVars map[string]HostVars `json:"hostvars"`
+}
+
+// Ansible builds the Ansible dynamic inventory of devices, grouping them by tag. The devices are named by their names,
+// which are unique in a namespace, and the ones without tags belong to the "ungrouped" group.
+//
+// The returned map is meant to be encoded as JSON.
+func Ansible(devices []models.Device, gateway Gateway) map[string]interface{} {
+	meta := Meta{HostVars: make(map[string]HostVars, len(devices))}
+	groups := make(map[string]*Group)
+	all := Group{}
+
+	add := func(name, host string) {
+		// A tag named "all" cannot be a group, as Ansible reserves the name to the group of every host.
+		if name == "all" {
+			all.Hosts = append(all.Hosts, host)
+
+			return
+		}
+
+		group, ok := groups[name]
+		if !ok {
+			group = &Group{Hosts: []string{}}
+			groups[name] = group
+		}
+
+		group.Hosts = append(group.Hosts, host)
+	}
+
+	for _, device := range devices {
+		vars := HostVars{
+			AnsibleHost: gateway.Host,
+			AnsiblePort: gateway.Port,
+			AnsibleUser: fmt.Sprintf("%s@%s.%s", gateway.User, device.Namespace, device.Name),
+			UID:         device.UID,
+			SSHID:       SSHID(device, gateway.Host),
+			Online:      device.Online,
+			Tags:        device.Tags,
+			Attributes:  device.Attributes,
+		}
+
+		if vars.Tags == nil {
+			vars.Tags = []string{}
+		}
+
+		if device.Info != nil {
+			vars.Platform = device.Info.Platform
+			vars.Arch = device.Info.Arch
+		}
+
+		meta.HostVars[device.Name] = vars
+
+		if len(device.Tags) == 0 {
+			add(GroupUngrouped, device.Name)
+		}
+
+		for _, tag := range device.Tags {
+			add(tag, device.Name)
+		}
+	}
+
+	children := make([]string, 0, len(groups))
+	for name := range groups {
+		children = append(children, name)
+	}
+
+	sort.Strings(children)
+	all.Children = children
+
+	inventory := map[string]interface{}{
+		"_meta": meta,
+		"all":   all,
+	}
+
+	for name, group := range groups {
+		inventory[name] = group
+	}
+
+	return inventory
+}
+
+// SSHConfig writes an OpenSSH client configuration to w with a host entry for each device, named by the device's name,
+// connecting to it through the gateway.
+func SSHConfig(w io.Writer, devices []models.Device, gateway Gateway) error {
+	for _, device := range devices {
+		if _, err := fmt.Fprintf(w, "Host %s\n    HostName %s\n    Port %d\n    User %s@%s.%s\n\n",
+			device.Name, gateway.Host, gateway.Port, gateway.User, device.Namespace, device.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
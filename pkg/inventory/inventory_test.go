@@ -0,0 +1,96 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var gateway = Gateway{Host: "shellhub.example.com", Port: 2222, User: "root"}
+
+var devices = []models.Device{
+	{
+		UID:       "uid1",
+		Name:      "router",
+		Namespace: "lab",
+		Online:    true,
+		Info:      &models.DeviceInfo{Platform: "docker", Arch: "amd64"},
+		Tags:      []string{"edge", "lisbon"},
+	},
+	{
+		UID:       "uid2",
+		Name:      "sensor",
+		Namespace: "lab",
+		Info:      &models.DeviceInfo{Platform: "native", Arch: "arm64"},
+		Tags:      []string{"lisbon", "all"},
+	},
+	{
+		UID:       "uid3",
+		Name:      "spare",
+		Namespace: "lab",
+	},
+}
+
+func TestAnsible(t *testing.T) {
+	data, err := json.Marshal(Ansible(devices, gateway))
+	assert.NoError(t, err)
+
+	expected := `{
+		"_meta": {
+			"hostvars": {
+				"router": {
+					"ansible_host": "shellhub.example.com",
+					"ansible_port": 2222,
+					"ansible_user": "root@lab.router",
+					"shellhub_uid": "uid1",
+					"shellhub_sshid": "lab.router@shellhub.example.com",
+					"shellhub_platform": "docker",
+					"shellhub_arch": "amd64",
+					"shellhub_online": true,
+					"shellhub_tags": ["edge", "lisbon"]
+				},
+				"sensor": {
+					"ansible_host": "shellhub.example.com",
+					"ansible_port": 2222,
+					"ansible_user": "root@lab.sensor",
+					"shellhub_uid": "uid2",
+					"shellhub_sshid": "lab.sensor@shellhub.example.com",
+					"shellhub_platform": "native",
+					"shellhub_arch": "arm64",
+					"shellhub_online": false,
+					"shellhub_tags": ["lisbon", "all"]
+				},
+				"spare": {
+					"ansible_host": "shellhub.example.com",
+					"ansible_port": 2222,
+					"ansible_user": "root@lab.spare",
+					"shellhub_uid": "uid3",
+					"shellhub_sshid": "lab.spare@shellhub.example.com",
+					"shellhub_platform": "",
+					"shellhub_arch": "",
+					"shellhub_online": false,
+					"shellhub_tags": []
+				}
+			}
+		},
+		"all": {"hosts": ["sensor"], "children": ["edge", "lisbon", "ungrouped"]},
+		"edge": {"hosts": ["router"]},
+		"lisbon": {"hosts": ["router", "sensor"]},
+		"ungrouped": {"hosts": ["spare"]}
+	}`
+
+	assert.JSONEq(t, expected, string(data))
+}
+
+func TestSSHConfig(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, SSHConfig(&buf, devices[:2], gateway))
+
+	expected := "Host router\n    HostName shellhub.example.com\n    Port 2222\n    User root@lab.router\n\n" +
+		"Host sensor\n    HostName shellhub.example.com\n    Port 2222\n    User root@lab.sensor\n\n"
+
+	assert.Equal(t, expected, buf.String())
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// Status of an access request.
+const (
+	AccessRequestPending  = "pending"
+	AccessRequestApproved = "approved"
+	AccessRequestDenied   = "denied"
+	// AccessRequestExpired is the status of a pending request that was not decided in time. It is not stored, but
+	// returned instead of the pending status.
+	AccessRequestExpired = "expired"
+)
+
+// AccessRequestTimeout is how long the SSH server waits for an access request to be decided.
+const AccessRequestTimeout = 5 * time.Minute
+
+// AccessRequest is a request to connect to a device with a namespace's protected tag, which must be approved by a
+// namespace's administrator before the connection is established.
+type AccessRequest struct {
+	ID        string `json:"id" bson:"id"`
+	TenantID  string `json:"tenant_id" bson:"tenant_id"`
+	DeviceUID UID    `json:"device_uid" bson:"device_uid"`
+	// SessionUID is the session waiting for the request to be decided.
+	SessionUID string `json:"session_uid" bson:"session_uid"`
+	// Username is the user on the device.
+	Username  string    `json:"username" bson:"username"`
+	IPAddress string    `json:"ip_address" bson:"ip_address"`
+	Status    string    `json:"status" bson:"status"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	// DecidedBy is the member who approved or denied the request.
+	DecidedBy string     `json:"decided_by,omitempty" bson:"decided_by,omitempty"`
+	DecidedAt *time.Time `json:"decided_at,omitempty" bson:"decided_at,omitempty"`
+	// GrantUntil is when the approval stops granting the same user, from the same address, access to the device
+	// without a new request. It is nil when the approval is only for the waiting session.
+	GrantUntil *time.Time `json:"grant_until,omitempty" bson:"grant_until,omitempty"`
+}
+
+// AccessRequestDecision is a member's decision over an access request.
+type AccessRequestDecision struct {
+	Status    string
+	DecidedBy string
+	DecidedAt time.Time
+	// GrantUntil is when the approval stops granting access without a new request. It is nil to approve only the
+	// waiting session.
+	GrantUntil *time.Time
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// APIKey is a namespace-scoped credential used by automation to access the API without a user's token.
+//
+// The key's secret is only shown when it is created; the API stores only its SHA256 digest.
+type APIKey struct {
+	ID         string     `json:"id" bson:"_id"`
+	TenantID   string     `json:"tenant_id" bson:"tenant_id"`
+	Name       string     `json:"name" bson:"name" validate:"required,min=3,max=64"`
+	Role       string     `json:"role" bson:"role" validate:"required,oneof=administrator operator observer"`
+	Digest     string     `json:"-" bson:"digest"`
+	CreatedBy  string     `json:"created_by" bson:"created_by"`
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at" bson:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at" bson:"last_used_at,omitempty"`
+}
+
+// Expired checks if the APIKey has an expiration time and if it has passed.
+func (k *APIKey) Expired(now time.Time) bool {
+	return k.ExpiresAt != nil && !now.Before(*k.ExpiresAt)
+}
+
+type APIKeyUpdate struct {
+	Name string `json:"name" bson:"name,omitempty"`
+	Role string `json:"role" bson:"role,omitempty"`
+}
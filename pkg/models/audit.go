@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+)
+
+// Audit actions recorded by the API when a mutating action is performed over a namespace.
+const (
+	AuditDeviceUpdateStatus     = "device.update_status"
+	AuditDeviceDelete           = "device.delete"
+	AuditDeviceUpdateAttributes = "device.update_attributes"
+	AuditDeviceResetHostKey     = "device.reset_host_key"
+	AuditNamespaceAddMember     = "namespace.add_member"
+	AuditNamespaceRemoveMember  = "namespace.remove_member"
+	AuditNamespaceEditMember    = "namespace.edit_member"
+	AuditNamespaceSessionRecord = "namespace.session_record"
+	AuditNamespaceMFARequired   = "namespace.mfa_required"
+	AuditNamespaceForwarding    = "namespace.reverse_forwarding"
+	AuditNamespaceAgentForward  = "namespace.agent_forwarding"
+	AuditNamespaceTimeouts      = "namespace.session_timeouts"
+	AuditNamespaceProtectedTags = "namespace.protected_tags"
+	AuditNamespaceAgentUpdate   = "namespace.agent_update"
+	AuditAPIKeyCreate           = "api_key.create"
+	AuditAPIKeyUpdate           = "api_key.update"
+	AuditAPIKeyDelete           = "api_key.delete"
+	AuditWebhookCreate          = "webhook.create"
+	AuditWebhookUpdate          = "webhook.update"
+	AuditWebhookDelete          = "webhook.delete"
+	AuditUserCACreate           = "user_ca.create"
+	AuditUserCADelete           = "user_ca.delete"
+	AuditSessionShadow          = "session.shadow"
+	AuditAccessRequestDecide    = "access_request.decide"
+)
+
+// Audit targets are the kinds of resource an audited action can act over.
+const (
+	AuditTargetDevice        = "device"
+	AuditTargetMember        = "member"
+	AuditTargetNamespace     = "namespace"
+	AuditTargetAPIKey        = "api_key"
+	AuditTargetWebhook       = "webhook"
+	AuditTargetUserCA        = "user_ca"
+	AuditTargetSession       = "session"
+	AuditTargetAccessRequest = "access_request"
+)
+
+// AuditActor is who performed an audited action.
+type AuditActor struct {
+	ID       string `json:"id" bson:"id"`
+	Username string `json:"username" bson:"username"`
+	Role     string `json:"role" bson:"role"`
+}
+
+// AuditTarget is the resource affected by an audited action.
+type AuditTarget struct {
+	Type string `json:"type" bson:"type"`
+	ID   string `json:"id" bson:"id"`
+}
+
+// AuditLog is an entry of the namespace's audit trail.
+//
+// Before and After contain only the fields changed by the action, so an entry can be read as a diff of the target.
+type AuditLog struct {
+	ID        string                 `json:"id,omitempty" bson:"_id,omitempty"`
+	TenantID  string                 `json:"tenant_id" bson:"tenant_id"`
+	Actor     AuditActor             `json:"actor" bson:"actor"`
+	Action    string                 `json:"action" bson:"action"`
+	Target    AuditTarget            `json:"target" bson:"target"`
+	Before    map[string]interface{} `json:"before,omitempty" bson:"before,omitempty"`
+	After     map[string]interface{} `json:"after,omitempty" bson:"after,omitempty"`
+	IPAddress string                 `json:"ip_address" bson:"ip_address"`
+	CreatedAt time.Time              `json:"created_at" bson:"created_at"`
+}
+
+// AuditFilter contains the optional fields used to filter the audit trail. Empty fields are ignored.
+type AuditFilter struct {
+	Action   string
+	ActorID  string
+	TargetID string
+	From     time.Time
+	To       time.Time
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// Kinds of keys the failed SSH authentications are limited by.
+const (
+	AuthLimitIP       = "ip"
+	AuthLimitDevice   = "device"
+	AuthLimitUsername = "username"
+)
+
+// Reasons for a SSH authentication to fail.
+const (
+	AuthFailureDeviceNotFound    = "device_not_found"
+	AuthFailurePublicKeyRejected = "public_key_rejected"
+	AuthFailureDeviceRejected    = "device_rejected"
+)
+
+// AuthBan temporarily blocks the SSH authentications of a source IP, a device or a username after too many failed
+// attempts.
+type AuthBan struct {
+	ID    string `json:"id" bson:"_id,omitempty"`
+	Kind  string `json:"kind" bson:"kind"`
+	Value string `json:"value" bson:"value"`
+	// Failures is the number of failed attempts that caused the ban.
+	Failures  int       `json:"failures" bson:"failures"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"`
+}
+
+// AuthEvaluation is the result of the evaluation of a SSH authentication attempt.
+type AuthEvaluation struct {
+	// Banned is true when the attempt is blocked by a ban, until ExpiresAt.
+	Banned    bool      `json:"banned"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// Delay is how many seconds the attempt must be delayed, as the recent failures come close to a ban.
+	Delay int `json:"delay"`
+}
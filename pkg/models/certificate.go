@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// UserCA is a namespace's trusted OpenSSH user certificate authority.
+//
+// The users holding a certificate signed by it can log in the namespace's devices as the certificate's principals,
+// without registering their public keys.
+type UserCA struct {
+	Fingerprint string `json:"fingerprint" bson:"fingerprint"`
+	TenantID    string `json:"tenant_id" bson:"tenant_id"`
+	Name        string `json:"name" bson:"name"`
+	// Data is the authority's public key in the authorized keys format.
+	Data      []byte    `json:"data" bson:"data"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// CertificateEvaluation is the result of a successful user certificate evaluation.
+type CertificateEvaluation struct {
+	// ForceCommand is the command executed instead of the one requested by the user, set by the certificate's
+	// "force-command" critical option.
+	ForceCommand string `json:"force_command"`
+}
+
+// CertificateAuthRequest is sent by a device's agent to check if a user certificate allows the user to log in the
+// device.
+type CertificateAuthRequest struct {
+	// Certificate is the user certificate in the authorized keys format.
+	Certificate []byte `json:"certificate"`
+	Username    string `json:"username"`
+	// Address is the address the user is connecting from.
+	Address string `json:"address"`
+}
@@ -23,6 +23,15 @@ type Device struct {
 	Position   *DevicePosition `json:"position" bson:"position"`
 	Tags       []string        `json:"tags" bson:"tags,omitempty"`
 	PublicURL  bool            `json:"public_url" bson:"public_url,omitempty"`
+	// Attributes are free-form key/value pairs describing the device, like its site or hardware revision.
+	Attributes map[string]string `json:"attributes" bson:"attributes,omitempty"`
+	// HostKeyFingerprint is the SHA256 fingerprint of the device's SSH host key, pinned on the first one reported by
+	// its agent, which the gateway verifies on each connection to the device.
+	HostKeyFingerprint string `json:"host_key_fingerprint,omitempty" bson:"host_key_fingerprint,omitempty"`
+	// Health is the latest health snapshot reported by the device's agent.
+	Health *DeviceHealth `json:"health,omitempty" bson:"health,omitempty"`
+	// HealthHistory are the latest health snapshots reported by the device's agent, from the oldest to the newest.
+	HealthHistory []DeviceHealth `json:"health_history,omitempty" bson:"health_history,omitempty"`
 }
 
 type DeviceAuthClaims struct {
@@ -35,6 +44,10 @@ type DeviceAuthClaims struct {
 type DeviceAuthRequest struct {
 	Info     *DeviceInfo `json:"info"`
 	Sessions []string    `json:"sessions,omitempty"`
+	// Attributes seeds the device's attributes when it is registered.
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// HostKeyFingerprint is the SHA256 fingerprint of the agent's SSH host key.
+	HostKeyFingerprint string `json:"host_key_fingerprint,omitempty"`
 	*DeviceAuth
 }
 
@@ -50,6 +63,12 @@ type DeviceAuthResponse struct {
 	Token     string `json:"token"`
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
+	// AgentUpdate is the policy for the automatic updates of the device's agent, set by its namespace.
+	AgentUpdate *AgentUpdate `json:"agent_update,omitempty"`
+	// ReverseForwarding is the policy for the remote port forwarding to the device, set by its namespace.
+	ReverseForwarding *ReverseForwarding `json:"reverse_forwarding,omitempty"`
+	// AgentForwarding indicates that the clients' SSH agents can be forwarded to the device, as set by its namespace.
+	AgentForwarding bool `json:"agent_forwarding,omitempty"`
 }
 
 type DeviceIdentity struct {
@@ -81,3 +100,26 @@ type DeviceRemoved struct {
 	Tenant    string    `json:"tenant_id" bson:"tenant_id"`
 	Timestamp time.Time `json:"timestamp" bson:"timestamp"`
 }
+
+// Device bulk operation actions.
+const (
+	DeviceBulkAccept    = "accept"
+	DeviceBulkReject    = "reject"
+	DeviceBulkRemove    = "remove"
+	DeviceBulkAddTag    = "add_tag"
+	DeviceBulkRemoveTag = "remove_tag"
+	DeviceBulkPublicURL = "public_url"
+)
+
+// Device bulk operation result statuses.
+const (
+	DeviceBulkSucceeded = "succeeded"
+	DeviceBulkFailed    = "failed"
+)
+
+// DeviceBulkResult is the outcome of a bulk operation on a single device.
+type DeviceBulkResult struct {
+	UID    string `json:"uid"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// DeviceHealthHistorySize is the number of health snapshots kept in a device's history, the latest one included.
+const DeviceHealthHistorySize = 24
+
+// DeviceHealth is a snapshot of a device's health, periodically reported by its agent.
+type DeviceHealth struct {
+	// Uptime is the time, in seconds, since the device was booted.
+	Uptime uint64 `json:"uptime" bson:"uptime"`
+	// Load is the device's load average over the last 1, 5 and 15 minutes.
+	Load DeviceLoad `json:"load" bson:"load"`
+	// Memory is the device's memory usage.
+	Memory DeviceMemory `json:"memory" bson:"memory"`
+	// Disks is the usage of the device's mounted disks.
+	Disks []DeviceDisk `json:"disks" bson:"disks" validate:"max=64,dive"`
+	// DiskUsage is the highest usage percentage of the device's disks, so the devices can be filtered by it.
+	DiskUsage float64 `json:"disk_usage" bson:"disk_usage"`
+	// Temperature is the device's highest CPU temperature, in degrees Celsius, when it is available.
+	Temperature *float64 `json:"temperature,omitempty" bson:"temperature,omitempty"`
+	// Addresses are the IP addresses of the device's primary network interface.
+	Addresses []string `json:"addresses" bson:"addresses" validate:"max=32,dive,ip"`
+	// ReportedAt is when the snapshot was received.
+	ReportedAt time.Time `json:"reported_at" bson:"reported_at"`
+}
+
+type DeviceLoad struct {
+	Load1  float64 `json:"load1" bson:"load1"`
+	Load5  float64 `json:"load5" bson:"load5"`
+	Load15 float64 `json:"load15" bson:"load15"`
+}
+
+// DeviceMemory is the device's memory usage, in bytes.
+type DeviceMemory struct {
+	Total     uint64 `json:"total" bson:"total"`
+	Available uint64 `json:"available" bson:"available"`
+	// Usage is the percentage of the memory not available.
+	Usage float64 `json:"usage" bson:"usage"`
+}
+
+// DeviceDisk is the usage, in bytes, of a disk mounted in the device.
+type DeviceDisk struct {
+	Mount string `json:"mount" bson:"mount" validate:"required"`
+	Total uint64 `json:"total" bson:"total"`
+	Used  uint64 `json:"used" bson:"used"`
+	// Usage is the percentage of the disk used.
+	Usage float64 `json:"usage" bson:"usage"`
+}
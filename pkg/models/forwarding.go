@@ -0,0 +1,60 @@
+package models
+
+import "net"
+
+// Reverse forwarding rule actions.
+const (
+	ReverseForwardingAllow = "allow"
+	ReverseForwardingDeny  = "deny"
+)
+
+// ReverseForwarding is a namespace's policy for the remote port forwarding, `ssh -R`, to its devices.
+//
+// The rules are evaluated in order and the first one matching the bind port decides. When the forwarding is disabled
+// or no rule matches, the bind is denied.
+type ReverseForwarding struct {
+	Enabled bool                    `json:"enabled" bson:"enabled"`
+	Rules   []ReverseForwardingRule `json:"rules" bson:"rules"`
+}
+
+// ReverseForwardingRule allows or denies binding a port in the range from FromPort to ToPort, inclusive, on a device.
+type ReverseForwardingRule struct {
+	Action   string `json:"action" bson:"action" validate:"required,oneof=allow deny"`
+	FromPort uint32 `json:"from_port" bson:"from_port" validate:"required,min=1,max=65535"`
+	ToPort   uint32 `json:"to_port" bson:"to_port" validate:"required,gtefield=FromPort,max=65535"`
+}
+
+// Allows reports whether the policy allows binding port on a device.
+//
+// The port zero, which lets the device choose the port, is never allowed as the chosen port cannot be evaluated.
+func (f *ReverseForwarding) Allows(port uint32) bool {
+	if f == nil || !f.Enabled || port == 0 {
+		return false
+	}
+
+	for _, rule := range f.Rules {
+		if port >= rule.FromPort && port <= rule.ToPort {
+			return rule.Action == ReverseForwardingAllow
+		}
+	}
+
+	return false
+}
+
+// AllowsBind reports whether the policy allows binding port on the address host of a device. Only the loopback
+// addresses can be bound, as the other ones would expose the forwarded port to the device's networks.
+func (f *ReverseForwarding) AllowsBind(host string, port uint32) bool {
+	return IsLoopbackBind(host) && f.Allows(port)
+}
+
+// IsLoopbackBind reports whether host, the bind address of a remote port forwarding, is a loopback address. The empty
+// address, as well as "*", binds on every address.
+func IsLoopbackBind(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && ip.IsLoopback()
+}
@@ -20,6 +20,44 @@ type Namespace struct {
 
 type NamespaceSettings struct {
 	SessionRecord bool `json:"session_record" bson:"session_record,omitempty"`
+	// MFARequired indicates that only members with MFA enabled can access the namespace.
+	MFARequired bool `json:"mfa_required" bson:"mfa_required,omitempty"`
+	// ReverseForwarding is the policy for the remote port forwarding to the namespace's devices.
+	ReverseForwarding *ReverseForwarding `json:"reverse_forwarding,omitempty" bson:"reverse_forwarding,omitempty"`
+	// AgentForwarding indicates that the members' SSH agents can be forwarded to the namespace's devices.
+	AgentForwarding bool `json:"agent_forwarding" bson:"agent_forwarding,omitempty"`
+	// SessionTimeouts are the limits of how long the SSH sessions to the namespace's devices can last.
+	SessionTimeouts *SessionTimeouts `json:"session_timeouts,omitempty" bson:"session_timeouts,omitempty"`
+	// ProtectedTags are the tags of the devices whose connections must be approved by a namespace's administrator.
+	ProtectedTags []string `json:"protected_tags,omitempty" bson:"protected_tags,omitempty"`
+	// AgentUpdate is the policy for the automatic updates of the namespace's agents.
+	AgentUpdate *AgentUpdate `json:"agent_update,omitempty" bson:"agent_update,omitempty"`
+}
+
+// NamespaceSettingsUpdate holds the namespace's settings to replace. The nil ones are kept as they are.
+type NamespaceSettingsUpdate struct {
+	ReverseForwarding *ReverseForwarding `json:"reverse_forwarding,omitempty" bson:"settings.reverse_forwarding,omitempty"`
+	AgentForwarding   *bool              `json:"agent_forwarding,omitempty" bson:"settings.agent_forwarding,omitempty"`
+	SessionTimeouts   *SessionTimeouts   `json:"session_timeouts,omitempty" bson:"settings.session_timeouts,omitempty"`
+	ProtectedTags     *[]string          `json:"protected_tags,omitempty" bson:"settings.protected_tags,omitempty"`
+	AgentUpdate       *AgentUpdate       `json:"agent_update,omitempty" bson:"settings.agent_update,omitempty"`
+}
+
+// AgentUpdate is the policy for the automatic updates of a namespace's agents, which otherwise update to the server's
+// version.
+type AgentUpdate struct {
+	// Hold stops the agents from updating.
+	Hold bool `json:"hold" bson:"hold,omitempty"`
+	// Version pins the agents to a version, which they update, or downgrade, to instead of the server's.
+	Version string `json:"version,omitempty" bson:"version,omitempty"`
+}
+
+// SessionTimeouts are the limits of a namespace's SSH sessions, in minutes. A zero limit is disabled.
+type SessionTimeouts struct {
+	// IdleTimeout is how long a session can stay without activity before it is closed.
+	IdleTimeout int `json:"idle_timeout" bson:"idle_timeout"`
+	// MaxDuration is how long a session can last before it is closed.
+	MaxDuration int `json:"max_duration" bson:"max_duration"`
 }
 
 type Member struct {
@@ -25,8 +25,22 @@ type Session struct {
 	Type          string          `json:"type" bson:"type"`
 	Term          string          `json:"term" bson:"term"`
 	Position      SessionPosition `json:"position" bson:"position"`
+	// Activity is what was done in a non-interactive session. It is only returned by the session details.
+	Activity *SessionActivity `json:"activity,omitempty" bson:"activity,omitempty"`
+	// ClosureReason is why the session was closed by the SSH server, empty when it was closed by the client.
+	ClosureReason string `json:"closure_reason,omitempty" bson:"closure_reason,omitempty"`
+	// FailureReason is why the session's authentication failed, empty when it succeeded.
+	FailureReason string `json:"failure_reason,omitempty" bson:"failure_reason,omitempty"`
+	// AccessRequest is the decided request to connect to a device with a namespace's protected tag.
+	AccessRequest *AccessRequest `json:"access_request,omitempty" bson:"access_request,omitempty"`
 }
 
+// Reasons for the SSH server to close a session.
+const (
+	SessionClosureIdleTimeout = "idle_timeout"
+	SessionClosureMaxDuration = "max_duration"
+)
+
 type ActiveSession struct {
 	UID      UID       `json:"uid"`
 	LastSeen time.Time `json:"last_seen" bson:"last_seen"`
@@ -52,3 +66,71 @@ type SessionRecorded struct {
 	Width     int    `json:"width" bson:"width,omitempty"`
 	Height    int    `json:"height" bson:"height,omitempty"`
 }
+
+// SessionShadow grants a namespace's member the access to join an active session, watching it or also typing on it.
+//
+// It is created by the API and redeemed, only once, by the SSH server through the token.
+type SessionShadow struct {
+	Token    string `json:"token"`
+	UID      string `json:"uid"`
+	TenantID string `json:"tenant_id"`
+	Username string `json:"username"`
+	Writable bool   `json:"writable"`
+}
+
+// SessionShadowCacheKey returns the cache key where a session shadow is kept until it is redeemed.
+func SessionShadowCacheKey(token string) string {
+	return "session_shadow/" + token
+}
+
+// File operations recorded in a SFTP session.
+const (
+	SessionFileOpen   = "open"
+	SessionFileRead   = "read"
+	SessionFileWrite  = "write"
+	SessionFileRename = "rename"
+	SessionFileRemove = "remove"
+	SessionFileMkdir  = "mkdir"
+	SessionFileRmdir  = "rmdir"
+)
+
+// SessionActivity is the record of a non-interactive session, as an exec, a heredoc or a SFTP session, which are not
+// recorded as the shell sessions are.
+type SessionActivity struct {
+	// Command is the command line executed in an exec session.
+	Command string        `json:"command,omitempty" bson:"command,omitempty"`
+	Stdin   SessionStream `json:"stdin" bson:"stdin"`
+	Stdout  SessionStream `json:"stdout" bson:"stdout"`
+	Stderr  SessionStream `json:"stderr" bson:"stderr"`
+	// ExitCode is the exit code of the command, nil when the command has not exited.
+	ExitCode *int `json:"exit_code,omitempty" bson:"exit_code,omitempty"`
+	// FileOperations are the file operations of a SFTP session.
+	FileOperations []SessionFileOperation `json:"file_operations,omitempty" bson:"file_operations,omitempty"`
+	// FileOperationsTruncated indicates that the session had more file operations than the recorded ones.
+	FileOperationsTruncated bool `json:"file_operations_truncated,omitempty" bson:"file_operations_truncated,omitempty"`
+}
+
+// SessionStreamEncodingBase64 is the encoding of a session's stream whose data is not valid UTF-8, as the one of a
+// binary transfer like scp.
+const SessionStreamEncodingBase64 = "base64"
+
+// SessionStream is the head of a session's stream. Only the first bytes of the stream are kept, while Size is the
+// stream's whole size.
+type SessionStream struct {
+	Data string `json:"data" bson:"data"`
+	// Encoding is the encoding of Data, empty when it is the stream's text itself or SessionStreamEncodingBase64.
+	Encoding  string `json:"encoding,omitempty" bson:"encoding,omitempty"`
+	Size      int64  `json:"size" bson:"size"`
+	Truncated bool   `json:"truncated" bson:"truncated"`
+}
+
+// SessionFileOperation is a file operation of a SFTP session.
+type SessionFileOperation struct {
+	Operation string `json:"operation" bson:"operation"`
+	Path      string `json:"path" bson:"path"`
+	// NewPath is the path a file was renamed to.
+	NewPath string `json:"new_path,omitempty" bson:"new_path,omitempty"`
+	// Bytes is the number of bytes read from or written to the file.
+	Bytes int64     `json:"bytes,omitempty" bson:"bytes,omitempty"`
+	Time  time.Time `json:"time" bson:"time"`
+}
@@ -24,10 +24,24 @@ type User struct {
 	CreatedAt      time.Time `json:"created_at" bson:"created_at"`
 	LastLogin      time.Time `json:"last_login" bson:"last_login"`
 	EmailMarketing bool      `json:"email_marketing" bson:"email_marketing"`
+	MFA            UserMFA   `json:"mfa" bson:"mfa"`
 	UserData       `bson:",inline"`
 	UserPassword   `bson:",inline"`
 }
 
+// UserMFA is the user's TOTP two-factor authentication state.
+//
+// Secret is set when the user generates it, but it is only required on login after the first code is verified, what
+// sets Enabled. RecoveryCodes contains the SHA256 digests of the unused recovery codes. Failures counts the consecutive
+// codes that failed, the last one at FailedAt, to lock the MFA when they are too many.
+type UserMFA struct {
+	Enabled       bool      `json:"enabled" bson:"enabled"`
+	Secret        string    `json:"-" bson:"secret,omitempty"`
+	RecoveryCodes []string  `json:"-" bson:"recovery_codes,omitempty"`
+	Failures      int       `json:"-" bson:"failures,omitempty"`
+	FailedAt      time.Time `json:"-" bson:"failed_at,omitempty"`
+}
+
 type UserAuthRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
@@ -41,6 +55,9 @@ type UserAuthResponse struct {
 	Tenant string `json:"tenant"`
 	Role   string `json:"role"`
 	Email  string `json:"email"`
+	// MFA indicates that Token is a short-lived "mfa pending" token, what must be exchanged, with a TOTP code, by the
+	// user's token.
+	MFA bool `json:"mfa"`
 }
 
 type UserAuthClaims struct {
@@ -59,3 +76,12 @@ type UserTokenRecover struct {
 	User      string    `json:"user_id"`
 	CreatedAt time.Time `json:"created_at" bson:"created_at"`
 }
+
+// UserMFAClaims are the claims of the short-lived token returned by the first step of a login when the user has MFA
+// enabled. It is only accepted by the MFA login step.
+type UserMFAClaims struct {
+	ID string `json:"id"`
+
+	AuthClaims           `mapstruct:",squash"`
+	jwt.RegisteredClaims `mapstruct:",squash"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+)
+
+// Webhook is a namespace's HTTPS endpoint where the selected events are delivered.
+//
+// The secret signs the deliveries' bodies and is only shown when the webhook is created.
+type Webhook struct {
+	ID        string    `json:"id" bson:"_id"`
+	TenantID  string    `json:"tenant_id" bson:"tenant_id"`
+	URL       string    `json:"url" bson:"url"`
+	Secret    string    `json:"-" bson:"secret"`
+	Events    []string  `json:"events" bson:"events"`
+	Active    bool      `json:"active" bson:"active"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+type WebhookUpdate struct {
+	URL    string   `json:"url" bson:"url,omitempty"`
+	Events []string `json:"events" bson:"events,omitempty"`
+	Active *bool    `json:"active" bson:"active,omitempty"`
+}
+
+// Webhook delivery statuses.
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookDelivery is an event delivered, or to be delivered, to a webhook. Each attempt updates its status, so the
+// deliveries work as the webhook's delivery log.
+type WebhookDelivery struct {
+	ID        string `json:"id" bson:"_id"`
+	TenantID  string `json:"tenant_id" bson:"tenant_id"`
+	WebhookID string `json:"webhook_id" bson:"webhook_id"`
+	Event     string `json:"event" bson:"event"`
+	// Payload is the JSON body sent to the webhook.
+	Payload       string     `json:"payload" bson:"payload"`
+	Status        string     `json:"status" bson:"status"`
+	Attempts      int        `json:"attempts" bson:"attempts"`
+	StatusCode    int        `json:"status_code" bson:"status_code"`
+	Error         string     `json:"error" bson:"error"`
+	CreatedAt     time.Time  `json:"created_at" bson:"created_at"`
+	LastAttemptAt *time.Time `json:"last_attempt_at" bson:"last_attempt_at,omitempty"`
+}
+
+type WebhookDeliveryUpdate struct {
+	Status        string     `bson:"status"`
+	Attempts      int        `bson:"attempts"`
+	StatusCode    int        `bson:"status_code"`
+	Error         string     `bson:"error"`
+	LastAttemptAt *time.Time `bson:"last_attempt_at"`
+}
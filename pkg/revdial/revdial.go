@@ -269,6 +269,8 @@ type Listener struct {
 	mu      sync.Mutex // guards below, closing connc, and writing to rw
 	readErr error
 	closed  bool
+
+	lastKeepAlive time.Time
 }
 
 type controlMsg struct {
@@ -317,8 +319,11 @@ func (ln *Listener) run() {
 			}
 			switch msg.Command {
 			case "keep-alive":
-			// Occasional no-op message from server to keep
-			// us alive through NAT timeouts.
+				// Occasional no-op message from server to keep
+				// us alive through NAT timeouts.
+				ln.mu.Lock()
+				ln.lastKeepAlive = clock.Now()
+				ln.mu.Unlock()
 			case "conn-ready":
 				go ln.grabConn(msg.ConnPath)
 			default:
@@ -342,6 +347,15 @@ func (ln *Listener) run() {
 	}
 }
 
+// LastKeepAlive returns when the last keep alive message was received from the server, or the zero time when none
+// was received yet.
+func (ln *Listener) LastKeepAlive() time.Time {
+	ln.mu.Lock()
+	defer ln.mu.Unlock()
+
+	return ln.lastKeepAlive
+}
+
 func (ln *Listener) sendMessage(m controlMsg) {
 	j, _ := json.Marshal(m)
 	j = append(j, '\n')
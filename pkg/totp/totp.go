@@ -0,0 +1,103 @@
+// Package totp implements the Time-Based One-Time Password algorithm, as defined by RFC 6238, used by the API to
+// provide two-factor authentication through authenticator apps.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period is the time, in seconds, that a code is valid.
+	Period = 30
+	// Digits is the number of digits of a code.
+	Digits = 6
+	// Skew is the number of periods, before and after the current one, in which a code is still accepted to tolerate
+	// clock drift between the server and the authenticator.
+	Skew = 1
+	// secretSize is the number of random bytes of a secret. 20 bytes is the size recommended to HMAC-SHA1.
+	secretSize = 20
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret generates a random secret encoded in base32, the format expected by authenticator apps.
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return encoding.EncodeToString(secret), nil
+}
+
+// URI returns the otpauth URI of a secret. The URI is what is encoded into the QR code read by authenticator apps.
+func URI(issuer, account, secret string) string {
+	values := url.Values{}
+	values.Set("secret", secret)
+	values.Set("issuer", issuer)
+	values.Set("algorithm", "SHA1")
+	values.Set("digits", fmt.Sprint(Digits))
+	values.Set("period", fmt.Sprint(Period))
+
+	return (&url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: values.Encode(),
+	}).String()
+}
+
+// Generate generates the code of a secret at a time.
+func Generate(secret string, t time.Time) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	return generate(key, uint64(t.Unix()/Period)), nil
+}
+
+// Validate checks if a code is valid to a secret at a time, accepting codes from Skew periods around it.
+func Validate(code, secret string, t time.Time) bool {
+	if len(code) != Digits {
+		return false
+	}
+
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return false
+	}
+
+	counter := t.Unix() / Period
+	for i := int64(-Skew); i <= Skew; i++ {
+		if subtle.ConstantTimeCompare([]byte(generate(key, uint64(counter+i))), []byte(code)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// generate implements the HOTP algorithm, defined by RFC 4226, to a counter.
+func generate(key []byte, counter uint64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", Digits, value%1000000)
+}
@@ -0,0 +1,47 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	// Test vectors from RFC 6238, Appendix B, truncated to six digits.
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	cases := []struct {
+		time     int64
+		expected string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tc := range cases {
+		code, err := Generate(secret, time.Unix(tc.time, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.expected, code)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	code, err := Generate(secret, now)
+	assert.NoError(t, err)
+
+	assert.True(t, Validate(code, secret, now))
+	assert.True(t, Validate(code, secret, now.Add(Period*time.Second)))
+	assert.False(t, Validate(code, secret, now.Add(3*Period*time.Second)))
+	assert.False(t, Validate("12345", secret, now))
+	assert.False(t, Validate(code, "invalid secret", now))
+}
@@ -5,4 +5,8 @@ const (
 	TagRegexp = "regexp"
 	// TagUsername is the tag used to validate ShellHub's username.
 	TagUsername = "username"
+	// TagAttributes is the tag used to validate device's attributes.
+	TagAttributes = "attributes"
+	// TagVersion is the tag used to validate a ShellHub's release version.
+	TagVersion = "version"
 )
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"reflect"
 	"regexp"
 
 	"github.com/go-playground/validator/v10"
@@ -17,3 +18,32 @@ func regexpValidator(field validator.FieldLevel) bool {
 func usernameValidator(field validator.FieldLevel) bool {
 	return regexp.MustCompile(`^([a-zA-Z0-9-_.@]){3,30}$`).MatchString(field.Field().String())
 }
+
+// AttributesMax is the number of attributes that a device can have.
+const AttributesMax = 32
+
+// attributesValidator is a function used to validate device's attributes.
+//
+// The keys are used as part of the filters' property names, so they cannot contain dots or dollar signs.
+func attributesValidator(field validator.FieldLevel) bool {
+	if field.Field().Kind() != reflect.Map || field.Field().Len() > AttributesMax {
+		return false
+	}
+
+	key := regexp.MustCompile(`^[a-zA-Z0-9-_]{1,64}$`)
+
+	iter := field.Field().MapRange()
+	for iter.Next() {
+		if !key.MatchString(iter.Key().String()) || len(iter.Value().String()) > 255 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// versionValidator is a function used to validate a ShellHub's release version, like v0.13.0, which tags the agent's
+// images and binaries.
+func versionValidator(field validator.FieldLevel) bool {
+	return regexp.MustCompile(`^v(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)(-[0-9A-Za-z.-]+)?$`).MatchString(field.Field().String())
+}
@@ -11,12 +11,14 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/shellhub-io/shellhub/pkg/loglevel"
+	"github.com/shellhub-io/shellhub/ssh/pkg/metrics"
 	sshTunnel "github.com/shellhub-io/shellhub/ssh/pkg/tunnel"
 	"github.com/shellhub-io/shellhub/ssh/server"
 	"github.com/shellhub-io/shellhub/ssh/server/handler"
 	"github.com/shellhub-io/shellhub/ssh/web"
 	"github.com/shellhub-io/shellhub/ssh/web/pkg/cache"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
 )
 
 func init() {
@@ -36,7 +38,10 @@ func main() {
 
 	tunnel := sshTunnel.NewTunnel("/ssh/connection", "/ssh/revdial")
 
+	metrics.RegisterAgents(tunnel.Tunnel.Connections)
+
 	router := tunnel.GetRouter()
+	router.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
 	router.HandleFunc("/sessions/{uid}/close", func(response http.ResponseWriter, request *http.Request) {
 		exit := func(response http.ResponseWriter, status int, err error) {
 			log.WithError(err).WithFields(log.Fields{
@@ -146,6 +151,8 @@ func main() {
 		Methods(http.MethodGet)
 	router.HandleFunc("/ws/ssh", web.HandlerCreateSession(web.CreateSession)).
 		Methods(http.MethodPost)
+	router.Handle("/ws/sessions/{uid}/shadow", websocket.Handler(handler.ShadowSession)).
+		Methods(http.MethodGet)
 
 	go http.ListenAndServe(":8080", router) // nolint:errcheck
 
@@ -0,0 +1,152 @@
+// Package activity records what is done in the non-interactive sessions, as exec, heredoc and SFTP sessions, whose
+// activity is not recorded as the shell sessions are.
+package activity
+
+import (
+	"encoding/base64"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	// StreamLimit is the number of bytes kept from each session's stream.
+	StreamLimit = 64 * 1024
+	// FileOperationsLimit is the number of file operations kept from a SFTP session.
+	FileOperationsLimit = 1000
+)
+
+// Stream keeps the head of a session's stream, counting its whole size.
+type Stream struct {
+	mu   sync.Mutex
+	data []byte
+	size int64
+}
+
+// Write keeps the bytes written up to StreamLimit. It never fails, so it can be used with io.TeeReader and
+// io.MultiWriter without interrupting the session.
+func (s *Stream) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if left := StreamLimit - len(s.data); left > 0 {
+		if len(p) < left {
+			left = len(p)
+		}
+
+		s.data = append(s.data, p[:left]...)
+	}
+
+	s.size += int64(len(p))
+
+	return len(p), nil
+}
+
+// record returns the stream's head. A head that is not valid UTF-8, as the one of a binary transfer like scp, is
+// base64 encoded, so it is kept as it is.
+func (s *Stream) record() models.SessionStream {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	head := s.data
+	if s.size > int64(len(head)) {
+		head = trimRune(head)
+	}
+
+	stream := models.SessionStream{
+		Data:      string(head),
+		Size:      s.size,
+		Truncated: s.size > int64(len(head)),
+	}
+
+	if !utf8.Valid(head) {
+		stream.Data = base64.StdEncoding.EncodeToString(head)
+		stream.Encoding = models.SessionStreamEncodingBase64
+	}
+
+	return stream
+}
+
+// trimRune drops the incomplete rune the truncation at StreamLimit may leave at the end of a head, so a text head is
+// still valid UTF-8.
+func trimRune(head []byte) []byte {
+	for i := len(head) - 1; i >= 0 && i >= len(head)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(head[i]) {
+			if !utf8.FullRune(head[i:]) {
+				return head[:i]
+			}
+
+			break
+		}
+	}
+
+	return head
+}
+
+// Recorder records the activity of a session.
+type Recorder struct {
+	Stdin  Stream
+	Stdout Stream
+	Stderr Stream
+
+	command string
+
+	mu         sync.Mutex
+	exitCode   *int
+	operations []models.SessionFileOperation
+	truncated  bool
+	sftp       *SFTP
+}
+
+// NewRecorder creates a Recorder for a session executing command. The command is empty for SFTP sessions.
+func NewRecorder(command string) *Recorder {
+	return &Recorder{command: command}
+}
+
+// Exit records the exit code of the session's command.
+func (r *Recorder) Exit(code int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.exitCode = &code
+}
+
+// fileOperation records a file operation, up to FileOperationsLimit.
+func (r *Recorder) fileOperation(operation models.SessionFileOperation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.operations) >= FileOperationsLimit {
+		r.truncated = true
+
+		return
+	}
+
+	r.operations = append(r.operations, operation)
+}
+
+// Activity returns the session's activity recorded so far.
+func (r *Recorder) Activity() *models.SessionActivity {
+	r.mu.Lock()
+	sftp := r.sftp
+	r.mu.Unlock()
+
+	// The files left open when the SFTP session ends are recorded as if they were closed.
+	if sftp != nil {
+		sftp.flush()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &models.SessionActivity{
+		Command:                 r.command,
+		Stdin:                   r.Stdin.record(),
+		Stdout:                  r.Stdout.record(),
+		Stderr:                  r.Stderr.record(),
+		ExitCode:                r.exitCode,
+		FileOperations:          r.operations,
+		FileOperationsTruncated: r.truncated,
+	}
+}
@@ -0,0 +1,65 @@
+package activity
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamRecord(t *testing.T) {
+	cases := []struct {
+		description string
+		writes      [][]byte
+		expected    models.SessionStream
+	}{
+		{
+			description: "keeps the whole stream under the limit",
+			writes:      [][]byte{[]byte("ls\n"), []byte("pwd\n")},
+			expected:    models.SessionStream{Data: "ls\npwd\n", Size: 7},
+		},
+		{
+			description: "drops the rune split by the truncation",
+			writes:      [][]byte{[]byte(strings.Repeat("a", StreamLimit-1) + "é")},
+			expected:    models.SessionStream{Data: strings.Repeat("a", StreamLimit-1), Size: StreamLimit + 1, Truncated: true},
+		},
+		{
+			description: "keeps a rune split between writes under the limit",
+			writes:      [][]byte{[]byte("é")[:1], []byte("é")[1:]},
+			expected:    models.SessionStream{Data: "é", Size: 2},
+		},
+		{
+			description: "encodes a binary stream as base64",
+			writes:      [][]byte{{0xff, 0xfe}},
+			expected: models.SessionStream{
+				Data:     base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe}),
+				Size:     2,
+				Encoding: models.SessionStreamEncodingBase64,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var stream Stream
+			for _, p := range tc.writes {
+				n, err := stream.Write(p)
+				assert.NoError(t, err)
+				assert.Equal(t, len(p), n)
+			}
+
+			assert.Equal(t, tc.expected, stream.record())
+		})
+	}
+}
+
+func TestTrimRune(t *testing.T) {
+	euro := []byte("€")
+
+	assert.Equal(t, []byte("a"), trimRune(append([]byte("a"), euro[:2]...)))
+	assert.Equal(t, append([]byte("a"), euro...), trimRune(append([]byte("a"), euro...)))
+	assert.Equal(t, []byte{0xff}, trimRune([]byte{0xff}))
+	assert.Empty(t, trimRune([]byte{}))
+}
@@ -0,0 +1,370 @@
+package activity
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// SFTP packet types, as defined in the draft-ietf-secsh-filexfer-02, used to record the file operations.
+const (
+	sftpOpen   = 3
+	sftpClose  = 4
+	sftpRead   = 5
+	sftpWrite  = 6
+	sftpRemove = 13
+	sftpMkdir  = 14
+	sftpRmdir  = 15
+	sftpRename = 18
+	sftpStatus = 101
+	sftpHandle = 102
+	sftpData   = 103
+)
+
+// sftpPacketLimit is the largest SFTP packet parsed. The packets are limited to 256 KiB by the OpenSSH's sftp-server,
+// so a larger one means that the stream is not a SFTP one and the parsing is given up.
+const sftpPacketLimit = 1024 * 1024
+
+var errSFTPPacket = errors.New("malformed SFTP packet")
+
+// sftpFile is a file opened in a SFTP session.
+type sftpFile struct {
+	path    string
+	read    int64
+	written int64
+}
+
+// SFTP parses a SFTP session's packets, recording its file operations.
+type SFTP struct {
+	recorder *Recorder
+
+	mu sync.Mutex
+	// opens are the paths of the files whose opening was requested, by request ID.
+	opens map[uint32]string
+	// reads are the handles whose reading was requested, by request ID.
+	reads map[uint32]string
+	// pending are the operations waiting for their status, by request ID.
+	pending map[uint32]models.SessionFileOperation
+	// files are the opened files, by handle.
+	files map[string]*sftpFile
+
+	requests  sftpStream
+	responses sftpStream
+}
+
+// NewSFTP creates a SFTP parser recording the file operations of a session to recorder.
+func NewSFTP(recorder *Recorder) *SFTP {
+	s := &SFTP{
+		recorder: recorder,
+		opens:    make(map[uint32]string),
+		reads:    make(map[uint32]string),
+		pending:  make(map[uint32]models.SessionFileOperation),
+		files:    make(map[string]*sftpFile),
+	}
+
+	s.requests.handle = s.request
+	s.responses.handle = s.response
+
+	recorder.mu.Lock()
+	recorder.sftp = s
+	recorder.mu.Unlock()
+
+	return s
+}
+
+// Requests returns the writer where the packets sent by the client must be written.
+func (s *SFTP) Requests() io.Writer {
+	return &s.requests
+}
+
+// Responses returns the writer where the packets sent by the device must be written.
+func (s *SFTP) Responses() io.Writer {
+	return &s.responses
+}
+
+// request handles a packet sent by the client.
+func (s *SFTP) request(kind byte, packet *sftpPacket) error {
+	id, err := packet.uint32()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch kind {
+	case sftpOpen:
+		path, err := packet.string()
+		if err != nil {
+			return err
+		}
+
+		s.opens[id] = path
+	case sftpClose:
+		handle, err := packet.string()
+		if err != nil {
+			return err
+		}
+
+		s.close(handle)
+	case sftpRead:
+		handle, err := packet.string()
+		if err != nil {
+			return err
+		}
+
+		s.reads[id] = handle
+	case sftpWrite:
+		handle, err := packet.string()
+		if err != nil {
+			return err
+		}
+
+		if _, err := packet.uint64(); err != nil {
+			return err
+		}
+
+		data, err := packet.bytes()
+		if err != nil {
+			return err
+		}
+
+		if file, ok := s.files[handle]; ok {
+			file.written += int64(len(data))
+		}
+	case sftpRemove, sftpMkdir, sftpRmdir:
+		path, err := packet.string()
+		if err != nil {
+			return err
+		}
+
+		operations := map[byte]string{
+			sftpRemove: models.SessionFileRemove,
+			sftpMkdir:  models.SessionFileMkdir,
+			sftpRmdir:  models.SessionFileRmdir,
+		}
+
+		s.pending[id] = models.SessionFileOperation{Operation: operations[kind], Path: path}
+	case sftpRename:
+		path, err := packet.string()
+		if err != nil {
+			return err
+		}
+
+		newPath, err := packet.string()
+		if err != nil {
+			return err
+		}
+
+		s.pending[id] = models.SessionFileOperation{Operation: models.SessionFileRename, Path: path, NewPath: newPath}
+	}
+
+	return nil
+}
+
+// response handles a packet sent by the device.
+func (s *SFTP) response(kind byte, packet *sftpPacket) error {
+	id, err := packet.uint32()
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch kind {
+	case sftpHandle:
+		handle, err := packet.string()
+		if err != nil {
+			return err
+		}
+
+		if path, ok := s.opens[id]; ok {
+			delete(s.opens, id)
+
+			s.files[handle] = &sftpFile{path: path}
+			s.recorder.fileOperation(models.SessionFileOperation{
+				Operation: models.SessionFileOpen,
+				Path:      path,
+				Time:      clock(),
+			})
+		}
+	case sftpData:
+		data, err := packet.bytes()
+		if err != nil {
+			return err
+		}
+
+		if handle, ok := s.reads[id]; ok {
+			delete(s.reads, id)
+
+			if file, ok := s.files[handle]; ok {
+				file.read += int64(len(data))
+			}
+		}
+	case sftpStatus:
+		code, err := packet.uint32()
+		if err != nil {
+			return err
+		}
+
+		delete(s.opens, id)
+		delete(s.reads, id)
+
+		// Only the operations that succeeded are recorded.
+		if operation, ok := s.pending[id]; ok {
+			delete(s.pending, id)
+
+			if code == 0 {
+				operation.Time = clock()
+				s.recorder.fileOperation(operation)
+			}
+		}
+	}
+
+	return nil
+}
+
+// close records the bytes read from and written to a file when it is closed. It must be called with the parser locked.
+func (s *SFTP) close(handle string) {
+	file, ok := s.files[handle]
+	if !ok {
+		return
+	}
+
+	delete(s.files, handle)
+
+	if file.read > 0 {
+		s.recorder.fileOperation(models.SessionFileOperation{
+			Operation: models.SessionFileRead,
+			Path:      file.path,
+			Bytes:     file.read,
+			Time:      clock(),
+		})
+	}
+
+	if file.written > 0 {
+		s.recorder.fileOperation(models.SessionFileOperation{
+			Operation: models.SessionFileWrite,
+			Path:      file.path,
+			Bytes:     file.written,
+			Time:      clock(),
+		})
+	}
+}
+
+// flush records the files left open.
+func (s *SFTP) flush() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for handle := range s.files {
+		s.close(handle)
+	}
+}
+
+var clock = func() time.Time {
+	return time.Now().UTC()
+}
+
+// sftpStream splits a stream of SFTP packets, handling each one when it is complete. It never fails: when the stream
+// cannot be parsed, it is just not parsed anymore.
+type sftpStream struct {
+	handle func(kind byte, packet *sftpPacket) error
+
+	buffer []byte
+	broken bool
+}
+
+func (s *sftpStream) Write(p []byte) (int, error) {
+	if s.broken {
+		return len(p), nil
+	}
+
+	s.buffer = append(s.buffer, p...)
+
+	for len(s.buffer) >= 5 {
+		length := binary.BigEndian.Uint32(s.buffer)
+		if length == 0 || length > sftpPacketLimit {
+			s.broken = true
+			s.buffer = nil
+
+			break
+		}
+
+		if uint32(len(s.buffer)-4) < length {
+			break
+		}
+
+		packet := &sftpPacket{data: s.buffer[5 : 4+length]}
+		if err := s.handle(s.buffer[4], packet); err != nil {
+			s.broken = true
+			s.buffer = nil
+
+			break
+		}
+
+		s.buffer = s.buffer[4+length:]
+	}
+
+	// The buffer is released when it is fully consumed, so it does not hold the memory of a large packet.
+	if len(s.buffer) == 0 {
+		s.buffer = nil
+	}
+
+	return len(p), nil
+}
+
+// sftpPacket reads the fields of a SFTP packet.
+type sftpPacket struct {
+	data []byte
+}
+
+func (p *sftpPacket) uint32() (uint32, error) {
+	if len(p.data) < 4 {
+		return 0, errSFTPPacket
+	}
+
+	value := binary.BigEndian.Uint32(p.data)
+	p.data = p.data[4:]
+
+	return value, nil
+}
+
+func (p *sftpPacket) uint64() (uint64, error) {
+	if len(p.data) < 8 {
+		return 0, errSFTPPacket
+	}
+
+	value := binary.BigEndian.Uint64(p.data)
+	p.data = p.data[8:]
+
+	return value, nil
+}
+
+// bytes reads a string field without copying it.
+func (p *sftpPacket) bytes() ([]byte, error) {
+	length, err := p.uint32()
+	if err != nil {
+		return nil, err
+	}
+
+	if uint32(len(p.data)) < length {
+		return nil, errSFTPPacket
+	}
+
+	value := p.data[:length]
+	p.data = p.data[length:]
+
+	return value, nil
+}
+
+func (p *sftpPacket) string() (string, error) {
+	value, err := p.bytes()
+
+	return string(value), err
+}
@@ -0,0 +1,212 @@
+// Package hub keeps the active shell sessions that namespace's members can join, broadcasting the output of each
+// session to the members watching it and feeding their input, when allowed, to the session.
+package hub
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ViewerBuffer is the number of output chunks queued to a viewer before it is considered too slow and dropped.
+const ViewerBuffer = 256
+
+var (
+	ErrSessionNotFound = errors.New("session not found or not shareable")
+	ErrReadOnly        = errors.New("the session was joined as read-only")
+	ErrClosed          = errors.New("the session was closed")
+)
+
+// Hub keeps the shareable sessions by their UIDs.
+type Hub struct {
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// New creates a new Hub.
+func New() *Hub {
+	return &Hub{sessions: make(map[string]*Session)}
+}
+
+// DefaultHub is the Hub used by the SSH server.
+var DefaultHub = New()
+
+// Open makes a session shareable. The viewers' input is written to input, and the banners notifying that a viewer
+// joined or left are written to owner, the session's original client.
+//
+// The session must be closed when it ends, disconnecting its viewers.
+func (h *Hub) Open(uid string, input, owner io.Writer) *Session {
+	session := &Session{
+		uid:     uid,
+		input:   input,
+		owner:   owner,
+		viewers: make(map[*Viewer]struct{}),
+		hub:     h,
+	}
+
+	h.mu.Lock()
+	h.sessions[uid] = session
+	h.mu.Unlock()
+
+	return session
+}
+
+// Lookup returns the shareable session with the given UID.
+func (h *Hub) Lookup(uid string) (*Session, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	session, ok := h.sessions[uid]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+
+	return session, nil
+}
+
+// Session is a shareable session.
+type Session struct {
+	uid   string
+	input io.Writer
+	owner io.Writer
+	hub   *Hub
+
+	mu      sync.Mutex
+	viewers map[*Viewer]struct{}
+	closed  bool
+
+	// inputMu serializes the viewers' input, so a slow input does not hold the broadcast of the output.
+	inputMu sync.Mutex
+}
+
+// Write broadcasts the session's output to its viewers. It never blocks the session: a viewer too slow to keep up with
+// the output is disconnected.
+func (s *Session) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for viewer := range s.viewers {
+		chunk := make([]byte, len(p))
+		copy(chunk, p)
+
+		select {
+		case viewer.output <- chunk:
+		default:
+			delete(s.viewers, viewer)
+			close(viewer.output)
+		}
+	}
+
+	return len(p), nil
+}
+
+// Join adds a viewer to the session, notifying the session's original client. When writable, the viewer can also type
+// on the session.
+func (s *Session) Join(username string, writable bool) (*Viewer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrClosed
+	}
+
+	viewer := &Viewer{
+		session:  s,
+		username: username,
+		writable: writable,
+		output:   make(chan []byte, ViewerBuffer),
+	}
+
+	s.viewers[viewer] = struct{}{}
+
+	mode := "read-only"
+	if writable {
+		mode = "read-write"
+	}
+
+	s.notify(fmt.Sprintf("%s joined the session (%s)", username, mode))
+
+	return viewer, nil
+}
+
+// leave removes a viewer from the session, notifying the session's original client.
+func (s *Session) leave(viewer *Viewer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.viewers[viewer]; ok {
+		delete(s.viewers, viewer)
+		close(viewer.output)
+	}
+
+	if !s.closed {
+		s.notify(fmt.Sprintf("%s left the session", viewer.username))
+	}
+}
+
+// notify writes a banner to the session's original client. It must be called with the session locked.
+func (s *Session) notify(message string) {
+	fmt.Fprintf(s.owner, "\r\n\x1b[1m[ShellHub] %s\x1b[0m\r\n", message) //nolint:errcheck
+}
+
+// Close removes the session from the hub and disconnects its viewers.
+func (s *Session) Close() {
+	s.hub.mu.Lock()
+	if s.hub.sessions[s.uid] == s {
+		delete(s.hub.sessions, s.uid)
+	}
+	s.hub.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+
+	for viewer := range s.viewers {
+		delete(s.viewers, viewer)
+		close(viewer.output)
+	}
+}
+
+// Viewer is a member who joined a session.
+type Viewer struct {
+	session  *Session
+	username string
+	writable bool
+	output   chan []byte
+	left     sync.Once
+}
+
+// Output returns the channel where the session's output is sent to the viewer. It is closed when the viewer is
+// disconnected.
+func (v *Viewer) Output() <-chan []byte {
+	return v.output
+}
+
+// Write types on the session. It fails with ErrReadOnly when the viewer joined the session as read-only.
+func (v *Viewer) Write(p []byte) (int, error) {
+	if !v.writable {
+		return 0, ErrReadOnly
+	}
+
+	v.session.mu.Lock()
+	closed := v.session.closed
+	v.session.mu.Unlock()
+
+	if closed {
+		return 0, ErrClosed
+	}
+
+	v.session.inputMu.Lock()
+	defer v.session.inputMu.Unlock()
+
+	return v.session.input.Write(p)
+}
+
+// Leave disconnects the viewer from the session. It is safe to call it more than once.
+func (v *Viewer) Leave() {
+	v.left.Do(func() {
+		v.session.leave(v)
+	})
+}
@@ -0,0 +1,153 @@
+package hub
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpen(t *testing.T) {
+	hub := New()
+
+	_, err := hub.Lookup("uid")
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+
+	session := hub.Open("uid", new(bytes.Buffer), new(bytes.Buffer))
+
+	found, err := hub.Lookup("uid")
+	assert.NoError(t, err)
+	assert.Equal(t, session, found)
+
+	session.Close()
+
+	_, err = hub.Lookup("uid")
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
+
+func TestOpenReplaced(t *testing.T) {
+	hub := New()
+
+	previous := hub.Open("uid", new(bytes.Buffer), new(bytes.Buffer))
+	current := hub.Open("uid", new(bytes.Buffer), new(bytes.Buffer))
+
+	// Closing the replaced session does not remove the one opened after it.
+	previous.Close()
+
+	found, err := hub.Lookup("uid")
+	assert.NoError(t, err)
+	assert.Equal(t, current, found)
+}
+
+func TestJoin(t *testing.T) {
+	owner := new(bytes.Buffer)
+	session := New().Open("uid", new(bytes.Buffer), owner)
+
+	viewer, err := session.Join("user", false)
+	assert.NoError(t, err)
+	assert.Contains(t, owner.String(), "user joined the session (read-only)")
+
+	_, err = session.Write([]byte("output"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("output"), <-viewer.Output())
+
+	session.Close()
+
+	_, ok := <-viewer.Output()
+	assert.False(t, ok)
+
+	_, err = session.Join("user", false)
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func TestJoinSlowViewer(t *testing.T) {
+	session := New().Open("uid", new(bytes.Buffer), new(bytes.Buffer))
+
+	viewer, err := session.Join("user", false)
+	assert.NoError(t, err)
+
+	// The session is never blocked by a viewer: the one not reading its output is dropped.
+	for i := 0; i <= ViewerBuffer; i++ {
+		_, err := session.Write([]byte("output"))
+		assert.NoError(t, err)
+	}
+
+	var received int
+	for range viewer.Output() {
+		received++
+	}
+
+	assert.Equal(t, ViewerBuffer, received)
+
+	session.mu.Lock()
+	assert.Empty(t, session.viewers)
+	session.mu.Unlock()
+}
+
+func TestLeave(t *testing.T) {
+	owner := new(bytes.Buffer)
+	session := New().Open("uid", new(bytes.Buffer), owner)
+
+	viewer, err := session.Join("user", true)
+	assert.NoError(t, err)
+
+	viewer.Leave()
+	viewer.Leave()
+
+	assert.Equal(t, 1, bytes.Count(owner.Bytes(), []byte("user left the session")))
+
+	_, ok := <-viewer.Output()
+	assert.False(t, ok)
+
+	// The output is no longer sent to the viewer, which channel was closed.
+	_, err = session.Write([]byte("output"))
+	assert.NoError(t, err)
+}
+
+func TestViewerWrite(t *testing.T) {
+	cases := []struct {
+		description string
+		writable    bool
+		closed      bool
+		expected    error
+		input       string
+	}{
+		{
+			description: "fails when the viewer joined as read-only",
+			writable:    false,
+			expected:    ErrReadOnly,
+			input:       "",
+		},
+		{
+			description: "fails when the session was closed",
+			writable:    true,
+			closed:      true,
+			expected:    ErrClosed,
+			input:       "",
+		},
+		{
+			description: "succeeds to type on the session",
+			writable:    true,
+			expected:    nil,
+			input:       "input",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			input := new(bytes.Buffer)
+			session := New().Open("uid", input, new(bytes.Buffer))
+
+			viewer, err := session.Join("user", tc.writable)
+			assert.NoError(t, err)
+
+			if tc.closed {
+				session.Close()
+			}
+
+			_, err = viewer.Write([]byte("input"))
+			assert.ErrorIs(t, err, tc.expected)
+			assert.Equal(t, tc.input, input.String())
+		})
+	}
+}
@@ -6,8 +6,6 @@ const (
 	authentication = "authentication"
 	// Password is the key to store and restore the password from the context.
 	password = "password"
-	// fingerprint is the key to store and restore the public key from the context.
-	fingerprint = "public_key"
 	// api is the key to store and restore an instance of internal api client.
 	api = "api"
 	// tag is the key to store and restore the tag from the context.
@@ -26,8 +24,36 @@ const (
 	agent = "agent"
 	// established is the key to store and restore the established state from the context.
 	established = "established"
+	// authEvaluation is the key to store and restore the evaluation of the connection's authentication attempts from
+	// the context.
+	authEvaluation = "auth_evaluation"
+	// authFailure is the key to store and restore why the connection's last authentication attempt failed from the
+	// context.
+	authFailure = "auth_failure"
 )
 
+// The extensions of the permissions granted by the public key a connection authenticated with. As the server returns
+// them for the key the client signs with, they cannot be changed by other keys the client offers in the connection.
+const (
+	// ExtensionFingerprint is the extension which holds the public key's fingerprint.
+	ExtensionFingerprint = "fingerprint"
+	// ExtensionJumpKey is the extension which holds the public key of a jump-host connection, in the wire format.
+	ExtensionJumpKey = "jump-key"
+	// ExtensionCertificate is the extension which tells the permissions granted by a user certificate apart from the
+	// ones granted by a public key.
+	ExtensionCertificate = "certificate"
+	// ExtensionPermitPortForwarding, ExtensionPermitAgentForwarding and ExtensionPermitPTY are the user certificate's
+	// extensions enforced by the server, copied from it. A connection authenticated by a certificate without one of
+	// them is not permitted to do what it allows.
+	ExtensionPermitPortForwarding  = "permit-port-forwarding"
+	ExtensionPermitAgentForwarding = "permit-agent-forwarding"
+	ExtensionPermitPTY             = "permit-pty"
+)
+
+// OptionForceCommand is the critical option of the permissions granted by a user certificate which holds the command
+// it forces.
+const OptionForceCommand = "force-command"
+
 const (
 	// PasswordAuthenticationMethod represents the password authentication method.
 	PasswordAuthenticationMethod = iota + 1
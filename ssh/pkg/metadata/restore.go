@@ -13,6 +13,17 @@ func restore(ctx gliderssh.Context, key string) interface{} {
 	return ctx.Value(key)
 }
 
+// permissions restores the permissions granted by the public key the connection authenticated with. They are empty
+// before the connection is authenticated, or when it is not by a public key.
+func permissions(ctx gliderssh.Context) *gossh.Permissions {
+	conn, ok := ctx.Value(gliderssh.ContextKeyConn).(*gossh.ServerConn)
+	if !ok || conn.Permissions == nil {
+		return &gossh.Permissions{}
+	}
+
+	return conn.Permissions
+}
+
 // RestoreRequest restores the request type from context as metadata.
 func RestoreRequest(ctx gliderssh.Context) string {
 	value := restore(ctx, request)
@@ -43,14 +54,9 @@ func RestorePassword(ctx gliderssh.Context) string {
 	return value.(string)
 }
 
-// RestoreFingerprint restores the fingerprint from context as metadata.
+// RestoreFingerprint restores the fingerprint of the public key the connection authenticated with.
 func RestoreFingerprint(ctx gliderssh.Context) string {
-	value := restore(ctx, fingerprint)
-	if value == nil {
-		return ""
-	}
-
-	return value.(string)
+	return permissions(ctx).Extensions[ExtensionFingerprint]
 }
 
 // RestoreTarget restores the target from context as metadata.
@@ -112,3 +118,60 @@ func RestoreEstablished(ctx gliderssh.Context) bool {
 
 	return value.(bool)
 }
+
+// RestoreForceCommand restores the command forced by the user certificate the connection authenticated with. It is
+// empty when no command is forced.
+func RestoreForceCommand(ctx gliderssh.Context) string {
+	return permissions(ctx).CriticalOptions[OptionForceCommand]
+}
+
+// RestorePermit checks if the connection is permitted to do what extension, one of the user certificate's extensions
+// enforced by the server, allows. Only the connections authenticated by a user certificate are restricted by them.
+func RestorePermit(ctx gliderssh.Context, extension string) bool {
+	extensions := permissions(ctx).Extensions
+	if _, ok := extensions[ExtensionCertificate]; !ok {
+		return true
+	}
+
+	_, ok := extensions[extension]
+
+	return ok
+}
+
+// RestoreJumpKey restores the public key a jump-host connection authenticated with. It is nil when the connection is
+// not a jump-host one.
+func RestoreJumpKey(ctx gliderssh.Context) gossh.PublicKey {
+	data, ok := permissions(ctx).Extensions[ExtensionJumpKey]
+	if !ok {
+		return nil
+	}
+
+	key, err := gossh.ParsePublicKey([]byte(data))
+	if err != nil {
+		return nil
+	}
+
+	return key
+}
+
+// RestoreAuthEvaluation restores the evaluation of the connection's authentication attempts from context as metadata.
+// It is nil when the attempts were not evaluated yet.
+func RestoreAuthEvaluation(ctx gliderssh.Context) *models.AuthEvaluation {
+	value := restore(ctx, authEvaluation)
+	if value == nil {
+		return nil
+	}
+
+	return value.(*models.AuthEvaluation)
+}
+
+// RestoreAuthFailure restores why the connection's last authentication attempt failed from context as metadata. It is
+// empty when no attempt failed.
+func RestoreAuthFailure(ctx gliderssh.Context) string {
+	value := restore(ctx, authFailure)
+	if value == nil {
+		return ""
+	}
+
+	return value.(string)
+}
@@ -43,16 +43,21 @@ func StoreAuthenticationMethod(ctx gliderssh.Context, method AuthenticationMetho
 	store(ctx, authentication, method)
 }
 
+// StoreAuthEvaluation stores the evaluation of the connection's authentication attempts in the context as metadata.
+func StoreAuthEvaluation(ctx gliderssh.Context, evaluation *models.AuthEvaluation) {
+	store(ctx, authEvaluation, evaluation)
+}
+
+// StoreAuthFailure stores why the connection's last authentication attempt failed in the context as metadata.
+func StoreAuthFailure(ctx gliderssh.Context, reason string) {
+	store(ctx, authFailure, reason)
+}
+
 // StorePassword stores the password in the context as metadata.
 func StorePassword(ctx gliderssh.Context, value string) {
 	store(ctx, password, value)
 }
 
-// MaybeStoreFingerprint stores the fingerprint in the context as metadata if is not set yet.
-func MaybeStoreFingerprint(ctx gliderssh.Context, value string) string {
-	return maybeStore(ctx, fingerprint, value).(string)
-}
-
 // MaybeStoreTarget stores the target in the context as metadata if is not set yet.
 func MaybeStoreTarget(ctx gliderssh.Context, sshid string) (*target.Target, error) {
 	value, err := target.NewTarget(sshid)
@@ -0,0 +1,90 @@
+// Package metrics defines the Prometheus metrics exposed by the SSH server.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "shellhub_ssh"
+
+// Authentication results.
+const (
+	AuthSuccess = "success"
+	AuthFailure = "failure"
+)
+
+var (
+	sessions = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "sessions_active",
+		Help:      "Number of active sessions by type.",
+	}, []string{"type"})
+
+	dialDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "dial_duration_seconds",
+		Help:      "Time taken to dial a connected agent through the tunnel.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	dialErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dial_errors_total",
+		Help:      "Number of failed dials to a connected agent through the tunnel.",
+	})
+
+	authentications = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "authentications_total",
+		Help:      "Number of authentication attempts by method and result.",
+	}, []string{"method", "result"})
+)
+
+// RegisterAgents registers a gauge reporting the number of connected agents returned by connected.
+func RegisterAgents(connected func() int) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "agents_connected",
+		Help:      "Number of agents connected to the tunnel.",
+	}, func() float64 {
+		return float64(connected())
+	})
+}
+
+// SessionStarted increments the active sessions of a type, returning a function to decrement it when the session
+// finishes.
+func SessionStarted(kind string) func() {
+	gauge := sessions.WithLabelValues(kind)
+	gauge.Inc()
+
+	return gauge.Dec
+}
+
+// ObserveDial records the duration of a dial to an agent and whether it failed.
+func ObserveDial(elapsed time.Duration, err error) {
+	dialDuration.Observe(elapsed.Seconds())
+
+	if err != nil {
+		dialErrors.Inc()
+	}
+}
+
+// ObserveAuth records an authentication attempt using method.
+func ObserveAuth(method string, ok bool) {
+	result := AuthFailure
+	if ok {
+		result = AuthSuccess
+	}
+
+	authentications.WithLabelValues(method, result).Inc()
+}
+
+// Handler returns the HTTP handler that serves the metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
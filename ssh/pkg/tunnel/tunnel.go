@@ -4,10 +4,12 @@ import (
 	"context"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/ssh/pkg/metrics"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -35,6 +37,9 @@ func NewTunnel(connection, dial string) *Tunnel {
 			log.Error(err)
 		}
 	}
+	tunnel.Tunnel.DialHandler = func(_ string, elapsed time.Duration, err error) {
+		metrics.ObserveDial(elapsed, err)
+	}
 
 	return tunnel
 }
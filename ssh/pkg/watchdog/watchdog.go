@@ -0,0 +1,152 @@
+// Package watchdog closes the sessions that exceed the idle timeout or the maximum duration set by their namespaces,
+// warning the users before doing it.
+package watchdog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// Warning is how long before closing a session its user is warned. It is shortened to half of the limit when the limit
+// is shorter than twice it.
+const Warning = time.Minute
+
+// interval is how often the limits are checked.
+const interval = time.Second
+
+// Watchdog watches a session, closing it when it exceeds a limit.
+type Watchdog struct {
+	idle    time.Duration
+	max     time.Duration
+	warn    func(message string)
+	expire  func(reason string)
+	started time.Time
+
+	mu         sync.Mutex
+	last       time.Time
+	idleWarned bool
+	maxWarned  bool
+
+	stop chan struct{}
+	once sync.Once
+}
+
+// New creates a Watchdog for the timeouts. warn is called with the message to be shown to the user before the session
+// is closed, and expire with the reason to close it. It returns nil when no limit is set.
+func New(timeouts *models.SessionTimeouts, warn func(message string), expire func(reason string)) *Watchdog {
+	if timeouts == nil || (timeouts.IdleTimeout <= 0 && timeouts.MaxDuration <= 0) {
+		return nil
+	}
+
+	now := time.Now()
+
+	return &Watchdog{
+		idle:    time.Duration(timeouts.IdleTimeout) * time.Minute,
+		max:     time.Duration(timeouts.MaxDuration) * time.Minute,
+		warn:    warn,
+		expire:  expire,
+		started: now,
+		last:    now,
+		stop:    make(chan struct{}),
+	}
+}
+
+// Touch records an activity in the session, restarting its idle timeout. It is safe to call it on a nil Watchdog.
+func (w *Watchdog) Touch() {
+	if w == nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.last = time.Now()
+	w.idleWarned = false
+}
+
+// Run watches the session until it is closed by a limit or the Watchdog is stopped. It is safe to call it on a nil
+// Watchdog.
+func (w *Watchdog) Run() {
+	if w == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case now := <-ticker.C:
+			if reason := w.check(now); reason != "" {
+				w.expire(reason)
+
+				return
+			}
+		}
+	}
+}
+
+// Stop stops watching the session. It is safe to call it more than once and on a nil Watchdog.
+func (w *Watchdog) Stop() {
+	if w == nil {
+		return
+	}
+
+	w.once.Do(func() {
+		close(w.stop)
+	})
+}
+
+// check warns the user about a limit about to be exceeded, returning the closure reason when a limit was exceeded.
+func (w *Watchdog) check(now time.Time) string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.max > 0 {
+		left := w.started.Add(w.max).Sub(now)
+		if left <= 0 {
+			w.warn("The session reached its maximum duration and was closed")
+
+			return models.SessionClosureMaxDuration
+		}
+
+		if !w.maxWarned && left <= warning(w.max) {
+			w.maxWarned = true
+			w.warn(fmt.Sprintf("The session will reach its maximum duration and be closed in %s", round(left)))
+		}
+	}
+
+	if w.idle > 0 {
+		left := w.last.Add(w.idle).Sub(now)
+		if left <= 0 {
+			w.warn("The session was idle for too long and was closed")
+
+			return models.SessionClosureIdleTimeout
+		}
+
+		if !w.idleWarned && left <= warning(w.idle) {
+			w.idleWarned = true
+			w.warn(fmt.Sprintf("The session is idle and will be closed in %s", round(left)))
+		}
+	}
+
+	return ""
+}
+
+// warning returns how long before exceeding limit the user is warned.
+func warning(limit time.Duration) time.Duration {
+	if limit < 2*Warning {
+		return limit / 2
+	}
+
+	return Warning
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(time.Second)
+}
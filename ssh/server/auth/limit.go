@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"net"
+	"time"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	"github.com/shellhub-io/shellhub/ssh/pkg/target"
+	log "github.com/sirupsen/logrus"
+)
+
+// evaluateAttempt checks if the connection's authentication attempts are banned, delaying them when their recent
+// failures come close to a ban. It is evaluated once per connection, as a client tries many keys in a connection.
+//
+// When the API cannot evaluate the attempt, it is not limited, so the gateway does not stop working with it.
+func evaluateAttempt(ctx gliderssh.Context, api internalclient.Client) bool {
+	if evaluation := metadata.RestoreAuthEvaluation(ctx); evaluation != nil {
+		return !evaluation.Banned
+	}
+
+	attempt := authAttempt(ctx)
+
+	evaluation, err := api.EvaluateAuthAttempt(attempt)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"address": attempt.IPAddress,
+			"device":  attempt.Device,
+		}).Warn("failed to evaluate the authentication attempt")
+
+		evaluation = &models.AuthEvaluation{}
+	}
+
+	metadata.StoreAuthEvaluation(ctx, evaluation)
+
+	if evaluation.Banned {
+		log.WithFields(log.Fields{
+			"address": attempt.IPAddress,
+			"device":  attempt.Device,
+			"user":    attempt.Username,
+			"until":   evaluation.ExpiresAt,
+		}).Info("authentication attempt blocked by a ban")
+
+		return false
+	}
+
+	if evaluation.Delay > 0 {
+		time.Sleep(time.Duration(evaluation.Delay) * time.Second)
+	}
+
+	return true
+}
+
+// ReportFailure waits for the connection to be closed, reporting it to the API when its authentication failed. It must
+// be started when the connection is accepted, as its context is canceled when it is closed.
+func ReportFailure(ctx gliderssh.Context) {
+	<-ctx.Done()
+
+	// The connection is only set when the authentication succeeds.
+	if ctx.Value(gliderssh.ContextKeyConn) != nil {
+		return
+	}
+
+	reason := metadata.RestoreAuthFailure(ctx)
+	if reason == "" {
+		return
+	}
+
+	failure := request.AuthFailure{
+		AuthAttempt: authAttempt(ctx),
+		Reason:      reason,
+	}
+
+	if device := metadata.RestoreDevice(ctx); device != nil {
+		failure.DeviceUID = device.UID
+	}
+
+	recordFailure(metadata.MaybeSetAPI(ctx, internalclient.NewClient()), failure)
+}
+
+// RecordDeviceRejection records that the device rejected the credentials of the connection's session, after the
+// gateway accepted them.
+func RecordDeviceRejection(ctx gliderssh.Context, session string) {
+	failure := request.AuthFailure{
+		AuthAttempt: authAttempt(ctx),
+		SessionUID:  session,
+		Reason:      models.AuthFailureDeviceRejected,
+	}
+
+	if device := metadata.RestoreDevice(ctx); device != nil {
+		failure.DeviceUID = device.UID
+	}
+
+	recordFailure(metadata.MaybeSetAPI(ctx, internalclient.NewClient()), failure)
+}
+
+// RecordJumpRejection records that the public key of a jump-host connection was rejected by the device, whose SSHID is
+// sshid, it tried to jump to.
+func RecordJumpRejection(ctx gliderssh.Context, device *models.Device, sshid string) {
+	attempt := authAttempt(ctx)
+	attempt.Device = sshid
+
+	recordFailure(metadata.MaybeSetAPI(ctx, internalclient.NewClient()), request.AuthFailure{
+		AuthAttempt: attempt,
+		DeviceUID:   device.UID,
+		Reason:      models.AuthFailurePublicKeyRejected,
+	})
+}
+
+// recordFailure records a failed authentication in the API.
+func recordFailure(api internalclient.Client, failure request.AuthFailure) {
+	if err := api.RecordAuthFailure(failure); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"address": failure.IPAddress,
+			"device":  failure.Device,
+			"reason":  failure.Reason,
+		}).Warn("failed to record the authentication failure")
+	}
+}
+
+// authAttempt returns the connection's authentication attempt. Its device is empty in a jump-host connection, as it
+// has no target.
+func authAttempt(ctx gliderssh.Context) request.AuthAttempt {
+	attempt := request.AuthAttempt{Username: ctx.User()}
+
+	if host, _, err := net.SplitHostPort(ctx.RemoteAddr().String()); err == nil {
+		attempt.IPAddress = host
+	}
+
+	if tag, err := target.NewTarget(ctx.User()); err == nil {
+		attempt.Device = tag.Data
+		attempt.Username = tag.Username
+	}
+
+	return attempt
+}
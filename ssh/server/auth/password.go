@@ -3,6 +3,7 @@ package auth
 import (
 	"github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
 	log "github.com/sirupsen/logrus"
 )
@@ -28,13 +29,21 @@ func PasswordHandler(ctx ssh.Context, password string) bool {
 
 	api := metadata.MaybeSetAPI(ctx, internalclient.NewClient())
 
+	if !evaluateAttempt(ctx, api) {
+		return false
+	}
+
 	lookup, err := metadata.MaybeStoreLookup(ctx, tag, api)
 	if err != nil {
+		metadata.StoreAuthFailure(ctx, models.AuthFailureDeviceNotFound)
+
 		return false
 	}
 
 	_, errs := metadata.MaybeStoreDevice(ctx, lookup, api)
 	if len(errs) > 0 {
+		metadata.StoreAuthFailure(ctx, models.AuthFailureDeviceNotFound)
+
 		return false
 	}
 
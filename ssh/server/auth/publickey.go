@@ -1,58 +1,187 @@
 package auth
 
 import (
+	"encoding/hex"
+	"errors"
+
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/ssh/pkg/magickey"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	"github.com/shellhub-io/shellhub/ssh/pkg/target"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
 )
 
+// ErrPublicKeyRejected is returned by the public key callback when the key cannot authenticate the connection.
+var ErrPublicKeyRejected = errors.New("permission denied")
+
+// PublicKeyCallback returns the public key callback of the connection whose context is ctx, observing the result of
+// each key's authentication.
+//
+// Unlike the permissions of gliderlabs' PublicKeyHandler, shared by every key offered in the connection, each key is
+// granted its own, so what they hold, like the key of a jump-host connection, is bound to the key the client signs
+// with, even when the server reuses the result of a previous callback to it.
+func PublicKeyCallback(ctx gliderssh.Context, observe func(ok bool)) func(gossh.ConnMetadata, gossh.PublicKey) (*gossh.Permissions, error) {
+	return func(conn gossh.ConnMetadata, key gossh.PublicKey) (*gossh.Permissions, error) {
+		applyConnMetadata(ctx, conn)
+
+		permissions, ok := PublicKeyHandler(ctx, key)
+		observe(ok)
+
+		if !ok {
+			return nil, ErrPublicKeyRejected
+		}
+
+		return permissions, nil
+	}
+}
+
+// applyConnMetadata stores the connection's metadata in its context, as gliderlabs does before calling its handlers.
+func applyConnMetadata(ctx gliderssh.Context, conn gossh.ConnMetadata) {
+	if ctx.Value(gliderssh.ContextKeySessionID) != nil {
+		return
+	}
+
+	ctx.SetValue(gliderssh.ContextKeySessionID, hex.EncodeToString(conn.SessionID()))
+	ctx.SetValue(gliderssh.ContextKeyClientVersion, string(conn.ClientVersion()))
+	ctx.SetValue(gliderssh.ContextKeyServerVersion, string(conn.ServerVersion()))
+	ctx.SetValue(gliderssh.ContextKeyUser, conn.User())
+	ctx.SetValue(gliderssh.ContextKeyLocalAddr, conn.LocalAddr())
+	ctx.SetValue(gliderssh.ContextKeyRemoteAddr, conn.RemoteAddr())
+}
+
 // PublicKeyHandler handles ShellHub client`s connection using public key authentication method.
 // Public key authentication is the first authentication method tried by the server to connect the client to agent.
 //
 // It receives the public key from the client and tries to authenticate it.
 //
-// Returns true if the public key authentication method is used and false otherwise.
-func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool {
+// Returns the permissions granted by the public key and true if the public key authentication method is used and
+// false otherwise.
+func PublicKeyHandler(ctx gliderssh.Context, publicKey gossh.PublicKey) (*gossh.Permissions, bool) {
 	sshid := metadata.MaybeStoreSSHID(ctx, ctx.User())
-	fingerprint := metadata.MaybeStoreFingerprint(ctx, gossh.FingerprintLegacyMD5(publicKey))
+	fingerprint := gossh.FingerprintLegacyMD5(publicKey)
+
+	permissions := &gossh.Permissions{
+		Extensions: map[string]string{
+			metadata.ExtensionFingerprint: fingerprint,
+		},
+	}
 
 	log.WithFields(log.Fields{
 		"sshid":       sshid,
 		"fingerprint": fingerprint,
 	}).Trace("trying to use public key authentication")
 
+	// A login without a target is a jump-host connection, e.g. `ssh -J user@gateway user@namespace.device`. As its
+	// devices are only known when its channels are opened, its public key is evaluated against each one of them then.
+	if _, err := target.NewTarget(sshid); err != nil {
+		api := metadata.MaybeSetAPI(ctx, internalclient.NewClient())
+		if !evaluateAttempt(ctx, api) {
+			return nil, false
+		}
+
+		// Although the key is only evaluated against a device when a channel is opened to it, a key not registered
+		// in any namespace, or a certificate not signed by an authority trusted by any of them, cannot reach any.
+		registered := fingerprint
+		if cert, ok := publicKey.(*gossh.Certificate); ok {
+			registered = gossh.FingerprintLegacyMD5(cert.SignatureKey)
+		}
+
+		if ok, err := api.KeyRegistered(registered); !ok || err != nil {
+			metadata.StoreAuthFailure(ctx, models.AuthFailurePublicKeyRejected)
+
+			return nil, false
+		}
+
+		if cert, ok := publicKey.(*gossh.Certificate); ok {
+			certificatePermissions(permissions, cert)
+		}
+
+		permissions.Extensions[metadata.ExtensionJumpKey] = string(publicKey.Marshal())
+
+		log.WithFields(log.Fields{
+			"user":        sshid,
+			"fingerprint": fingerprint,
+		}).Info("using public key authentication method to a jump-host connection")
+
+		return permissions, true
+	}
+
 	tag, err := metadata.MaybeStoreTarget(ctx, sshid)
 	if err != nil {
-		return false
+		return nil, false
 	}
 
 	api := metadata.MaybeSetAPI(ctx, internalclient.NewClient())
 
+	if !evaluateAttempt(ctx, api) {
+		return nil, false
+	}
+
 	lookup, err := metadata.MaybeStoreLookup(ctx, tag, api)
 	if err != nil {
-		return false
+		metadata.StoreAuthFailure(ctx, models.AuthFailureDeviceNotFound)
+
+		return nil, false
 	}
 
 	device, errs := metadata.MaybeStoreDevice(ctx, lookup, api)
 	if len(errs) > 0 {
-		return false
+		metadata.StoreAuthFailure(ctx, models.AuthFailureDeviceNotFound)
+
+		return nil, false
+	}
+
+	// A user certificate is authenticated by the namespace's trusted certificate authorities instead of by the
+	// namespace's public keys.
+	if cert, ok := publicKey.(*gossh.Certificate); ok {
+		evaluation, err := api.EvaluateCertificate(gossh.MarshalAuthorizedKey(cert), device, tag.Username, ctx.RemoteAddr().String())
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"sshid":  sshid,
+				"key_id": cert.KeyId,
+			}).Warn("failed to evaluate the user certificate")
+
+			metadata.StoreAuthFailure(ctx, models.AuthFailurePublicKeyRejected)
+
+			return nil, false
+		}
+
+		certificatePermissions(permissions, cert)
+		if evaluation.ForceCommand != "" {
+			permissions.CriticalOptions = map[string]string{
+				metadata.OptionForceCommand: evaluation.ForceCommand,
+			}
+		}
+
+		metadata.StoreAuthenticationMethod(ctx, metadata.PublicKeyAuthenticationMethod)
+
+		log.WithFields(log.Fields{
+			"sshid":  sshid,
+			"key_id": cert.KeyId,
+		}).Info("using user certificate authentication method to connect the client to agent")
+
+		return permissions, true
 	}
 
 	magic, err := gossh.NewPublicKey(&magickey.GetRerefence().PublicKey)
 	if err != nil {
-		return false
+		return nil, false
 	}
 
 	if gossh.FingerprintLegacyMD5(magic) != fingerprint {
 		if _, err = api.GetPublicKey(fingerprint, device.TenantID); err != nil {
-			return false
+			metadata.StoreAuthFailure(ctx, models.AuthFailurePublicKeyRejected)
+
+			return nil, false
 		}
 
 		if ok, err := api.EvaluateKey(fingerprint, device, tag.Username); !ok || err != nil {
-			return false
+			metadata.StoreAuthFailure(ctx, models.AuthFailurePublicKeyRejected)
+
+			return nil, false
 		}
 	}
 
@@ -63,5 +192,21 @@ func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool
 		"fingerprint": fingerprint,
 	}).Info("using public key authentication method to connect the client to agent")
 
-	return true
+	return permissions, true
+}
+
+// certificatePermissions marks the permissions as granted by a user certificate, copying the certificate's extensions
+// enforced by the server.
+func certificatePermissions(permissions *gossh.Permissions, cert *gossh.Certificate) {
+	permissions.Extensions[metadata.ExtensionCertificate] = ""
+
+	for _, extension := range []string{
+		metadata.ExtensionPermitPortForwarding,
+		metadata.ExtensionPermitAgentForwarding,
+		metadata.ExtensionPermitPTY,
+	} {
+		if _, ok := cert.Extensions[extension]; ok {
+			permissions.Extensions[extension] = ""
+		}
+	}
 }
@@ -0,0 +1,271 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"net"
+	"testing"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// newSigner generates a new ed25519 signer.
+func newSigner(t *testing.T) gossh.Signer {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	signer, err := gossh.NewSignerFromKey(private)
+	assert.NoError(t, err)
+
+	return signer
+}
+
+// serve starts a SSH server authenticating the public keys by the API, which sends to permissions the permissions of
+// the connections' session channels.
+func serve(t *testing.T, api internalclient.Client, permissions chan<- *gossh.Permissions) string {
+	t.Helper()
+
+	server := &gliderssh.Server{ // nolint: exhaustruct
+		ConnCallback: func(ctx gliderssh.Context, conn net.Conn) net.Conn {
+			metadata.MaybeSetAPI(ctx, api)
+
+			return conn
+		},
+		PasswordHandler: func(ctx gliderssh.Context, password string) bool {
+			return false
+		},
+		ServerConfigCallback: func(ctx gliderssh.Context) *gossh.ServerConfig {
+			return &gossh.ServerConfig{ // nolint: exhaustruct
+				PublicKeyCallback: PublicKeyCallback(ctx, func(bool) {}),
+			}
+		},
+		ChannelHandlers: map[string]gliderssh.ChannelHandler{
+			"session": func(_ *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
+				permissions <- conn.Permissions
+
+				newChan.Reject(gossh.Prohibited, "") //nolint:errcheck
+			},
+		},
+	}
+
+	server.AddHostKey(newSigner(t))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	go server.Serve(listener) //nolint:errcheck
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	return listener.Addr().String()
+}
+
+func TestPublicKeyCallbackJump(t *testing.T) {
+	registered := newSigner(t)
+	unregistered := newSigner(t)
+
+	authority := newSigner(t)
+	cert := &gossh.Certificate{
+		Key:             unregistered.PublicKey(),
+		CertType:        gossh.UserCert,
+		ValidPrincipals: []string{"user"},
+		ValidBefore:     gossh.CertTimeInfinity,
+	}
+	assert.NoError(t, cert.SignCert(rand.Reader, authority))
+
+	certSigner, err := gossh.NewCertSigner(cert, unregistered)
+	assert.NoError(t, err)
+
+	fingerprint := func(key gossh.PublicKey) string {
+		return gossh.FingerprintLegacyMD5(key)
+	}
+
+	cases := []struct {
+		description   string
+		signers       []gossh.Signer
+		requiredMocks func(api *mocks.Client)
+		expected      gossh.PublicKey
+	}{
+		{
+			description: "fails when the public key is not registered in any namespace",
+			signers:     []gossh.Signer{unregistered},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("KeyRegistered", fingerprint(unregistered.PublicKey())).Return(false, nil)
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the registration of the public key cannot be checked",
+			signers:     []gossh.Signer{registered},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("KeyRegistered", fingerprint(registered.PublicKey())).Return(false, errors.New("error"))
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the certificate authority is not trusted by any namespace",
+			signers:     []gossh.Signer{certSigner},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("KeyRegistered", fingerprint(authority.PublicKey())).Return(false, nil)
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the public key is registered",
+			signers:     []gossh.Signer{registered},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("KeyRegistered", fingerprint(registered.PublicKey())).Return(true, nil)
+			},
+			expected: registered.PublicKey(),
+		},
+		{
+			description: "succeeds when the certificate authority is trusted",
+			signers:     []gossh.Signer{certSigner},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("KeyRegistered", fingerprint(authority.PublicKey())).Return(true, nil)
+			},
+			expected: certSigner.PublicKey(),
+		},
+		{
+			description: "succeeds with the key the client authenticates with, instead of the keys it offers before",
+			signers:     []gossh.Signer{unregistered, registered},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("KeyRegistered", fingerprint(unregistered.PublicKey())).Return(false, nil)
+				api.On("KeyRegistered", fingerprint(registered.PublicKey())).Return(true, nil)
+			},
+			expected: registered.PublicKey(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			api.On("EvaluateAuthAttempt", mock.Anything).Return(&models.AuthEvaluation{}, nil)
+			tc.requiredMocks(api)
+
+			permissions := make(chan *gossh.Permissions, 1)
+
+			client, err := gossh.Dial("tcp", serve(t, api, permissions), &gossh.ClientConfig{ // nolint: exhaustruct
+				User:            "user",
+				Auth:            []gossh.AuthMethod{gossh.PublicKeys(tc.signers...)},
+				HostKeyCallback: gossh.InsecureIgnoreHostKey(), //nolint:gosec
+			})
+			if tc.expected == nil {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			defer client.Close()
+
+			_, _, err = client.OpenChannel("session", nil)
+			assert.Error(t, err)
+
+			granted := <-permissions
+			assert.Equal(t, string(tc.expected.Marshal()), granted.Extensions[metadata.ExtensionJumpKey])
+			assert.Equal(t, fingerprint(tc.expected), granted.Extensions[metadata.ExtensionFingerprint])
+		})
+	}
+}
+
+func TestPublicKeyCallbackCertificate(t *testing.T) {
+	key := newSigner(t)
+	authority := newSigner(t)
+
+	device := &models.Device{UID: "uid", TenantID: "tenant"}
+
+	newCertSigner := func(extensions map[string]string) gossh.Signer {
+		cert := &gossh.Certificate{
+			Key:             key.PublicKey(),
+			CertType:        gossh.UserCert,
+			ValidPrincipals: []string{"user"},
+			ValidBefore:     gossh.CertTimeInfinity,
+			Permissions:     gossh.Permissions{Extensions: extensions},
+		}
+		assert.NoError(t, cert.SignCert(rand.Reader, authority))
+
+		signer, err := gossh.NewCertSigner(cert, key)
+		assert.NoError(t, err)
+
+		return signer
+	}
+
+	forced := newCertSigner(nil)
+	permitted := newCertSigner(map[string]string{
+		metadata.ExtensionPermitPTY:             "",
+		metadata.ExtensionPermitAgentForwarding: "",
+		"permit-user-rc":                        "",
+	})
+
+	cases := []struct {
+		description string
+		signer      gossh.Signer
+		evaluation  *models.CertificateEvaluation
+		expected    *gossh.Permissions
+	}{
+		{
+			description: "grants the command forced by the certificate",
+			signer:      forced,
+			evaluation:  &models.CertificateEvaluation{ForceCommand: "uptime"},
+			expected: &gossh.Permissions{
+				CriticalOptions: map[string]string{
+					metadata.OptionForceCommand: "uptime",
+				},
+				Extensions: map[string]string{
+					metadata.ExtensionFingerprint: gossh.FingerprintLegacyMD5(forced.PublicKey()),
+					metadata.ExtensionCertificate: "",
+				},
+			},
+		},
+		{
+			description: "grants the extensions of the certificate enforced by the server",
+			signer:      permitted,
+			evaluation:  &models.CertificateEvaluation{},
+			expected: &gossh.Permissions{
+				Extensions: map[string]string{
+					metadata.ExtensionFingerprint:           gossh.FingerprintLegacyMD5(permitted.PublicKey()),
+					metadata.ExtensionCertificate:           "",
+					metadata.ExtensionPermitPTY:             "",
+					metadata.ExtensionPermitAgentForwarding: "",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			api.On("EvaluateAuthAttempt", mock.Anything).Return(&models.AuthEvaluation{}, nil)
+			api.On("DeviceLookup", map[string]string{"domain": "namespace", "name": "device"}).Return(device, nil)
+			api.On("EvaluateCertificate", gossh.MarshalAuthorizedKey(tc.signer.PublicKey()), device, "user", mock.Anything).
+				Return(tc.evaluation, nil)
+
+			permissions := make(chan *gossh.Permissions, 1)
+
+			client, err := gossh.Dial("tcp", serve(t, api, permissions), &gossh.ClientConfig{ // nolint: exhaustruct
+				User:            "user@namespace.device",
+				Auth:            []gossh.AuthMethod{gossh.PublicKeys(tc.signer)},
+				HostKeyCallback: gossh.InsecureIgnoreHostKey(), //nolint:gosec
+			})
+			assert.NoError(t, err)
+			defer client.Close()
+
+			_, _, err = client.OpenChannel("session", nil)
+			assert.Error(t, err)
+
+			assert.Equal(t, tc.expected, <-permissions)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package channels
+
+import (
+	"io"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	// AuthAgentRequest is the session request type sent to ask for the forwarding of the client's SSH agent.
+	// e.g. `ssh -A user@sshid`.
+	AuthAgentRequest = "auth-agent-req@openssh.com"
+	// AuthAgentChannel is the channel type opened by the server side of a connection to reach the client's SSH agent.
+	AuthAgentChannel = "auth-agent@openssh.com"
+)
+
+// ForwardAgent forwards the client's SSH agent to the device. It asks the agent's session for the agent forwarding
+// and relays each AuthAgentChannel opened by the agent to a new AuthAgentChannel opened to the client.
+//
+// It must be called before the session's shell or command is started, as the agent only exposes the forwarded agent to
+// the processes started after the request.
+func ForwardAgent(client gliderssh.Session, agent *gossh.Client, session *gossh.Session) error {
+	conn := client.Context().Value(gliderssh.ContextKeyConn).(*gossh.ServerConn)
+
+	chans := agent.HandleChannelOpen(AuthAgentChannel)
+
+	ok, err := session.SendRequest(AuthAgentRequest, true, nil)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		log.WithFields(log.Fields{
+			"sshid": client.User(),
+		}).Warn("agent forwarding request was rejected by the device")
+
+		return nil
+	}
+
+	go func() {
+		for newChan := range chans {
+			go relayAgent(conn, newChan)
+		}
+	}()
+
+	return nil
+}
+
+// relayAgent opens an AuthAgentChannel to the client and proxies the agent's channel to it.
+func relayAgent(conn *gossh.ServerConn, newChan gossh.NewChannel) {
+	client, clientReqs, err := conn.OpenChannel(AuthAgentChannel, nil)
+	if err != nil {
+		newChan.Reject(gossh.ConnectionFailed, "error opening the channel to the client: "+err.Error()) //nolint:errcheck
+
+		return
+	}
+
+	agent, agentReqs, err := newChan.Accept()
+	if err != nil {
+		client.Close()
+
+		return
+	}
+
+	go gossh.DiscardRequests(clientReqs)
+	go gossh.DiscardRequests(agentReqs)
+
+	go func() {
+		defer agent.CloseWrite() //nolint:errcheck
+		io.Copy(agent, client)   //nolint:errcheck
+	}()
+
+	defer client.Close()
+	defer agent.Close()
+
+	io.Copy(client, agent) //nolint:errcheck
+}
@@ -0,0 +1,259 @@
+package channels
+
+import (
+	"errors"
+	"io"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	"github.com/shellhub-io/shellhub/ssh/pkg/metrics"
+	"github.com/shellhub-io/shellhub/ssh/pkg/target"
+	"github.com/shellhub-io/shellhub/ssh/pkg/watchdog"
+	"github.com/shellhub-io/shellhub/ssh/server/auth"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// JumpPort is the only port a jump-host connection can open channels to, as they reach the devices' SSH server.
+const JumpPort = 22
+
+var (
+	ErrJumpForbidden    = errors.New("the public key is not allowed to access the device")
+	ErrJumpForceCommand = errors.New("a certificate forcing a command cannot be used to jump to a device")
+	ErrJumpRecorded     = errors.New("the device's namespace records its sessions, which cannot be done through a jump-host")
+)
+
+// jump pipes a channel of a jump-host connection straight to the SSH server of the device whose SSHID is addr, e.g.
+// `namespace.device`, so the gateway does not terminate the SSH session inside the channel. The connection's public
+// key is evaluated against the device, using the connection's user as the user on the device.
+//
+// As the gateway cannot see inside the session, the namespace's policies for the remote port forwarding and the agent
+// forwarding are enforced by the device's agent, and the ones of the session timeouts by the channel's traffic. The
+// namespaces recording their sessions cannot be jumped to.
+func jump(ctx gliderssh.Context, tunnel *httptunnel.Tunnel, newChan gossh.NewChannel, key gossh.PublicKey, addr string, port uint32) {
+	if !metadata.RestorePermit(ctx, metadata.ExtensionPermitPortForwarding) {
+		newChan.Reject(gossh.Prohibited, "port forwarding is not permitted by the user certificate") //nolint:errcheck
+
+		return
+	}
+
+	if port != JumpPort {
+		newChan.Reject(gossh.Prohibited, "only the SSH server of a device can be jumped to") //nolint:errcheck
+
+		return
+	}
+
+	tag := &target.Target{Username: ctx.User(), Data: addr}
+
+	namespace, hostname, err := tag.SplitSSHID()
+	if err != nil {
+		newChan.Reject(gossh.ConnectionFailed, "the destination is not a device's SSHID") //nolint:errcheck
+
+		return
+	}
+
+	api := metadata.RestoreAPI(ctx)
+
+	lookup := map[string]string{
+		"domain": namespace,
+		"name":   hostname,
+	}
+
+	device, errs := api.DeviceLookup(lookup)
+	if len(errs) > 0 {
+		newChan.Reject(gossh.ConnectionFailed, "failed to find the device") //nolint:errcheck
+
+		return
+	}
+
+	if err := evaluateJump(api, key, device, tag.Username, ctx.RemoteAddr().String()); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"user":   tag.Username,
+			"device": device.UID,
+		}).Info("jump to the device was denied")
+
+		auth.RecordJumpRejection(ctx, device, addr)
+
+		newChan.Reject(gossh.Prohibited, err.Error()) //nolint:errcheck
+
+		return
+	}
+
+	if err := evaluateJumpRecord(api, device); err != nil {
+		newChan.Reject(gossh.Prohibited, err.Error()) //nolint:errcheck
+
+		return
+	}
+
+	sess, err := session.NewJump(ctx, tunnel, device, lookup, tag.Username)
+	if err != nil {
+		newChan.Reject(gossh.ConnectionFailed, err.Error()) //nolint:errcheck
+
+		return
+	}
+
+	channel, reqs, err := newChan.Accept()
+	if err != nil {
+		sess.Dialed.Close()
+		sess.Finish() // nolint:errcheck
+
+		return
+	}
+
+	go gossh.DiscardRequests(reqs)
+
+	if errs := api.SessionAsAuthenticated(sess.UID); len(errs) > 0 {
+		log.WithError(errs[0]).WithFields(log.Fields{
+			"session": sess.UID,
+		}).Warn("failed to set the jump session as authenticated")
+	}
+
+	log.WithFields(log.Fields{
+		"session": sess.UID,
+		"user":    tag.Username,
+		"device":  device.UID,
+	}).Info("jump-host channel started")
+
+	go func() {
+		defer sess.Finish() // nolint:errcheck
+		defer metrics.SessionStarted(sess.Type)()
+
+		dog := watchJump(api, device, sess, func() {
+			channel.Close()
+			sess.Dialed.Close()
+		})
+		defer dog.Stop()
+
+		pipeJump(channel, sess.Dialed, dog)
+
+		log.WithFields(log.Fields{
+			"session": sess.UID,
+		}).Info("jump-host channel closed")
+	}()
+}
+
+// pipeJump copies the traffic between a jump-host channel and the device's SSH server until any side stops, closing
+// both of them. Only the client's traffic restarts the watchdog's idle timeout, as the device's one does not mean that
+// someone is using the session.
+func pipeJump(channel io.ReadWriteCloser, dialed io.ReadWriteCloser, dog *watchdog.Watchdog) {
+	done := make(chan struct{}, 2)
+
+	go func() {
+		io.Copy(channel, dialed) //nolint:errcheck
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(dialed, &touchReader{Reader: channel, watchdog: dog}) //nolint:errcheck
+		done <- struct{}{}
+	}()
+
+	// When any side stops, the whole pipe is closed.
+	<-done
+
+	channel.Close()
+	dialed.Close()
+}
+
+// touchReader restarts the watchdog's idle timeout on each read.
+type touchReader struct {
+	io.Reader
+	watchdog *watchdog.Watchdog
+}
+
+func (r *touchReader) Read(p []byte) (int, error) {
+	read, err := r.Reader.Read(p)
+	if read > 0 {
+		r.watchdog.Touch()
+	}
+
+	return read, err
+}
+
+// watchJump starts watching a jump-host session by the timeouts of the device's namespace, recording the closure reason and
+// calling closer when it exceeds one of them. As the session is encrypted end to end, the client cannot be warned
+// before. It returns a nil Watchdog, which is safe to use, when the namespace has no timeouts or they cannot be got.
+func watchJump(api internalclient.Client, device *models.Device, sess *session.Session, closer func()) *watchdog.Watchdog {
+	timeouts, err := api.GetSessionTimeouts(device.TenantID)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"session": sess.UID,
+		}).Warn("failed to get the namespace's session timeouts")
+
+		return nil
+	}
+
+	dog := watchdog.New(timeouts, func(string) {}, func(reason string) {
+		log.WithFields(log.Fields{
+			"session": sess.UID,
+			"reason":  reason,
+		}).Info("closing the jump-host session as it exceeded a namespace's timeout")
+
+		if err := api.SetSessionClosure(sess.UID, reason); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"session": sess.UID,
+			}).Error("failed to record the session's closure reason")
+		}
+
+		closer()
+	})
+
+	go dog.Run()
+
+	return dog
+}
+
+// evaluateJumpRecord checks if the sessions to the device are not recorded, as the gateway cannot record the ones
+// inside a jump-host channel. When it cannot be checked, the device cannot be jumped to.
+func evaluateJumpRecord(api internalclient.Client, device *models.Device) error {
+	if recorded, err := api.GetSessionRecord(device.TenantID); err != nil || recorded {
+		return ErrJumpRecorded
+	}
+
+	return nil
+}
+
+// evaluateJump checks if the public key of a jump-host connection, coming from address, can access the device as
+// username. A user certificate is evaluated by the namespace's trusted certificate authorities, but it cannot force a
+// command, as the gateway cannot enforce it in a session it does not terminate.
+func evaluateJump(api internalclient.Client, key gossh.PublicKey, device *models.Device, username, address string) error {
+	if cert, ok := key.(*gossh.Certificate); ok {
+		evaluation, err := api.EvaluateCertificate(gossh.MarshalAuthorizedKey(cert), device, username, address)
+		if err != nil {
+			return ErrJumpForbidden
+		}
+
+		if evaluation.ForceCommand != "" {
+			return ErrJumpForceCommand
+		}
+
+		return nil
+	}
+
+	fingerprint := gossh.FingerprintLegacyMD5(key)
+
+	if _, err := api.GetPublicKey(fingerprint, device.TenantID); err != nil {
+		return ErrJumpForbidden
+	}
+
+	if ok, err := api.EvaluateKey(fingerprint, device, username); !ok || err != nil {
+		return ErrJumpForbidden
+	}
+
+	return nil
+}
+
+// SessionHandler handles the session channels, rejecting them in a jump-host connection, as it has no device to open
+// them to. Its devices are only reached through DirectTCPIPChannel channels.
+func SessionHandler(server *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
+	if metadata.RestoreJumpKey(ctx) != nil {
+		newChan.Reject(gossh.Prohibited, "a jump-host connection cannot open sessions") //nolint:errcheck
+
+		return
+	}
+
+	gliderssh.DefaultSessionHandler(server, conn, newChan, ctx)
+}
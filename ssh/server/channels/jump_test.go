@@ -0,0 +1,179 @@
+package channels
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestEvaluateJump(t *testing.T) {
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	key, err := gossh.NewPublicKey(public)
+	assert.NoError(t, err)
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	authority, err := gossh.NewSignerFromKey(private)
+	assert.NoError(t, err)
+
+	cert := &gossh.Certificate{
+		Key:             key,
+		CertType:        gossh.UserCert,
+		ValidPrincipals: []string{"root"},
+		ValidBefore:     gossh.CertTimeInfinity,
+	}
+	assert.NoError(t, cert.SignCert(rand.Reader, authority))
+
+	device := &models.Device{UID: "uid", TenantID: "tenant"}
+	fingerprint := gossh.FingerprintLegacyMD5(key)
+
+	cases := []struct {
+		description   string
+		key           gossh.PublicKey
+		requiredMocks func(api *mocks.Client)
+		expected      error
+	}{
+		{
+			description: "fails when the public key is not registered in the device's namespace",
+			key:         key,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetPublicKey", fingerprint, "tenant").Return(nil, internalclient.ErrNotFound)
+			},
+			expected: ErrJumpForbidden,
+		},
+		{
+			description: "fails when the public key does not allow the user on the device",
+			key:         key,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetPublicKey", fingerprint, "tenant").Return(&models.PublicKey{}, nil)
+				api.On("EvaluateKey", fingerprint, device, "root").Return(false, nil)
+			},
+			expected: ErrJumpForbidden,
+		},
+		{
+			description: "succeeds when the public key allows the user on the device",
+			key:         key,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetPublicKey", fingerprint, "tenant").Return(&models.PublicKey{}, nil)
+				api.On("EvaluateKey", fingerprint, device, "root").Return(true, nil)
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the certificate is rejected",
+			key:         cert,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("EvaluateCertificate", gossh.MarshalAuthorizedKey(cert), device, "root", "127.0.0.1:22").
+					Return(nil, internalclient.ErrCertificateRejected)
+			},
+			expected: ErrJumpForbidden,
+		},
+		{
+			description: "fails when the certificate forces a command",
+			key:         cert,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("EvaluateCertificate", gossh.MarshalAuthorizedKey(cert), device, "root", "127.0.0.1:22").
+					Return(&models.CertificateEvaluation{ForceCommand: "uptime"}, nil)
+			},
+			expected: ErrJumpForceCommand,
+		},
+		{
+			description: "succeeds when the certificate is accepted",
+			key:         cert,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("EvaluateCertificate", gossh.MarshalAuthorizedKey(cert), device, "root", "127.0.0.1:22").
+					Return(&models.CertificateEvaluation{}, nil)
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			assert.ErrorIs(t, evaluateJump(api, tc.key, device, "root", "127.0.0.1:22"), tc.expected)
+
+			api.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEvaluateJumpRecord(t *testing.T) {
+	device := &models.Device{UID: "uid", TenantID: "tenant"}
+
+	cases := []struct {
+		description string
+		recorded    bool
+		err         error
+		expected    error
+	}{
+		{
+			description: "fails when the namespace records its sessions",
+			recorded:    true,
+			expected:    ErrJumpRecorded,
+		},
+		{
+			description: "fails when it cannot be checked if the namespace records its sessions",
+			err:         errors.New("error"),
+			expected:    ErrJumpRecorded,
+		},
+		{
+			description: "succeeds when the namespace does not record its sessions",
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			api.On("GetSessionRecord", "tenant").Return(tc.recorded, tc.err)
+
+			assert.ErrorIs(t, evaluateJumpRecord(api, device), tc.expected)
+		})
+	}
+}
+
+func TestPipeJump(t *testing.T) {
+	channel, client := net.Pipe()
+	dialed, device := net.Pipe()
+
+	done := make(chan struct{})
+	go func() {
+		pipeJump(channel, dialed, nil)
+		close(done)
+	}()
+
+	go client.Write([]byte("ping")) //nolint:errcheck
+
+	buffer := make([]byte, 4)
+	_, err := io.ReadFull(device, buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, "ping", string(buffer))
+
+	go device.Write([]byte("pong")) //nolint:errcheck
+
+	_, err = io.ReadFull(client, buffer)
+	assert.NoError(t, err)
+	assert.Equal(t, "pong", string(buffer))
+
+	// When a side stops, the other one is closed too.
+	device.Close()
+	<-done
+
+	_, err = client.Read(buffer)
+	assert.ErrorIs(t, err, io.EOF)
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/httptunnel"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
 	gossh "golang.org/x/crypto/ssh"
 )
@@ -21,25 +22,42 @@ const (
 	DynamicTCPIPChannel = "dynamic-tcpip"
 )
 
+// channelData is the extra data of DirectTCPIPChannel and DynamicTCPIPChannel channels.
+type channelData struct {
+	DestAddr   string
+	DestPort   uint32
+	OriginAddr string
+	OriginPort uint32
+}
+
 // DefaultTCPIPHandler is the default handler for DirectTCPIPChannel and DynamicTCPIPChannel channels.
 //
 // It will reject the channel if the LocalPortForwardingCallback is not set or returns false.
 // Otherwise, it will dial the agent and proxy the channel.
-func DefaultTCPIPHandler(server *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
-	type channelData struct {
-		DestAddr   string
-		DestPort   uint32
-		OriginAddr string
-		OriginPort uint32
-	}
+//
+// In a jump-host connection, the channel is piped straight to the SSH server of the device whose SSHID is the
+// channel's destination instead, through the tunnel.
+func DefaultTCPIPHandler(tunnel *httptunnel.Tunnel) gliderssh.ChannelHandler {
+	return func(server *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
+		data := channelData{}
+		if err := gossh.Unmarshal(newChan.ExtraData(), &data); err != nil {
+			newChan.Reject(gossh.ConnectionFailed, "error parsing forward data: "+err.Error()) //nolint:errcheck
 
-	data := channelData{}
-	if err := gossh.Unmarshal(newChan.ExtraData(), &data); err != nil {
-		newChan.Reject(gossh.ConnectionFailed, "error parsing forward data: "+err.Error()) //nolint:errcheck
+			return
+		}
 
-		return
+		if key := metadata.RestoreJumpKey(ctx); key != nil {
+			jump(ctx, tunnel, newChan, key, data.DestAddr, data.DestPort)
+
+			return
+		}
+
+		forward(server, newChan, ctx, data)
 	}
+}
 
+// forward dials the destination from the agent and proxies the channel to it.
+func forward(server *gliderssh.Server, newChan gossh.NewChannel, ctx gliderssh.Context, data channelData) {
 	if server.LocalPortForwardingCallback == nil || !server.LocalPortForwardingCallback(ctx, data.DestAddr, data.DestPort) {
 		newChan.Reject(gossh.Prohibited, "port forwarding is disabled") //nolint:errcheck
 
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// Errors returned by handlers to client when the host key of the SSH server they connect to cannot be verified.
+var (
+	ErrHostKeyMismatch = fmt.Errorf("the device's SSH host key does not match the one pinned to it, so the connection was refused as it could be intercepted. If the device was re-provisioned, ask an administrator of the namespace to reset its host key")
+	ErrGatewayHostKey  = fmt.Errorf("failed to load the server's host key")
+)
+
+// hostKeyVerifier verifies the SSH host key presented through the tunnel of a device against the one pinned to it.
+type hostKeyVerifier struct {
+	device   *models.Device
+	mismatch bool
+}
+
+func newHostKeyVerifier(device *models.Device) *hostKeyVerifier {
+	return &hostKeyVerifier{device: device}
+}
+
+// Callback is the HostKeyCallback of the connections to the device. A device without a pinned host key, as the ones
+// whose agents do not report it, is trusted.
+func (v *hostKeyVerifier) Callback(_ string, _ net.Addr, key gossh.PublicKey) error {
+	if v.device == nil || v.device.HostKeyFingerprint == "" {
+		return nil
+	}
+
+	if fingerprint := gossh.FingerprintSHA256(key); fingerprint != v.device.HostKeyFingerprint {
+		v.mismatch = true
+
+		log.WithFields(log.Fields{
+			"device":    v.device.UID,
+			"pinned":    v.device.HostKeyFingerprint,
+			"presented": fingerprint,
+		}).Error("device's host key does not match the pinned one")
+
+		return ErrHostKeyMismatch
+	}
+
+	return nil
+}
+
+// Mismatched checks if the host key presented by the device was not the pinned one. The SSH client does not wrap the
+// callback's error, so it cannot be told apart from the connection's error.
+func (v *hostKeyVerifier) Mismatched() bool {
+	return v.mismatch
+}
+
+// gatewayHostKey returns a HostKeyCallback accepting only the host key of the gateway's own SSH server, to which the
+// web terminal's connections are made.
+func gatewayHostKey() (gossh.HostKeyCallback, error) {
+	data, err := os.ReadFile(os.Getenv("PRIVATE_KEY"))
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := gossh.ParsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return gossh.FixedHostKey(signer.PublicKey()), nil
+}
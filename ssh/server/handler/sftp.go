@@ -4,12 +4,18 @@ import (
 	"context"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/ssh/pkg/activity"
 	"github.com/shellhub-io/shellhub/ssh/pkg/flow"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	"github.com/shellhub-io/shellhub/ssh/pkg/metrics"
+	"github.com/shellhub-io/shellhub/ssh/server/auth"
 	"github.com/shellhub-io/shellhub/ssh/session"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
@@ -17,6 +23,9 @@ import (
 
 const SFTPSubsystem = "sftp"
 
+// ErrSubsystemForbidden is returned when the user's certificate forces a command.
+var ErrSubsystemForbidden = fmt.Errorf("failed to open the subsystem as the certificate forces a command")
+
 // SFTPSubsystemHandler handlers a SFTP connection.
 func SFTPSubsystemHandler(tunnel *httptunnel.Tunnel) gliderssh.SubsystemHandler {
 	return func(client gliderssh.Session) {
@@ -31,6 +40,13 @@ func SFTPSubsystemHandler(tunnel *httptunnel.Tunnel) gliderssh.SubsystemHandler
 
 		ctx := client.Context()
 
+		// A command forced by the user's certificate cannot be bypassed through the SFTP subsystem.
+		if metadata.RestoreForceCommand(ctx) != "" {
+			sendAndInformError(client, ErrSubsystemForbidden, ErrSubsystemForbidden)
+
+			return
+		}
+
 		api := metadata.RestoreAPI(ctx)
 
 		sess, err := session.NewSession(client, tunnel)
@@ -41,10 +57,13 @@ func SFTPSubsystemHandler(tunnel *httptunnel.Tunnel) gliderssh.SubsystemHandler
 		}
 
 		defer sess.Finish() // nolint:errcheck
+		defer metrics.SessionStarted(sess.Type)()
+
+		verifier := newHostKeyVerifier(metadata.RestoreDevice(ctx))
 
 		config := &gossh.ClientConfig{ // nolint: exhaustruct
 			User:            sess.Username,
-			HostKeyCallback: gossh.InsecureIgnoreHostKey(), // nolint:gosec
+			HostKeyCallback: verifier.Callback,
 		}
 
 		switch metadata.RestoreAuthenticationMethod(ctx) {
@@ -84,6 +103,13 @@ func SFTPSubsystemHandler(tunnel *httptunnel.Tunnel) gliderssh.SubsystemHandler
 		}
 
 		if err = connectSFTP(ctx, client, sess, api, config); err != nil {
+			switch {
+			case verifier.Mismatched():
+				err = ErrHostKeyMismatch
+			case errors.Is(err, ErrAuthentication):
+				auth.RecordDeviceRejection(ctx, sess.UID)
+			}
+
 			sendAndInformError(client, err, err)
 
 			return
@@ -119,11 +145,18 @@ func connectSFTP(ctx context.Context, client gliderssh.Session, sess *session.Se
 		return err
 	}
 
+	// The SFTP packets are parsed in both directions, recording the files' operations requested by the client and
+	// whose results are returned by the device.
+	recorder := activity.NewRecorder("")
+	defer recordActivity(api, sess.UID, recorder)
+
+	parser := activity.NewSFTP(recorder)
+
 	done := make(chan bool)
 
-	go flw.PipeIn(client, done)
-	go flw.PipeOut(client, done)
-	go flw.PipeErr(client, done)
+	go flw.PipeIn(io.TeeReader(client, parser.Requests()), done)
+	go flw.PipeOut(io.MultiWriter(client, parser.Responses()), done)
+	go flw.PipeErr(io.MultiWriter(client, &recorder.Stderr), done)
 
 	<-done
 	<-done
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/shellhub-io/shellhub/ssh/pkg/hub"
+	"github.com/shellhub-io/shellhub/ssh/web/pkg/cache"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+)
+
+var (
+	ErrShadowToken   = errors.New("failed to get the session shadow from the token")
+	ErrShadowSession = errors.New("failed to join the session")
+)
+
+// ShadowSession is the handler for a namespace's member joining an active session through a websocket.
+//
+// It receives the session's UID as path param and the token of the session shadow, granted by the API, as query
+// param. The session's output is written to the websocket and, when the shadow is writable, the websocket's input is
+// typed on the session.
+func ShadowSession(socket *websocket.Conn) {
+	uid := mux.Vars(socket.Request())["uid"]
+
+	shadow, err := cache.RedeemShadow(socket.Request().Context(), socket.Request().URL.Query().Get("token"))
+	if err != nil || shadow.UID != uid {
+		if err == nil {
+			err = errors.New("the session shadow was granted to another session")
+		}
+
+		sendAndInformError(socket, err, ErrShadowToken)
+
+		return
+	}
+
+	session, err := hub.DefaultHub.Lookup(uid)
+	if err != nil {
+		sendAndInformError(socket, err, ErrShadowSession)
+
+		return
+	}
+
+	viewer, err := session.Join(shadow.Username, shadow.Writable)
+	if err != nil {
+		sendAndInformError(socket, err, ErrShadowSession)
+
+		return
+	}
+
+	defer viewer.Leave()
+
+	log.WithFields(log.Fields{
+		"session":  uid,
+		"username": shadow.Username,
+		"writable": shadow.Writable,
+	}).Info("member joined the session")
+
+	go func() {
+		// The websocket is read even when the viewer cannot type, so its closing is noticed.
+		buffer := make([]byte, 1024)
+		for {
+			read, err := socket.Read(buffer)
+			if err != nil {
+				viewer.Leave()
+
+				return
+			}
+
+			if _, err := viewer.Write(buffer[:read]); err != nil && !errors.Is(err, hub.ErrReadOnly) {
+				return
+			}
+		}
+	}()
+
+	conn := &wsconn{
+		pinger: time.NewTicker(pingInterval),
+	}
+
+	defer conn.pinger.Stop()
+
+	go conn.keepAlive(socket)
+
+	for chunk := range viewer.Output() {
+		if _, err := socket.Write(chunk); err != nil {
+			return
+		}
+	}
+}
@@ -18,8 +18,13 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
 	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/activity"
 	"github.com/shellhub-io/shellhub/ssh/pkg/flow"
+	"github.com/shellhub-io/shellhub/ssh/pkg/hub"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	"github.com/shellhub-io/shellhub/ssh/pkg/metrics"
+	"github.com/shellhub-io/shellhub/ssh/server/auth"
+	"github.com/shellhub-io/shellhub/ssh/server/channels"
 	"github.com/shellhub-io/shellhub/ssh/session"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
@@ -55,6 +60,7 @@ var (
 	ErrShell              = fmt.Errorf("failed to get the shell to agent")
 	ErrTarget             = fmt.Errorf("failed to get client target")
 	ErrAuthentication     = fmt.Errorf("failed to authenticate to device")
+	ErrForceCommand       = fmt.Errorf("failed to exec the command forced by the certificate in the device")
 )
 
 // sendAndInformError sends the external error to client and log the internal one to server.
@@ -92,6 +98,7 @@ func SSHHandler(tunnel *httptunnel.Tunnel) gliderssh.Handler {
 		}
 
 		defer sess.Finish() // nolint: errcheck
+		defer metrics.SessionStarted(sess.Type)()
 
 		if wh := webhook.NewClient(); wh != nil {
 			res, err := wh.Connect(sess.Lookup)
@@ -117,9 +124,11 @@ func SSHHandler(tunnel *httptunnel.Tunnel) gliderssh.Handler {
 			return
 		}
 
+		verifier := newHostKeyVerifier(metadata.RestoreDevice(ctx))
+
 		config := &gossh.ClientConfig{ // nolint: exhaustruct
 			User:            sess.Username,
-			HostKeyCallback: gossh.InsecureIgnoreHostKey(), // nolint: gosec
+			HostKeyCallback: verifier.Callback,
 		}
 
 		switch metadata.RestoreAuthenticationMethod(ctx) {
@@ -160,6 +169,13 @@ func SSHHandler(tunnel *httptunnel.Tunnel) gliderssh.Handler {
 
 		err = connectSSH(ctx, client, sess, config, api, opts)
 		if err != nil {
+			switch {
+			case verifier.Mismatched():
+				err = ErrHostKeyMismatch
+			case errors.Is(err, ErrAuthentication):
+				auth.RecordDeviceRejection(ctx, sess.UID)
+			}
+
 			sendAndInformError(client, err, err)
 
 			return
@@ -184,11 +200,50 @@ func connectSSH(ctx context.Context, client gliderssh.Session, sess *session.Ses
 
 	defer agent.Close()
 
+	// The client's SSH agent is only forwarded when its namespace and its user certificate, if any, allow, as it lets
+	// the device authenticate as the client to other servers while the session lasts.
+	if gliderssh.AgentRequested(client) {
+		device := metadata.RestoreDevice(ctx.(gliderssh.Context))
+
+		if !metadata.RestorePermit(ctx.(gliderssh.Context), metadata.ExtensionPermitAgentForwarding) {
+			log.WithFields(log.Fields{
+				"sshid": client.User(),
+			}).Info("agent forwarding is not permitted by the user certificate")
+		} else if allowed, err := api.EvaluateAgentForwarding(device.TenantID); err != nil || !allowed {
+			log.WithError(err).WithFields(log.Fields{
+				"sshid": client.User(),
+			}).Info("agent forwarding is not allowed for the namespace")
+		} else if err := channels.ForwardAgent(client, connection, agent); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"sshid": client.User(),
+			}).Warn("failed to forward the agent to the device")
+		}
+	}
+
 	go session.HandleRequests(ctx, reqs, api, ctx.Done())
 
 	metadata.MaybeStoreEstablished(ctx.(gliderssh.Context), true)
 
-	pty, winCh, _ := client.Pty()
+	pty, winCh, isPty := client.Pty()
+
+	// The namespace's timeouts close the session when it is idle or lasting for too long.
+	client, unwatch := watchSession(api, sess, client, connection, isPty)
+	defer unwatch()
+
+	// A command forced by the user's certificate replaces the shell or the command requested by the client.
+	if command := metadata.RestoreForceCommand(ctx.(gliderssh.Context)); command != "" {
+		if isPty {
+			if err := agent.RequestPty(pty.Term, pty.Window.Height, pty.Window.Width, gossh.TerminalModes{}); err != nil {
+				return ErrPty
+			}
+		}
+
+		if err := exec(api, sess.UID, agent, client, command); err != nil {
+			return ErrForceCommand
+		}
+
+		return nil
+	}
 
 	switch sess.GetType() {
 	case session.Term, session.Web:
@@ -202,7 +257,7 @@ func connectSSH(ctx context.Context, client gliderssh.Session, sess *session.Ses
 			return ErrRequestHeredoc
 		}
 	case session.Exec, session.SCP:
-		err := exec(api, sess.UID, agent, client)
+		err := exec(api, sess.UID, agent, client, client.RawCommand())
 		if err != nil {
 			return ErrRequestExec
 		}
@@ -260,6 +315,10 @@ func shell(api internalclient.Client, sess *session.Session, uid string, agent *
 		return err
 	}
 
+	// The shell can be joined by the namespace's members, who receive its output and, when allowed, type on it.
+	shared := hub.DefaultHub.Open(uid, flw.Stdin, client)
+	defer shared.Close()
+
 	done := make(chan bool)
 
 	go flw.PipeIn(client, done)
@@ -280,6 +339,8 @@ func shell(api internalclient.Client, sess *session.Session, uid string, agent *
 				break
 			}
 
+			shared.Write(buffer[:read]) //nolint:errcheck
+
 			if envs.IsEnterprise() || envs.IsCloud() {
 				message := string(buffer[:read])
 
@@ -337,11 +398,14 @@ func heredoc(api internalclient.Client, uid string, agent *gossh.Session, client
 		return err
 	}
 
+	recorder := activity.NewRecorder("")
+	defer recordActivity(api, uid, recorder)
+
 	done := make(chan bool)
 
-	go flw.PipeIn(client, nil)
-	go flw.PipeOut(client, done)
-	go flw.PipeErr(client, nil)
+	go flw.PipeIn(io.TeeReader(client, &recorder.Stdin), nil)
+	go flw.PipeOut(io.MultiWriter(client, &recorder.Stdout), done)
+	go flw.PipeErr(io.MultiWriter(client, &recorder.Stderr), nil)
 
 	go func() {
 		// When agent stop to send data, it means that the command has finished and the process should be closed.
@@ -365,13 +429,16 @@ func heredoc(api internalclient.Client, uid string, agent *gossh.Session, client
 		}).Warning("command on agent returned an error")
 	}
 
-	client.Exit(exitCodeFromError(err)) // nolint:errcheck
+	code := exitCodeFromError(err)
+	recorder.Exit(code)
+
+	client.Exit(code) // nolint:errcheck
 
 	return nil
 }
 
-// exec handles a non-interactive session.
-func exec(api internalclient.Client, uid string, agent *gossh.Session, client gliderssh.Session) error {
+// exec handles a non-interactive session, executing command on the agent.
+func exec(api internalclient.Client, uid string, agent *gossh.Session, client gliderssh.Session, command string) error {
 	if errs := api.SessionAsAuthenticated(uid); len(errs) > 0 {
 		return errs[0]
 	}
@@ -385,12 +452,15 @@ func exec(api internalclient.Client, uid string, agent *gossh.Session, client gl
 		return err
 	}
 
+	recorder := activity.NewRecorder(command)
+	defer recordActivity(api, uid, recorder)
+
 	waitPipeIn := make(chan bool)
 	waitPipeOut := make(chan bool)
 
-	go flw.PipeIn(client, waitPipeIn)
-	go flw.PipeOut(client, waitPipeOut)
-	go flw.PipeErr(client, nil)
+	go flw.PipeIn(io.TeeReader(client, &recorder.Stdin), waitPipeIn)
+	go flw.PipeOut(io.MultiWriter(client, &recorder.Stdout), waitPipeOut)
+	go flw.PipeErr(io.MultiWriter(client, &recorder.Stderr), nil)
 
 	go func() {
 		// When the client stop to send data, it means that the command has finished and the process should be closed.
@@ -399,10 +469,10 @@ func exec(api internalclient.Client, uid string, agent *gossh.Session, client gl
 		agent.Close()
 	}()
 
-	if err := agent.Start(client.RawCommand()); err != nil {
+	if err := agent.Start(command); err != nil {
 		log.WithError(err).WithFields(log.Fields{
 			"client":  uid,
-			"command": client.RawCommand(),
+			"command": command,
 		}).Error("failed to start a command on agent")
 
 		return err
@@ -414,11 +484,23 @@ func exec(api internalclient.Client, uid string, agent *gossh.Session, client gl
 	if err != nil {
 		log.WithError(err).WithFields(log.Fields{
 			"client":  uid,
-			"command": client.RawCommand(),
+			"command": command,
 		}).Warning("command on agent returned an error")
 	}
 
-	client.Exit(exitCodeFromError(err)) // nolint:errcheck
+	code := exitCodeFromError(err)
+	recorder.Exit(code)
+
+	client.Exit(code) // nolint:errcheck
 
 	return nil
 }
+
+// recordActivity sends the activity recorded in a non-interactive session to the API.
+func recordActivity(api internalclient.Client, uid string, recorder *activity.Recorder) {
+	if err := api.RecordSessionActivity(uid, recorder.Activity()); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"client": uid,
+		}).Error("failed to record the session activity")
+	}
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"fmt"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	"github.com/shellhub-io/shellhub/ssh/pkg/watchdog"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// watchedSession is a client's session whose activity restarts the idle timeout of its namespace. In an interactive
+// session, only the client's input is an activity, as a command continuously writing to the terminal does not mean
+// that someone is using it.
+type watchedSession struct {
+	gliderssh.Session
+	watchdog    *watchdog.Watchdog
+	interactive bool
+}
+
+func (s *watchedSession) Read(p []byte) (int, error) {
+	read, err := s.Session.Read(p)
+	if read > 0 {
+		s.watchdog.Touch()
+	}
+
+	return read, err
+}
+
+func (s *watchedSession) Write(p []byte) (int, error) {
+	if !s.interactive {
+		s.watchdog.Touch()
+	}
+
+	return s.Session.Write(p)
+}
+
+// watchSession closes the connection to the device when the session exceeds the idle timeout or the maximum duration
+// of its namespace, recording the closure reason. It returns the client to be used by the session and a function to
+// stop watching it.
+//
+// When the timeouts cannot be got from the API, the session is not limited.
+func watchSession(api internalclient.Client, sess *session.Session, client gliderssh.Session, connection *gossh.Client, interactive bool) (gliderssh.Session, func()) {
+	device := metadata.RestoreDevice(client.Context())
+
+	timeouts, err := api.GetSessionTimeouts(device.TenantID)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"session": sess.UID,
+		}).Warn("failed to get the namespace's session timeouts")
+
+		return client, func() {}
+	}
+
+	warn := func(message string) {
+		if interactive {
+			fmt.Fprintf(client, "\r\n\x1b[1m[ShellHub] %s\x1b[0m\r\n", message) //nolint:errcheck
+
+			return
+		}
+
+		fmt.Fprintf(client.Stderr(), "[ShellHub] %s\n", message) //nolint:errcheck
+	}
+
+	expire := func(reason string) {
+		log.WithFields(log.Fields{
+			"session": sess.UID,
+			"reason":  reason,
+		}).Info("closing the session as it exceeded a namespace's timeout")
+
+		if err := api.SetSessionClosure(sess.UID, reason); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"session": sess.UID,
+			}).Error("failed to record the session's closure reason")
+		}
+
+		connection.Close() // nolint:errcheck
+	}
+
+	dog := watchdog.New(timeouts, warn, expire)
+	if dog == nil {
+		return client, func() {}
+	}
+
+	go dog.Run()
+
+	return &watchedSession{Session: client, watchdog: dog, interactive: interactive}, dog.Stop
+}
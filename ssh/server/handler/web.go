@@ -171,10 +171,17 @@ func WebSession(socket *websocket.Conn, input *web.Session) {
 		return
 	}
 
+	hostKey, err := gatewayHostKey()
+	if err != nil {
+		sendAndInformError(socket, err, ErrGatewayHostKey)
+
+		return
+	}
+
 	connection, err := ssh.Dial("tcp", "localhost:2222", &ssh.ClientConfig{ //nolint: exhaustruct
 		User:            data.User,
 		Auth:            auth,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+		HostKeyCallback: hostKey,
 	})
 	if err != nil {
 		sendAndInformError(socket, err, ErrDialSSH)
@@ -0,0 +1,241 @@
+// Package requests handles the global requests sent by a ShellHub client to the Connect server.
+package requests
+
+import (
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	// TCPIPForwardRequest is the global request type to start a remote port forwarding.
+	// e.g. `ssh -R 8080:localhost:80 user@sshid`.
+	TCPIPForwardRequest = "tcpip-forward"
+	// CancelTCPIPForwardRequest is the global request type to stop a remote port forwarding.
+	CancelTCPIPForwardRequest = "cancel-tcpip-forward"
+	// ForwardedTCPIPChannel is the channel type opened for each connection accepted by a remote port forwarding.
+	ForwardedTCPIPChannel = "forwarded-tcpip"
+)
+
+// forwardRequest is the payload of both TCPIPForwardRequest and CancelTCPIPForwardRequest requests.
+type forwardRequest struct {
+	BindAddr string
+	BindPort uint32
+}
+
+// forwardedChannelData is the extra data of a ForwardedTCPIPChannel channel.
+type forwardedChannelData struct {
+	DestAddr   string
+	DestPort   uint32
+	OriginAddr string
+	OriginPort uint32
+}
+
+// forwardsKey is the context key to store and restore the connection's forwards.
+type forwardsKey struct{}
+
+// forwards are the addresses bound on the agent by the remote port forwardings of a client's connection.
+type forwards struct {
+	mu    sync.Mutex
+	binds map[string]struct{}
+}
+
+func (f *forwards) add(bind string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.binds[bind] = struct{}{}
+}
+
+func (f *forwards) remove(bind string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.binds, bind)
+}
+
+func (f *forwards) has(bind string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.binds[bind]
+
+	return ok
+}
+
+// ReversePortForwardingCallback checks if the namespace's policy allows the device to bind port for a remote port
+// forwarding. Only the device's loopback addresses can be bound, and only when the user certificate the connection
+// authenticated with, if any, permits the port forwarding.
+func ReversePortForwardingCallback(ctx gliderssh.Context, host string, port uint32) bool {
+	device := metadata.RestoreDevice(ctx)
+	api := metadata.RestoreAPI(ctx)
+	if device == nil || api == nil || !models.IsLoopbackBind(host) {
+		return false
+	}
+
+	if !metadata.RestorePermit(ctx, metadata.ExtensionPermitPortForwarding) {
+		return false
+	}
+
+	allowed, err := api.EvaluateReverseForwarding(device.TenantID, port)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"tenant": device.TenantID,
+			"host":   host,
+			"port":   port,
+		}).Error("failed to evaluate the remote port forwarding")
+
+		return false
+	}
+
+	return allowed
+}
+
+// ForwardedTCPIPHandler handles TCPIPForwardRequest and CancelTCPIPForwardRequest requests.
+//
+// It will reject the request if the ReversePortForwardingCallback is not set or returns false. Otherwise, the request
+// is relayed to the agent, which listens on the device and opens a ForwardedTCPIPChannel for each accepted connection.
+// These channels are relayed back to the client through its connection.
+//
+// The connection to the agent is only established when the client opens a session, so the request waits up to
+// timeout for it. It means that a forwarding without a session, e.g. `ssh -N -R`, is rejected.
+func ForwardedTCPIPHandler(timeout time.Duration) gliderssh.RequestHandler {
+	return func(ctx gliderssh.Context, srv *gliderssh.Server, req *gossh.Request) (bool, []byte) {
+		var data forwardRequest
+		if err := gossh.Unmarshal(req.Payload, &data); err != nil {
+			return false, nil
+		}
+
+		if req.Type == TCPIPForwardRequest {
+			if srv.ReversePortForwardingCallback == nil || !srv.ReversePortForwardingCallback(ctx, data.BindAddr, data.BindPort) {
+				return false, nil
+			}
+		}
+
+		agent := waitAgent(ctx, timeout)
+		if agent == nil {
+			return false, nil
+		}
+
+		fwds := restoreForwards(ctx, agent)
+		bind := net.JoinHostPort(data.BindAddr, strconv.FormatUint(uint64(data.BindPort), 10))
+
+		// The bind is added before the request is relayed, as the agent may open a channel as soon as it listens.
+		if req.Type == TCPIPForwardRequest {
+			fwds.add(bind)
+		}
+
+		ok, payload, err := agent.SendRequest(req.Type, true, req.Payload)
+		if err != nil || !ok {
+			if req.Type == TCPIPForwardRequest {
+				fwds.remove(bind)
+			}
+
+			return false, nil
+		}
+
+		if req.Type == CancelTCPIPForwardRequest {
+			fwds.remove(bind)
+		}
+
+		return true, payload
+	}
+}
+
+// waitAgent waits up to timeout for the connection between server and agent to be established, returning nil when it
+// is not.
+func waitAgent(ctx gliderssh.Context, timeout time.Duration) *gossh.Client {
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	deadline := time.After(timeout)
+
+	for {
+		if metadata.RestoreEstablished(ctx) {
+			return metadata.RestoreAgent(ctx)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-deadline:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// restoreForwards restores the connection's forwards from the context. On the first call, the forwards are created and
+// the agent's ForwardedTCPIPChannel channels start to be relayed to the client.
+//
+// The requests of a connection are handled one at a time, so there is no race to create them.
+func restoreForwards(ctx gliderssh.Context, agent *gossh.Client) *forwards {
+	if value, ok := ctx.Value(forwardsKey{}).(*forwards); ok {
+		return value
+	}
+
+	fwds := &forwards{binds: make(map[string]struct{})}
+	ctx.SetValue(forwardsKey{}, fwds)
+
+	conn := ctx.Value(gliderssh.ContextKeyConn).(*gossh.ServerConn)
+
+	go func() {
+		for newChan := range agent.HandleChannelOpen(ForwardedTCPIPChannel) {
+			var data forwardedChannelData
+			if err := gossh.Unmarshal(newChan.ExtraData(), &data); err != nil {
+				newChan.Reject(gossh.ConnectionFailed, "error parsing forward data: "+err.Error()) //nolint:errcheck
+
+				continue
+			}
+
+			if !fwds.has(net.JoinHostPort(data.DestAddr, strconv.FormatUint(uint64(data.DestPort), 10))) {
+				newChan.Reject(gossh.Prohibited, "no forwarding for the address") //nolint:errcheck
+
+				continue
+			}
+
+			go relay(conn, newChan)
+		}
+	}()
+
+	return fwds
+}
+
+// relay opens a ForwardedTCPIPChannel to the client and proxies the agent's channel to it.
+func relay(conn *gossh.ServerConn, newChan gossh.NewChannel) {
+	client, clientReqs, err := conn.OpenChannel(ForwardedTCPIPChannel, newChan.ExtraData())
+	if err != nil {
+		newChan.Reject(gossh.ConnectionFailed, "error opening the channel to the client: "+err.Error()) //nolint:errcheck
+
+		return
+	}
+
+	agent, agentReqs, err := newChan.Accept()
+	if err != nil {
+		client.Close()
+
+		return
+	}
+
+	go gossh.DiscardRequests(clientReqs)
+	go gossh.DiscardRequests(agentReqs)
+
+	go func() {
+		defer client.Close()
+		defer agent.Close()
+		io.Copy(client, agent) //nolint:errcheck
+	}()
+	go func() {
+		defer client.Close()
+		defer agent.Close()
+		io.Copy(agent, client) //nolint:errcheck
+	}()
+}
@@ -9,10 +9,13 @@ import (
 	"github.com/pires/go-proxyproto"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
+	"github.com/shellhub-io/shellhub/ssh/pkg/metrics"
 	"github.com/shellhub-io/shellhub/ssh/server/auth"
 	"github.com/shellhub-io/shellhub/ssh/server/channels"
 	"github.com/shellhub-io/shellhub/ssh/server/handler"
+	"github.com/shellhub-io/shellhub/ssh/server/requests"
 	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 type Options struct {
@@ -34,9 +37,27 @@ func NewServer(opts *Options, tunnel *httptunnel.Tunnel) *Server {
 	}
 
 	server.sshd = &gliderssh.Server{ // nolint: exhaustruct
-		Addr:             ":2222",
-		PasswordHandler:  auth.PasswordHandler,
-		PublicKeyHandler: auth.PublicKeyHandler,
+		Addr: ":2222",
+		ConnCallback: func(ctx gliderssh.Context, conn net.Conn) net.Conn {
+			go auth.ReportFailure(ctx)
+
+			return conn
+		},
+		PasswordHandler: func(ctx gliderssh.Context, password string) bool {
+			ok := auth.PasswordHandler(ctx, password)
+			metrics.ObserveAuth("password", ok)
+
+			return ok
+		},
+		// The public key authentication is set in the server's configuration, instead of by a PublicKeyHandler, so
+		// each key is granted its own permissions.
+		ServerConfigCallback: func(ctx gliderssh.Context) *gossh.ServerConfig {
+			return &gossh.ServerConfig{ // nolint: exhaustruct
+				PublicKeyCallback: auth.PublicKeyCallback(ctx, func(ok bool) {
+					metrics.ObserveAuth("publickey", ok)
+				}),
+			}
+		},
 		SessionRequestCallback: func(client gliderssh.Session, request string) bool {
 			metadata.StoreRequest(client.Context(), request)
 
@@ -46,16 +67,21 @@ func NewServer(opts *Options, tunnel *httptunnel.Tunnel) *Server {
 		SubsystemHandlers: map[string]gliderssh.SubsystemHandler{
 			handler.SFTPSubsystem: handler.SFTPSubsystemHandler(tunnel),
 		},
+		PtyCallback: func(ctx gliderssh.Context, pty gliderssh.Pty) bool {
+			return metadata.RestorePermit(ctx, metadata.ExtensionPermitPTY)
+		},
 		LocalPortForwardingCallback: func(ctx gliderssh.Context, dhost string, dport uint32) bool {
-			return true
+			return metadata.RestorePermit(ctx, metadata.ExtensionPermitPortForwarding)
 		},
-		ReversePortForwardingCallback: func(ctx gliderssh.Context, bindHost string, bindPort uint32) bool {
-			return false
+		ReversePortForwardingCallback: requests.ReversePortForwardingCallback,
+		RequestHandlers: map[string]gliderssh.RequestHandler{
+			requests.TCPIPForwardRequest:       requests.ForwardedTCPIPHandler(opts.ConnectTimeout),
+			requests.CancelTCPIPForwardRequest: requests.ForwardedTCPIPHandler(opts.ConnectTimeout),
 		},
 		ChannelHandlers: map[string]gliderssh.ChannelHandler{
-			"session":                    gliderssh.DefaultSessionHandler,
-			channels.DirectTCPIPChannel:  channels.DefaultTCPIPHandler,
-			channels.DynamicTCPIPChannel: channels.DefaultTCPIPHandler,
+			"session":                    channels.SessionHandler,
+			channels.DirectTCPIPChannel:  channels.DefaultTCPIPHandler(tunnel),
+			channels.DynamicTCPIPChannel: channels.DefaultTCPIPHandler(tunnel),
 		},
 	}
 
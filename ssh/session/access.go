@@ -0,0 +1,81 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// AccessPollInterval is how often a session waiting for its access request to be decided checks it.
+const AccessPollInterval = 2 * time.Second
+
+// Errors returned by the NewSession to the client when its connection to a device with a protected tag is not approved.
+var (
+	ErrAccessRequest = fmt.Errorf("failed to request the access to the device")
+	ErrAccessDenied  = fmt.Errorf("you cannot connect to this device because your access request was denied")
+	ErrAccessExpired = fmt.Errorf("you cannot connect to this device because your access request was not approved in time")
+)
+
+// waitAccess requests the access of a session to a device and, when the device has any of its namespace's protected
+// tags, waits for the request to be decided, informing the client through w, when it is not nil. It returns the
+// approved request, or nil when the connection does not need to be approved.
+func waitAccess(ctx context.Context, api internalclient.Client, req request.AccessRequestCreate, w io.Writer) (*models.AccessRequest, error) {
+	access, err := api.CreateAccessRequest(req)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"session": req.SessionUID,
+			"device":  req.DeviceUID,
+		}).Error("failed to request the access to the device")
+
+		return nil, ErrAccessRequest
+	}
+
+	if access == nil || access.Status == models.AccessRequestApproved {
+		return access, nil
+	}
+
+	if w != nil {
+		fmt.Fprintf(w, "This device requires an administrator to approve your connection. Waiting up to %s for the approval of the request %s\n", models.AccessRequestTimeout, access.ID) // nolint:errcheck
+	}
+
+	timeout := time.NewTimer(models.AccessRequestTimeout)
+	defer timeout.Stop()
+
+	ticker := time.NewTicker(AccessPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout.C:
+			return nil, ErrAccessExpired
+		case <-ticker.C:
+		}
+
+		decided, err := api.GetAccessRequest(access.ID)
+		if err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"session": req.SessionUID,
+				"request": access.ID,
+			}).Warn("failed to get the access request")
+
+			continue
+		}
+
+		switch decided.Status {
+		case models.AccessRequestApproved:
+			return decided, nil
+		case models.AccessRequestDenied:
+			return nil, ErrAccessDenied
+		case models.AccessRequestExpired:
+			return nil, ErrAccessExpired
+		}
+	}
+}
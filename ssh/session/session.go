@@ -12,9 +12,12 @@ import (
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/go-resty/resty/v2"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/request"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
 	"github.com/shellhub-io/shellhub/ssh/pkg/host"
 	"github.com/shellhub-io/shellhub/ssh/pkg/metadata"
 	log "github.com/sirupsen/logrus"
@@ -43,6 +46,8 @@ type Session struct {
 	Type          string `json:"type"`
 	Term          string `json:"term"`
 	Authenticated bool   `json:"authenticated"`
+	// AccessRequest is the ID of the request approving the session's connection to a device with a protected tag.
+	AccessRequest string `json:"access_request,omitempty"` // nolint: tagliatelle
 	Lookup        map[string]string
 	Pty           bool
 	Dialed        net.Conn
@@ -55,6 +60,7 @@ const (
 	HereDoc = "heredoc" // heredoc pty.
 	SCP     = "scp"     // scp.
 	SFTP    = "sftp"    // sftp subsystem.
+	Jump    = "jump"    // jump host to the device's SSH server.
 	Unk     = "unknown" // unknown.
 )
 
@@ -131,28 +137,20 @@ func NewSession(client gliderssh.Session, tunnel *httptunnel.Tunnel) (*Session,
 	lookup["username"] = tag.Username
 	lookup["ip_address"] = hos.Host
 
-	if envs.IsCloud() || envs.IsEnterprise() {
-		if err := api.FirewallEvaluate(lookup); err != nil {
-			switch {
-			case errors.Is(err, internalclient.ErrFirewallConnection):
-				return nil, ErrFirewallConnection
-			case errors.Is(err, internalclient.ErrFirewallBlock):
-				return nil, ErrFirewallBlock
-			default:
-				return nil, ErrFirewallUnknown
-			}
-		}
+	if err := evaluate(api, device, lookup); err != nil {
+		return nil, err
 	}
 
-	if envs.IsCloud() && envs.HasBilling() {
-		device, err := api.GetDevice(device.UID)
-		if err != nil {
-			return nil, ErrFindDevice
-		}
+	uid := client.Context().Value(gliderssh.ContextKeySessionID).(string) //nolint:forcetypeassert
 
-		if _, status, _ := api.BillingEvaluate(device.TenantID); status != 200 && status != 402 {
-			return nil, ErrBillingBlock
-		}
+	access, err := waitAccess(client.Context(), api, request.AccessRequestCreate{
+		DeviceUID:  device.UID,
+		SessionUID: uid,
+		Username:   tag.Username,
+		IPAddress:  hos.Host,
+	}, client.Stderr())
+	if err != nil {
+		return nil, err
 	}
 
 	dialed, err := tunnel.Dial(client.Context(), device.UID)
@@ -160,9 +158,11 @@ func NewSession(client gliderssh.Session, tunnel *httptunnel.Tunnel) (*Session,
 		return nil, ErrDial
 	}
 
-	uid := client.Context().Value(gliderssh.ContextKeySessionID).(string) //nolint:forcetypeassert
-
 	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/ssh/%s", uid), nil)
+	// The session's user and address are informed to the agent, which lists them through its control socket.
+	req.Header.Set("X-Username", tag.Username)
+	req.Header.Set("X-Real-Ip", hos.Host)
+
 	if err = req.Write(dialed); err != nil {
 		return nil, err
 	}
@@ -177,6 +177,10 @@ func NewSession(client gliderssh.Session, tunnel *httptunnel.Tunnel) (*Session,
 		Dialed:    dialed,
 	}
 
+	if access != nil {
+		session.AccessRequest = access.ID
+	}
+
 	handlePty(session)
 
 	session.Register(client) // nolint:errcheck
@@ -184,6 +188,99 @@ func NewSession(client gliderssh.Session, tunnel *httptunnel.Tunnel) (*Session,
 	return session, nil
 }
 
+// NewJump creates a session for a channel of a jump-host connection, which is piped straight to the device's SSH server
+// through the session's Dialed connection. The SSH session inside the channel is authenticated by the device itself.
+func NewJump(ctx gliderssh.Context, tunnel *httptunnel.Tunnel, device *models.Device, lookup map[string]string, username string) (*Session, error) {
+	hos, err := host.NewHost(ctx.RemoteAddr().String())
+	if err != nil {
+		return nil, ErrHost
+	}
+
+	api := metadata.RestoreAPI(ctx)
+	lookup["username"] = username
+	lookup["ip_address"] = hos.Host
+
+	if err := evaluate(api, device, lookup); err != nil {
+		return nil, err
+	}
+
+	// A jump-host connection can open many channels, so each one of them is a session with its own UID.
+	uid := uuid.Generate()
+
+	// The channel is not accepted yet, so the client cannot be informed that it is waiting for the approval.
+	access, err := waitAccess(ctx, api, request.AccessRequestCreate{
+		DeviceUID:  device.UID,
+		SessionUID: uid,
+		Username:   username,
+		IPAddress:  hos.Host,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	dialed, err := tunnel.Dial(ctx, device.UID)
+	if err != nil {
+		return nil, ErrDial
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("/ssh/%s", uid), nil)
+	req.Header.Set("X-Username", username)
+	req.Header.Set("X-Real-Ip", hos.Host)
+
+	if err = req.Write(dialed); err != nil {
+		dialed.Close()
+
+		return nil, err
+	}
+
+	session := &Session{ //nolint:exhaustruct
+		UID:       uid,
+		Username:  username,
+		IPAddress: hos.Host,
+		Device:    device.UID,
+		Type:      Jump,
+		Lookup:    lookup,
+		Dialed:    dialed,
+	}
+
+	if access != nil {
+		session.AccessRequest = access.ID
+	}
+
+	session.Register(nil) // nolint:errcheck
+
+	return session, nil
+}
+
+// evaluate checks if the firewall rules and the billing of the device's namespace allow the connection.
+func evaluate(api internalclient.Client, device *models.Device, lookup map[string]string) error {
+	if envs.IsCloud() || envs.IsEnterprise() {
+		if err := api.FirewallEvaluate(lookup); err != nil {
+			switch {
+			case errors.Is(err, internalclient.ErrFirewallConnection):
+				return ErrFirewallConnection
+			case errors.Is(err, internalclient.ErrFirewallBlock):
+				return ErrFirewallBlock
+			default:
+				return ErrFirewallUnknown
+			}
+		}
+	}
+
+	if envs.IsCloud() && envs.HasBilling() {
+		device, err := api.GetDevice(device.UID)
+		if err != nil {
+			return ErrFindDevice
+		}
+
+		if _, status, _ := api.BillingEvaluate(device.TenantID); status != 200 && status != 402 {
+			return ErrBillingBlock
+		}
+	}
+
+	return nil
+}
+
 func (s *Session) GetType() string {
 	return s.Type
 }
@@ -227,7 +324,8 @@ func (s *Session) Register(_ gliderssh.Session) error {
 }
 
 func (s *Session) Finish() error {
-	if s.Dialed != nil {
+	// The connection of a jump session is the one to the device's SSH server, which is closed with the piped channel.
+	if s.Dialed != nil && s.Type != Jump {
 		request, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/ssh/close/%s", s.UID), nil)
 
 		if err := request.Write(s.Dialed); err != nil {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/ssh/web/pkg/token"
 )
 
@@ -107,3 +108,29 @@ func Restore(ctx context.Context, token *token.Token) (*Data, error) {
 		Signature:   value.Signature,
 	}, nil
 }
+
+// RedeemShadow gets the session shadow granted by the API to token, removing it from the cache so it cannot be used
+// again.
+func RedeemShadow(ctx context.Context, token string) (*models.SessionShadow, error) {
+	connection, err := getConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	key := models.SessionShadowCacheKey(token)
+
+	var shadow models.SessionShadow
+	if err := connection.Get(ctx, key, &shadow); err != nil {
+		return nil, err
+	}
+
+	if shadow.Token == "" {
+		return nil, errors.New("session shadow not found")
+	}
+
+	if err := connection.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+
+	return &shadow, nil
+}